@@ -0,0 +1,259 @@
+package openproject
+
+import "context"
+
+// API gives access to the services of a client through interfaces, so code depending on them
+// can be tested without OpenProject, i.e. with the mocks of the openprojectmock package:
+//
+//	func CloseAll(api openproject.API, projectID string) error {
+//		workPackages, _, err := api.WorkPackages().GetList(...)
+//		...
+//	}
+//
+// Client implements API
+type API interface {
+	Auth() AuthenticationAPI
+	WorkPackages() WorkPackageAPI
+	Projects() ProjectAPI
+	Users() UserAPI
+	Statuses() StatusAPI
+	WikiPages() WikiPageAPI
+	Attachments() AttachmentAPI
+	Categories() CategoryAPI
+	Queries() QueryAPI
+	Groups() GroupAPI
+	Principals() PrincipalAPI
+	PlaceholderUsers() PlaceholderUserAPI
+}
+
+// AuthenticationAPI is the method set of AuthenticationService
+type AuthenticationAPI interface {
+	AcquireSessionCookie(username, password string) (bool, error)
+	AcquireSessionCookieWithContext(ctx context.Context, username, password string) (bool, error)
+	Authenticated() bool
+	GetCurrentUser() (*User, *Response, error)
+	GetCurrentUserWithContext(ctx context.Context) (*User, *Response, error)
+	Logout() error
+	LogoutWithContext(ctx context.Context) error
+	SetAPIKey(apiKey string)
+	SetBasicAuth(username, password string)
+}
+
+// WorkPackageAPI is the method set of WorkPackageService
+type WorkPackageAPI interface {
+	BulkCopy(workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error)
+	BulkCopyWithContext(ctx context.Context, workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error)
+	BulkCreate(workPackages []*WorkPackage, projectName string, options *BulkOptions) (*BulkReport, error)
+	BulkCreateWithContext(ctx context.Context, workPackages []*WorkPackage, projectName string, options *BulkOptions) (*BulkReport, error)
+	BulkDelete(workpackageIDs []string, options *BulkOptions) (*BulkReport, error)
+	BulkDeleteWithContext(ctx context.Context, workpackageIDs []string, options *BulkOptions) (*BulkReport, error)
+	BulkMove(workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error)
+	BulkMoveWithContext(ctx context.Context, workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error)
+	BulkUpdate(items []BulkUpdateItem, options *BulkOptions) (*BulkReport, error)
+	BulkUpdateWithContext(ctx context.Context, items []BulkUpdateItem, options *BulkOptions) (*BulkReport, error)
+	Create(workPackage *WorkPackage, projectName string) (*WorkPackage, *Response, error)
+	CreateWithContext(ctx context.Context, workPackage *WorkPackage, projectName string) (*WorkPackage, *Response, error)
+	Delete(workpackageID string) (*Response, error)
+	DeleteWithContext(ctx context.Context, workpackageID string) (*Response, error)
+	Get(workpackageID string) (*WorkPackage, *Response, error)
+	GetWithContext(ctx context.Context, workpackageID string) (*WorkPackage, *Response, error)
+	GetList(options *FilterOptions) ([]WorkPackage, *Response, error)
+	GetListWithContext(ctx context.Context, options *FilterOptions) ([]WorkPackage, *Response, error)
+	Update(workpackageID string, workPackage *WorkPackage) (*WorkPackage, *Response, error)
+	UpdateWithContext(ctx context.Context, workpackageID string, workPackage *WorkPackage) (*WorkPackage, *Response, error)
+}
+
+// ProjectAPI is the method set of ProjectService
+type ProjectAPI interface {
+	Create(project *Project) (*Project, *Response, error)
+	CreateWithContext(ctx context.Context, project *Project) (*Project, *Response, error)
+	Get(projectID string) (*Project, *Response, error)
+	GetWithContext(ctx context.Context, projectID string) (*Project, *Response, error)
+	GetList() (*SearchResultProject, *Response, error)
+	GetListWithContext(ctx context.Context) (*SearchResultProject, *Response, error)
+}
+
+// UserAPI is the method set of UserService
+type UserAPI interface {
+	Create(user *User) (*User, *Response, error)
+	CreateWithContext(ctx context.Context, user *User) (*User, *Response, error)
+	Delete(userID string) (*Response, error)
+	DeleteWithContext(ctx context.Context, userID string) (*Response, error)
+	Get(accountID string) (*User, *Response, error)
+	GetWithContext(ctx context.Context, accountID string) (*User, *Response, error)
+	GetList(options *FilterOptions) (*SearchResultUser, *Response, error)
+	GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultUser, *Response, error)
+	Invite(user *User) (*User, *Response, error)
+	InviteWithContext(ctx context.Context, user *User) (*User, *Response, error)
+	Lock(userID string) (*User, *Response, error)
+	LockWithContext(ctx context.Context, userID string) (*User, *Response, error)
+	Unlock(userID string) (*User, *Response, error)
+	UnlockWithContext(ctx context.Context, userID string) (*User, *Response, error)
+	Update(userID string, changes *UserUpdate) (*User, *Response, error)
+	UpdateWithContext(ctx context.Context, userID string, changes *UserUpdate) (*User, *Response, error)
+}
+
+// StatusAPI is the method set of StatusService
+type StatusAPI interface {
+	Get(statusID string) (*Status, *Response, error)
+	GetWithContext(ctx context.Context, statusID string) (*Status, *Response, error)
+	GetList() (*SearchResultStatus, *Response, error)
+	GetListWithContext(ctx context.Context) (*SearchResultStatus, *Response, error)
+}
+
+// WikiPageAPI is the method set of WikiPageService
+type WikiPageAPI interface {
+	AddAttachment(wikiID string, fileName string, content []byte) (*Attachment, *Response, error)
+	AddAttachmentWithContext(ctx context.Context, wikiID string, fileName string, content []byte) (*Attachment, *Response, error)
+	Create(projectID string, page *WikiPage) (*WikiPage, *Response, error)
+	CreateWithContext(ctx context.Context, projectID string, page *WikiPage) (*WikiPage, *Response, error)
+	Get(wikiID string) (*WikiPage, *Response, error)
+	GetWithContext(ctx context.Context, wikiID string) (*WikiPage, *Response, error)
+	GetAttachments(wikiID string) (*SearchResultAttachment, *Response, error)
+	GetAttachmentsWithContext(ctx context.Context, wikiID string) (*SearchResultAttachment, *Response, error)
+	GetList(projectID string) (*SearchResultWikiPage, *Response, error)
+	GetListWithContext(ctx context.Context, projectID string) (*SearchResultWikiPage, *Response, error)
+	Update(wikiID string, page *WikiPage) (*WikiPage, *Response, error)
+	UpdateWithContext(ctx context.Context, wikiID string, page *WikiPage) (*WikiPage, *Response, error)
+}
+
+// AttachmentAPI is the method set of AttachmentService
+type AttachmentAPI interface {
+	Download(attachmentID string) (*[]byte, error)
+	DownloadWithContext(ctx context.Context, attachmentID string) (*[]byte, error)
+	Get(attachmentID string) (*Attachment, *Response, error)
+	GetWithContext(ctx context.Context, attachmentID string) (*Attachment, *Response, error)
+	Upload(endpoint string, fileName string, content []byte) (*Attachment, *Response, error)
+	UploadWithContext(ctx context.Context, endpoint string, fileName string, content []byte) (*Attachment, *Response, error)
+}
+
+// CategoryAPI is the method set of CategoryService
+type CategoryAPI interface {
+	Get(categoryID string) (*Category, *Response, error)
+	GetWithContext(ctx context.Context, categoryID string) (*Category, *Response, error)
+	GetList(projectID string) (*CategoryList, *Response, error)
+	GetListWithContext(ctx context.Context, projectID string) (*CategoryList, *Response, error)
+}
+
+// QueryAPI is the method set of QueryService
+type QueryAPI interface {
+	Create(query *QueryPayload) (*Query, *Response, error)
+	CreateWithContext(ctx context.Context, query *QueryPayload) (*Query, *Response, error)
+	Delete(queryID string) (*Response, error)
+	DeleteWithContext(ctx context.Context, queryID string) (*Response, error)
+	Form(query *QueryPayload) (*QueryForm, *Response, error)
+	FormWithContext(ctx context.Context, query *QueryPayload) (*QueryForm, *Response, error)
+	Get(queryID string) (*Query, *Response, error)
+	GetWithContext(ctx context.Context, queryID string) (*Query, *Response, error)
+	GetDefault(projectID string) (*Query, *Response, error)
+	GetDefaultWithContext(ctx context.Context, projectID string) (*Query, *Response, error)
+	GetFilterInstanceSchema(filterName string) (*QueryFilterInstanceSchema, *Response, error)
+	GetFilterInstanceSchemaWithContext(ctx context.Context, filterName string) (*QueryFilterInstanceSchema, *Response, error)
+	GetFilterInstanceSchemas(projectID string) (*SearchResultQueryFilterInstanceSchema, *Response, error)
+	GetFilterInstanceSchemasWithContext(ctx context.Context, projectID string) (*SearchResultQueryFilterInstanceSchema, *Response, error)
+	GetList() (*SearchResultQuery, *Response, error)
+	GetListWithContext(ctx context.Context) (*SearchResultQuery, *Response, error)
+	GetResults(queryID string, options *QueryResultsOptions) (*SearchResultWP, *Response, error)
+	GetResultsWithContext(ctx context.Context, queryID string, options *QueryResultsOptions) (*SearchResultWP, *Response, error)
+	Star(queryID string) (*Query, *Response, error)
+	StarWithContext(ctx context.Context, queryID string) (*Query, *Response, error)
+	Unstar(queryID string) (*Query, *Response, error)
+	UnstarWithContext(ctx context.Context, queryID string) (*Query, *Response, error)
+	Update(queryID string, query *QueryPayload) (*Query, *Response, error)
+	UpdateWithContext(ctx context.Context, queryID string, query *QueryPayload) (*Query, *Response, error)
+}
+
+// GroupAPI is the method set of GroupService
+type GroupAPI interface {
+	AddMembers(groupID string, userIDs ...string) (*Group, *Response, error)
+	AddMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error)
+	Create(group *Group) (*Group, *Response, error)
+	CreateWithContext(ctx context.Context, group *Group) (*Group, *Response, error)
+	Delete(groupID string) (*Response, error)
+	DeleteWithContext(ctx context.Context, groupID string) (*Response, error)
+	Get(groupID string) (*Group, *Response, error)
+	GetWithContext(ctx context.Context, groupID string) (*Group, *Response, error)
+	GetList(options *FilterOptions) (*SearchResultGroup, *Response, error)
+	GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultGroup, *Response, error)
+	RemoveMembers(groupID string, userIDs ...string) (*Group, *Response, error)
+	RemoveMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error)
+	Update(groupID string, group *Group) (*Group, *Response, error)
+	UpdateWithContext(ctx context.Context, groupID string, group *Group) (*Group, *Response, error)
+}
+
+// PrincipalAPI is the method set of PrincipalService
+type PrincipalAPI interface {
+	GetList(options *FilterOptions) (*SearchResultPrincipal, *Response, error)
+	GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultPrincipal, *Response, error)
+	Resolve(href string) (*Principal, *Response, error)
+	ResolveWithContext(ctx context.Context, href string) (*Principal, *Response, error)
+}
+
+// PlaceholderUserAPI is the method set of PlaceholderUserService
+type PlaceholderUserAPI interface {
+	Create(placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error)
+	CreateWithContext(ctx context.Context, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error)
+	Delete(placeholderID string) (*Response, error)
+	DeleteWithContext(ctx context.Context, placeholderID string) (*Response, error)
+	Get(placeholderID string) (*PlaceholderUser, *Response, error)
+	GetWithContext(ctx context.Context, placeholderID string) (*PlaceholderUser, *Response, error)
+	GetList(options *FilterOptions) (*SearchResultPlaceholderUser, *Response, error)
+	GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultPlaceholderUser, *Response, error)
+	ReassignWorkPackages(placeholderID string, userID string) ([]WorkPackage, error)
+	ReassignWorkPackagesWithContext(ctx context.Context, placeholderID string, userID string) ([]WorkPackage, error)
+	Update(placeholderID string, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error)
+	UpdateWithContext(ctx context.Context, placeholderID string, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error)
+}
+
+// Services implement their interface
+var (
+	_ API                = (*Client)(nil)
+	_ AuthenticationAPI  = (*AuthenticationService)(nil)
+	_ WorkPackageAPI     = (*WorkPackageService)(nil)
+	_ ProjectAPI         = (*ProjectService)(nil)
+	_ UserAPI            = (*UserService)(nil)
+	_ StatusAPI          = (*StatusService)(nil)
+	_ WikiPageAPI        = (*WikiPageService)(nil)
+	_ AttachmentAPI      = (*AttachmentService)(nil)
+	_ CategoryAPI        = (*CategoryService)(nil)
+	_ QueryAPI           = (*QueryService)(nil)
+	_ GroupAPI           = (*GroupService)(nil)
+	_ PrincipalAPI       = (*PrincipalService)(nil)
+	_ PlaceholderUserAPI = (*PlaceholderUserService)(nil)
+)
+
+// Auth returns the authentication service of the client as interface
+func (c *Client) Auth() AuthenticationAPI { return c.Authentication }
+
+// WorkPackages returns the work-package service of the client as interface
+func (c *Client) WorkPackages() WorkPackageAPI { return c.WorkPackage }
+
+// Projects returns the project service of the client as interface
+func (c *Client) Projects() ProjectAPI { return c.Project }
+
+// Users returns the user service of the client as interface
+func (c *Client) Users() UserAPI { return c.User }
+
+// Statuses returns the status service of the client as interface
+func (c *Client) Statuses() StatusAPI { return c.Status }
+
+// WikiPages returns the wiki page service of the client as interface
+func (c *Client) WikiPages() WikiPageAPI { return c.WikiPage }
+
+// Attachments returns the attachment service of the client as interface
+func (c *Client) Attachments() AttachmentAPI { return c.Attachment }
+
+// Categories returns the category service of the client as interface
+func (c *Client) Categories() CategoryAPI { return c.Category }
+
+// Queries returns the query service of the client as interface
+func (c *Client) Queries() QueryAPI { return c.Query }
+
+// Groups returns the group service of the client as interface
+func (c *Client) Groups() GroupAPI { return c.Group }
+
+// Principals returns the principal service of the client as interface
+func (c *Client) Principals() PrincipalAPI { return c.Principal }
+
+// PlaceholderUsers returns the placeholder user service of the client as interface
+func (c *Client) PlaceholderUsers() PlaceholderUserAPI { return c.Placeholder }
@@ -1,9 +1,19 @@
 package openproject
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/pkg/errors"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // AttachmentService handles attachments for the OpenProject instance / API.
@@ -11,8 +21,22 @@ type AttachmentService struct {
 	client *Client
 }
 
+// SearchResultAttachment represent a list of attachments
+type SearchResultAttachment struct {
+	Embedded attachmentElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int                `json:"total" structs:"total"`
+	Count    int                `json:"count" structs:"count"`
+	PageSize int                `json:"pageSize" structs:"pageSize"`
+	Offset   int                `json:"offset" structs:"offset"`
+}
+
+// attachmentElements represent elements within SearchResultAttachment
+type attachmentElements struct {
+	Elements []Attachment `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
 // Attachment is the object representing OpenProject attachments.
-// TODO: Complete fields and complex fields (user, links, downloadlocation, container...)
+// TODO: Complete fields and complex fields (user, container...)
 type Attachment struct {
 	Type        string               `json:"_type,omitempty" structs:"_type,omitempty"`
 	ID          int                  `json:"id,omitempty" structs:"id,omitempty"`
@@ -21,6 +45,8 @@ type Attachment struct {
 	Description OPGenericDescription `json:"description,omitempty" structs:"description,omitempty"`
 	ContentType string               `json:"contentType,omitempty" structs:"contentType,omitempty"`
 	Digest      AttachmentDigest     `json:"digest,omitempty" structs:"digest,omitempty"`
+	CreatedAt   *Time                `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	Links       *AttachmentLinks     `json:"_links,omitempty" structs:"_links,omitempty"`
 }
 
 // AttachmentDigest wraps algorithm and hash
@@ -29,10 +55,44 @@ type AttachmentDigest struct {
 	Hash      string `json:"hash,omitempty" structs:"hash,omitempty"`
 }
 
+// AttachmentLinks are Attachment Links
+// AddAttachment and CompleteUpload are only present in the response of a prepared (direct) upload
+type AttachmentLinks struct {
+	Self                   *OPGenericLink        `json:"self,omitempty" structs:"self,omitempty"`
+	Author                 *OPGenericLink        `json:"author,omitempty" structs:"author,omitempty"`
+	Container              *OPGenericLink        `json:"container,omitempty" structs:"container,omitempty"`
+	DownloadLocation       *OPGenericLink        `json:"downloadLocation,omitempty" structs:"downloadLocation,omitempty"`
+	StaticDownloadLocation *OPGenericLink        `json:"staticDownloadLocation,omitempty" structs:"staticDownloadLocation,omitempty"`
+	AddAttachment          *AttachmentUploadLink `json:"addAttachment,omitempty" structs:"addAttachment,omitempty"`
+	CompleteUpload         *OPGenericLink        `json:"completeUpload,omitempty" structs:"completeUpload,omitempty"`
+}
+
+// AttachmentUploadLink points to the object storage a prepared attachment has to be uploaded to.
+// FormFields have to be sent along with the file as part of the multipart form.
+type AttachmentUploadLink struct {
+	Href       string            `json:"href,omitempty" structs:"href,omitempty"`
+	Method     string            `json:"method,omitempty" structs:"method,omitempty"`
+	FormFields map[string]string `json:"form_fields,omitempty" structs:"form_fields,omitempty"`
+}
+
+// AttachmentMetadata describes a file to be uploaded as attachment
+type AttachmentMetadata struct {
+	FileName    string                `json:"fileName" structs:"fileName"`
+	FileSize    int                   `json:"fileSize,omitempty" structs:"fileSize,omitempty"`
+	ContentType string                `json:"contentType,omitempty" structs:"contentType,omitempty"`
+	Description *OPGenericDescription `json:"description,omitempty" structs:"description,omitempty"`
+}
+
+// Constant to represent the attachment endpoint used when no container endpoint is provided
+const attachmentsEndpoint = "api/v3/attachments"
+
 // GetWithContext gets a wiki page from OpenProject using its ID
 func (s *AttachmentService) GetWithContext(ctx context.Context, attachmentID string) (*Attachment, *Response, error) {
 	apiEndPoint := fmt.Sprintf("api/v3/attachments/%s", attachmentID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndPoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*Attachment), Resp, err
 }
 
@@ -50,10 +110,10 @@ func (s *AttachmentService) DownloadWithContext(ctx context.Context, attachmentI
 	}
 
 	resp, err := s.client.Download(req)
-
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	respBytes, err := ioutil.ReadAll(resp.Body)
 
@@ -64,3 +124,245 @@ func (s *AttachmentService) DownloadWithContext(ctx context.Context, attachmentI
 func (s *AttachmentService) Download(attachmentID string) (*[]byte, error) {
 	return s.DownloadWithContext(context.Background(), attachmentID)
 }
+
+// UploadWithContext uploads a file as attachment into the attachments collection of a container,
+// i.e. "api/v3/work_packages/5/attachments". An empty endpoint uploads a containerless attachment.
+// Instances backed by an object storage offer direct uploads through "prepare" endpoint, in that case
+// the file is sent to the storage and the upload is completed afterwards. Otherwise the file is uploaded
+// as a regular multipart request.
+func (s *AttachmentService) UploadWithContext(ctx context.Context, endpoint string, fileName string, content []byte) (*Attachment, *Response, error) {
+	if endpoint == "" {
+		endpoint = attachmentsEndpoint
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+
+	metadata := &AttachmentMetadata{
+		FileName:    fileName,
+		FileSize:    len(content),
+		ContentType: detectContentType(fileName, content),
+	}
+
+	prepared, resp, err := s.prepareUploadWithContext(ctx, endpoint, metadata)
+	if err != nil {
+		if resp != nil && directUploadUnsupported(resp.StatusCode) {
+			return s.multipartUploadWithContext(ctx, endpoint, metadata, content)
+		}
+		return nil, resp, err
+	}
+
+	if err := s.uploadToStorageWithContext(ctx, prepared.Links.AddAttachment, metadata, content); err != nil {
+		return nil, resp, err
+	}
+
+	return s.completeUploadWithContext(ctx, prepared.Links.CompleteUpload)
+}
+
+// Upload wraps UploadWithContext using the background context.
+func (s *AttachmentService) Upload(endpoint string, fileName string, content []byte) (*Attachment, *Response, error) {
+	return s.UploadWithContext(context.Background(), endpoint, fileName, content)
+}
+
+// prepareUploadWithContext announces a direct upload and returns the attachment holding storage links
+func (s *AttachmentService) prepareUploadWithContext(ctx context.Context, endpoint string, metadata *AttachmentMetadata) (*Attachment, *Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "POST", endpoint+"/prepare", metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prepared := new(Attachment)
+	resp, err := s.client.Do(req, prepared)
+	if err != nil {
+		if resp != nil && directUploadUnsupported(resp.StatusCode) {
+			// Let the caller fall back to a regular upload
+			return nil, resp, err
+		}
+		return nil, resp, err
+	}
+	if prepared.Links == nil || prepared.Links.AddAttachment == nil || prepared.Links.AddAttachment.Href == "" {
+		return nil, resp, fmt.Errorf("prepared attachment %d does not provide an upload location", prepared.ID)
+	}
+	if prepared.Links.CompleteUpload == nil || prepared.Links.CompleteUpload.Href == "" {
+		return nil, resp, fmt.Errorf("prepared attachment %d does not provide a completion link", prepared.ID)
+	}
+
+	return prepared, resp, nil
+}
+
+// uploadToStorageWithContext sends the file to the object storage along with the form fields given by OpenProject.
+// The request goes straight to the storage, so it is sent without OpenProject credentials, see Client.SetStorageClient.
+func (s *AttachmentService) uploadToStorageWithContext(ctx context.Context, link *AttachmentUploadLink, metadata *AttachmentMetadata, content []byte) error {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+
+	// Storage policies are sensitive to field order only regarding the file, which must be the last part
+	fields := make([]string, 0, len(link.FormFields))
+	for field := range link.FormFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if err := writer.WriteField(field, link.FormFields[field]); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", metadata.FileName)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(link.Method)
+	if method == "" {
+		method = "POST"
+	}
+	req, err := newRequestWithContext(ctx, method, link.Href, buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	req, endCall := s.client.startCall(req)
+	resp, err := s.client.sendWith(req, s.client.storageHTTPClient().Do)
+	if err != nil {
+		err = newRequestError(req, err)
+	} else {
+		defer resp.Body.Close()
+		err = CheckResponse(resp)
+	}
+	endCall(resp, nil, err)
+	if err != nil {
+		return errors.Wrap(err, "upload to storage failed")
+	}
+
+	return nil
+}
+
+// SetStorageClient sets the HTTP client uploading files to the object storage of the instance, see AttachmentService.Upload.
+// These uploads must not carry OpenProject credentials: they go through the rate limits and the instrumentation
+// of the client, but not through its middlewares, which may add credentials (i.e. HeaderMiddleware).
+// By default, they are sent with the timeout of the *http.Client given to NewClient, without its cookie jar,
+// through the *http.Transport underlying the authenticating transports of this package (APIKeyTransport,
+// OAuth2Transport, ...) or http.DefaultTransport if the client has a transport of another kind, which may add
+// credentials too. Set a storage client to upload through another transport, i.e. a proxy
+func (c *Client) SetStorageClient(httpClient httpClient) {
+	c.storageClient = httpClient
+}
+
+// storageHTTPClient returns the HTTP client uploading files to the object storage, see SetStorageClient
+func (c *Client) storageHTTPClient() httpClient {
+	if c.storageClient != nil {
+		return c.storageClient
+	}
+	hc, ok := c.client.(*http.Client)
+	if !ok {
+		return http.DefaultClient
+	}
+	storageClient := &http.Client{Timeout: hc.Timeout}
+	if transport, ok := withoutCredentials(hc.Transport).(*http.Transport); ok {
+		storageClient.Transport = transport
+	}
+	return storageClient
+}
+
+// withoutCredentials returns the transport underlying the authenticating transports of this package
+func withoutCredentials(transport http.RoundTripper) http.RoundTripper {
+	for {
+		switch t := transport.(type) {
+		case *APIKeyTransport:
+			transport = t.transport()
+		case *BasicAuthTransport:
+			transport = t.transport()
+		case *CookieAuthTransport:
+			transport = t.transport()
+		case *JWTAuthTransport:
+			transport = t.transport()
+		case *OAuth2Transport:
+			transport = t.transport()
+		default:
+			return transport
+		}
+	}
+}
+
+// completeUploadWithContext notifies OpenProject the file has been uploaded to the storage
+func (s *AttachmentService) completeUploadWithContext(ctx context.Context, link *OPGenericLink) (*Attachment, *Response, error) {
+	method := strings.ToUpper(link.Method)
+	if method == "" {
+		method = "GET"
+	}
+	req, err := s.client.NewRequestWithContext(ctx, method, link.Href, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := new(Attachment)
+	resp, err := s.client.Do(req, attachment)
+	if err != nil {
+		return nil, resp, err
+	}
+	return attachment, resp, nil
+}
+
+// multipartUploadWithContext uploads metadata and file within a single multipart request
+func (s *AttachmentService) multipartUploadWithContext(ctx context.Context, endpoint string, metadata *AttachmentMetadata, content []byte) (*Attachment, *Response, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="metadata"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := json.NewEncoder(part).Encode(metadata); err != nil {
+		return nil, nil, err
+	}
+
+	part, err = writer.CreateFormFile("file", metadata.FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewMultiPartRequestWithContext(ctx, "POST", endpoint, buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	attachment := new(Attachment)
+	resp, err := s.client.Do(req, attachment)
+	if err != nil {
+		return nil, resp, err
+	}
+	return attachment, resp, nil
+}
+
+// directUploadUnsupported reports whether a "prepare" status code means the instance has no direct upload
+func directUploadUnsupported(statusCode int) bool {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// detectContentType guesses the content type from the file extension, falling back to content sniffing
+func detectContentType(fileName string, content []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(fileName)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
@@ -1,6 +1,8 @@
 package openproject
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -62,3 +64,153 @@ func TestAttachmentService_Download(t *testing.T) {
 		t.Errorf("Unexpected downloaded filesize %d", len(*file))
 	}
 }
+
+func TestAttachmentService_Upload_Direct(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/work_packages/36350/attachments"
+	content := []byte("# Release notes\n\n- Fixes")
+
+	raw, err := ioutil.ReadFile("./mocks/post/post-attachment.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	// The storage is reached without the credentials added by the transports and the middlewares of the client
+	var transportRequests, middlewareRequests int
+	transport := &APIKeyTransport{APIKey: "secret", Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		transportRequests++
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Tenant-Token", "tenant")
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	testClient, _ = NewClient(transport.Client(), testServer.URL)
+	testClient.Use(HeaderMiddleware(http.Header{"Authorization": {"Bearer tenant"}}), func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			middlewareRequests++
+			return next(req)
+		}
+	})
+
+	uploaded := false
+	testMux.HandleFunc(testAPIEdpoint+"/prepare", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, testAPIEdpoint+"/prepare")
+		if _, key, _ := r.BasicAuth(); key != "secret" {
+			t.Error("Expected the API key sent to OpenProject")
+		}
+
+		metadata := new(AttachmentMetadata)
+		if err := json.NewDecoder(r.Body).Decode(metadata); err != nil {
+			t.Error(err.Error())
+		}
+		if metadata.FileName != "release-notes.md" || metadata.FileSize != len(content) {
+			t.Errorf("Unexpected upload metadata %+v", metadata)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"_type":"Attachment","id":22,"_links":{
+			"addAttachment":{"href":"%s/storage","method":"post","form_fields":{"key":"uploads/22/release-notes.md","policy":"abc"}},
+			"completeUpload":{"href":"/api/v3/attachments/22/uploaded","method":"get"}}}`, testServer.URL)
+	})
+	testMux.HandleFunc("/storage", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.Header.Get("Authorization") != "" || r.Header.Get("X-Tenant-Token") != "" {
+			t.Errorf("Expected no credentials sent to the storage, got %v", r.Header)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err.Error())
+			return
+		}
+		if r.FormValue("key") != "uploads/22/release-notes.md" || r.FormValue("policy") != "abc" {
+			t.Errorf("Unexpected storage form fields %v", r.MultipartForm.Value)
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+		received, _ := ioutil.ReadAll(file)
+		if !bytes.Equal(received, content) {
+			t.Errorf("Unexpected uploaded content %q", received)
+		}
+		uploaded = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/api/v3/attachments/22/uploaded", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if !uploaded {
+			t.Error("Upload completed before sending the file to the storage")
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	attachment, _, err := testClient.Attachment.Upload("api/v3/work_packages/36350/attachments", "release-notes.md", content)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if attachment == nil {
+		t.Error("Expected attachment. Attachment is nil")
+		return
+	}
+	if attachment.ID != 22 {
+		t.Errorf("Unexpected attachment ID %d", attachment.ID)
+	}
+	if transportRequests != 2 || middlewareRequests != 2 {
+		t.Errorf("Expected the storage upload sent without the transport and the middlewares, got %d and %d requests",
+			transportRequests, middlewareRequests)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper calling a function
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAttachmentService_Upload_FallbackToMultipart(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/attachments"
+	content := []byte("# Release notes\n\n- Fixes")
+
+	raw, err := ioutil.ReadFile("./mocks/post/post-attachment.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint+"/prepare", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err.Error())
+			return
+		}
+		metadata := new(AttachmentMetadata)
+		if err := json.Unmarshal([]byte(r.FormValue("metadata")), metadata); err != nil {
+			t.Error(err.Error())
+		}
+		if metadata.FileName != "release-notes.md" {
+			t.Errorf("Unexpected metadata file name %s", metadata.FileName)
+		}
+		if _, _, err := r.FormFile("file"); err != nil {
+			t.Error(err.Error())
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	attachment, _, err := testClient.Attachment.Upload("", "release-notes.md", content)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if attachment == nil {
+		t.Error("Expected attachment. Attachment is nil")
+		return
+	}
+	if attachment.FileName != "release-notes.md" {
+		t.Errorf("Unexpected attachment filename %s", attachment.FileName)
+	}
+}
@@ -2,10 +2,16 @@ package openproject
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"github.com/pkg/errors"
+	"html"
 	"io/ioutil"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 )
 
 const (
@@ -15,6 +21,22 @@ const (
 	authTypeSession = 2
 )
 
+const (
+	// apiKeyUsername is the user name of the basic credentials carrying an API key
+	apiKeyUsername = "apikey"
+	// loginPath is the login form of OpenProject, relative to its base URL
+	loginPath = "login"
+	// logoutPath signs out the user of the session, relative to the base URL
+	logoutPath = "logout"
+)
+
+// authenticityTokenPatterns find the CSRF token of the login form, either as form field or as meta tag
+var authenticityTokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`name="authenticity_token"[^>]*value="([^"]*)"`),
+	regexp.MustCompile(`value="([^"]*)"[^>]*name="authenticity_token"`),
+	regexp.MustCompile(`name="csrf-token"[^>]*content="([^"]*)"`),
+}
+
 // AuthenticationService handles authentication for the OpenProject instance / API.
 type AuthenticationService struct {
 	client *Client
@@ -29,59 +51,43 @@ type AuthenticationService struct {
 	password string
 }
 
-// Session represents a Session JSON response by the OpenProject API.
+// Session represents an OpenProject web session, opened through the login form.
+// The API accepts session cookies along with the "X-Requested-With: XMLHttpRequest" header,
+// which the client adds to every request of the session
 type Session struct {
-	Self    string `json:"self,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Session struct {
-		Name  string `json:"name"`
-		Value string `json:"value"`
-	} `json:"session,omitempty"`
-	LoginInfo struct {
-		FailedLoginCount    int    `json:"failedLoginCount"`
-		LoginCount          int    `json:"loginCount"`
-		LastFailedLoginTime string `json:"lastFailedLoginTime"`
-		PreviousLoginTime   string `json:"previousLoginTime"`
-	} `json:"loginInfo"`
+	// Cookies carry the session, i.e. "_open_project_session"
 	Cookies []*http.Cookie
+	// User is the user signed in
+	User *User
 }
 
-// AcquireSessionCookieWithContext creates a new session for a user in OpenProject.
-// Once a session has been successfully created it can be used to access any of OpenProject's remote APIs and also the web UI by passing the appropriate HTTP Cookie header.
+// AcquireSessionCookieWithContext signs in a user through the login form of OpenProject and keeps the session.
+// Once a session has been successfully created it can be used to access any of OpenProject's APIs and also the web UI by passing the appropriate HTTP Cookie header.
 // The header will by automatically applied to every API request.
-// Note that it is generally preferable to use HTTP BASIC authentication with the REST API.
+// Note that it is generally preferable to authenticate with an API key, see APIKeyTransport.
 // Deprecated: Use CookieAuthTransport instead
 func (s *AuthenticationService) AcquireSessionCookieWithContext(ctx context.Context, username, password string) (bool, error) {
-	apiEndpoint := "rest/auth/1/session"
-	body := struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
-	}{
-		username,
-		password,
+	loginURL := s.client.baseURL.ResolveReference(&url.URL{Path: loginPath})
+	var transport http.RoundTripper
+	if httpClient, ok := s.client.client.(*http.Client); ok {
+		transport = httpClient.Transport
 	}
 
-	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, body)
+	cookies, err := openProjectLogin(ctx, transport, loginURL.String(), username, password)
 	if err != nil {
-		return false, err
+		return false, errors.Wrap(err, "auth at OpenProject instance failed")
 	}
 
-	session := new(Session)
-	resp, err := s.client.Do(req, session)
-
-	if resp != nil {
-		session.Cookies = resp.Cookies()
-	}
+	s.client.session = &Session{Cookies: cookies}
+	s.authType = authTypeSession
 
+	// Make sure the session is accepted by the API
+	user, _, err := s.GetCurrentUserWithContext(ctx)
 	if err != nil {
-		return false, fmt.Errorf("auth at OpenProject instance failed (HTTP(S) request). %s", err)
+		s.client.session = nil
+		return false, errors.Wrap(err, "auth at OpenProject instance failed")
 	}
-	if resp != nil && resp.StatusCode != 200 {
-		return false, fmt.Errorf("auth at OpenProject instance failed (HTTP(S) request). Status code: %d", resp.StatusCode)
-	}
-
-	s.client.session = session
-	s.authType = authTypeSession
+	s.client.session.User = user
 
 	return true, nil
 }
@@ -100,6 +106,12 @@ func (s *AuthenticationService) SetBasicAuth(username, password string) {
 	s.authType = authTypeBasic
 }
 
+// SetAPIKey sets the API key of a user (My account > Access tokens) for the basic auth against the OpenProject instance.
+// Deprecated: Use APIKeyTransport instead
+func (s *AuthenticationService) SetAPIKey(apiKey string) {
+	s.SetBasicAuth(apiKeyUsername, apiKey)
+}
+
 // Authenticated reports if the current Client has authentication details for OpenProject
 func (s *AuthenticationService) Authenticated() bool {
 	if s != nil {
@@ -121,25 +133,21 @@ func (s *AuthenticationService) LogoutWithContext(ctx context.Context) error {
 		return fmt.Errorf("no user is authenticated")
 	}
 
-	apiEndpoint := "rest/auth/1/session"
-	req, err := s.client.NewRequestWithContext(ctx, "DELETE", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", logoutPath, nil)
 	if err != nil {
 		return fmt.Errorf("creating the request to log the user out failed : %s", err)
 	}
 
 	resp, err := s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("error sending the logout request: %s", err)
-	}
-	if resp.StatusCode != 204 {
-		return fmt.Errorf("the logout was unsuccessful with status %d", resp.StatusCode)
+		return errors.Wrap(err, "the logout was unsuccessful")
 	}
+	resp.Body.Close()
 
 	// If logout successful, delete session
 	s.client.session = nil
 
 	return nil
-
 }
 
 // Logout wraps LogoutWithContext using the background context.
@@ -149,46 +157,90 @@ func (s *AuthenticationService) Logout() error {
 	return s.LogoutWithContext(context.Background())
 }
 
-// GetCurrentUserWithContext gets the details of the current user.
-func (s *AuthenticationService) GetCurrentUserWithContext(ctx context.Context) (*Session, error) {
+// GetCurrentUserWithContext gets the user the client is authenticated as (api/v3/users/me),
+// whatever the authentication method. Anonymous clients get a not found or unauthenticated error
+func (s *AuthenticationService) GetCurrentUserWithContext(ctx context.Context) (*User, *Response, error) {
 	if s == nil {
-		return nil, fmt.Errorf("authentication Service is not instantiated")
-	}
-	if s.authType != authTypeSession || s.client.session == nil {
-		return nil, fmt.Errorf("no user is authenticated yet")
+		return nil, nil, fmt.Errorf("authentication Service is not instantiated")
 	}
 
-	apiEndpoint := "rest/auth/1/session"
-	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	return s.client.User.GetWithContext(ctx, "me")
+}
+
+// GetCurrentUser wraps GetCurrentUserWithContext using the background context.
+func (s *AuthenticationService) GetCurrentUser() (*User, *Response, error) {
+	return s.GetCurrentUserWithContext(context.Background())
+}
+
+// openProjectLogin signs in through the login form at loginURL and returns the session cookies.
+// The form is requested first, to get the CSRF token bound to the session
+func openProjectLogin(ctx context.Context, transport http.RoundTripper, loginURL, username, password string) ([]*http.Cookie, error) {
+	jar, err := cookiejar.New(nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not create request for getting user info : %s", err)
+		return nil, err
+	}
+	loginClient := &http.Client{
+		Transport: transport,
+		Jar:       jar,
+		Timeout:   time.Second * 60,
+		// The redirect tells whether the login succeeded
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
 	}
 
-	resp, err := s.client.Do(req, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", loginURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request to get user info : %s", err)
+		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("getting user info failed with status : %d", resp.StatusCode)
+	resp, err := loginClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
-
-	defer resp.Body.Close()
-	ret := new(Session)
-	data, err := ioutil.ReadAll(resp.Body)
+	page, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("couldn't read body from the response : %s", err)
+		return nil, err
+	}
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	token := ""
+	for _, pattern := range authenticityTokenPatterns {
+		if match := pattern.FindSubmatch(page); match != nil {
+			token = html.UnescapeString(string(match[1]))
+			break
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no authenticity token found within the login form %s", loginURL)
 	}
 
-	err = json.Unmarshal(data, &ret)
-
+	form := url.Values{
+		"username":           {username},
+		"password":           {password},
+		"authenticity_token": {token},
+	}
+	req, err = http.NewRequestWithContext(ctx, "POST", loginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err = loginClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshall received user info : %s", err)
+		return nil, err
 	}
+	resp.Body.Close()
 
-	return ret, nil
-}
+	// OpenProject redirects once signed in, and renders the form again otherwise
+	location, _ := resp.Location()
+	if resp.StatusCode < 300 || resp.StatusCode > 399 || (location != nil && strings.HasSuffix(strings.TrimRight(location.Path, "/"), "/"+loginPath)) {
+		return nil, fmt.Errorf("login of user %s failed with status %d, check the credentials", username, resp.StatusCode)
+	}
 
-// GetCurrentUser wraps GetCurrentUserWithContext using the background context.
-func (s *AuthenticationService) GetCurrentUser() (*Session, error) {
-	return s.GetCurrentUserWithContext(context.Background())
+	cookies := jar.Cookies(req.URL)
+	if len(cookies) == 0 {
+		return nil, errors.New("no session cookie returned")
+	}
+	return cookies, nil
 }
@@ -0,0 +1,124 @@
+package openproject
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// handleLogin mocks the login form of OpenProject, accepting user "admin" with password "secret"
+func handleLogin(t *testing.T) {
+	testMux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			http.SetCookie(w, &http.Cookie{Name: "_open_project_session", Value: "anonymous", Path: "/"})
+			fmt.Fprint(w, `<form action="/login" method="post"><input type="hidden" name="authenticity_token" value="csrf&#43;token" autocomplete="off" /></form>`)
+			return
+		}
+		r.ParseForm()
+		if cookie, err := r.Cookie("_open_project_session"); err != nil || cookie.Value != "anonymous" {
+			t.Error("Expected login form posted within the session of the form")
+		}
+		if r.PostForm.Get("authenticity_token") != "csrf+token" {
+			t.Errorf("Unexpected authenticity token %q", r.PostForm.Get("authenticity_token"))
+		}
+		if r.PostForm.Get("username") != "admin" || r.PostForm.Get("password") != "secret" {
+			fmt.Fprint(w, `<div class="flash error">Invalid user or password</div>`)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "_open_project_session", Value: "signed-in", Path: "/"})
+		http.Redirect(w, r, "/my/page", http.StatusFound)
+	})
+}
+
+// handleUsersMe answers api/v3/users/me for the given authorization check
+func handleUsersMe(t *testing.T, authorized func(r *http.Request) bool) {
+	raw, err := ioutil.ReadFile("./mocks/get/get-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if !authorized(r) {
+			w.Header().Set("Content-Type", "application/hal+json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:Unauthenticated","message":"You need to be authenticated to access this resource."}`)
+			return
+		}
+		fmt.Fprint(w, string(raw))
+	})
+}
+
+func TestAuthenticationService_AcquireSessionCookie(t *testing.T) {
+	setup()
+	defer teardown()
+	handleLogin(t)
+	handleUsersMe(t, func(r *http.Request) bool {
+		cookie, err := r.Cookie("_open_project_session")
+		return err == nil && cookie.Value == "signed-in" && r.Header.Get("X-Requested-With") == "XMLHttpRequest"
+	})
+	logouts := 0
+	testMux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		logouts++
+	})
+
+	if ok, err := testClient.Authentication.AcquireSessionCookie("admin", "wrong"); ok || err == nil {
+		t.Error("Expected login failure")
+	}
+	if testClient.Authentication.Authenticated() {
+		t.Error("Expected client not authenticated")
+	}
+
+	ok, err := testClient.Authentication.AcquireSessionCookie("admin", "secret")
+	if !ok || err != nil {
+		t.Fatalf("Expected login, error given: %v", err)
+	}
+	if !testClient.Authentication.Authenticated() || testClient.session.User == nil || testClient.session.User.Login != "manuel.boira@darecode.com" {
+		t.Errorf("Expected session of the user, %+v given", testClient.session)
+	}
+
+	if err := testClient.Authentication.Logout(); err != nil || logouts != 1 || testClient.Authentication.Authenticated() {
+		t.Errorf("Expected logout, %d done (%v)", logouts, err)
+	}
+}
+
+func TestCookieAuthTransport(t *testing.T) {
+	setup()
+	defer teardown()
+	handleLogin(t)
+	handleUsersMe(t, func(r *http.Request) bool {
+		cookie, err := r.Cookie("_open_project_session")
+		return err == nil && cookie.Value == "signed-in" && r.Header.Get("X-Requested-With") == "XMLHttpRequest"
+	})
+
+	tp := &CookieAuthTransport{Username: "admin", Password: "secret", AuthURL: testServer.URL + "/login"}
+	client, _ := NewClient(tp.Client(), testServer.URL)
+	if user, _, err := client.Authentication.GetCurrentUser(); err != nil || user.ID != 1 {
+		t.Errorf("Expected current user, error given: %v", err)
+	}
+}
+
+func TestAPIKeyTransport(t *testing.T) {
+	setup()
+	defer teardown()
+	handleUsersMe(t, func(r *http.Request) bool {
+		username, password, ok := r.BasicAuth()
+		return ok && username == "apikey" && password == "0123456789abcdef"
+	})
+
+	tp := &APIKeyTransport{APIKey: "0123456789abcdef"}
+	client, _ := NewClient(tp.Client(), testServer.URL)
+	user, _, err := client.Authentication.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if user.ID != 1 || user.Login != "manuel.boira@darecode.com" || !user.Admin {
+		t.Errorf("Unexpected user %+v", user)
+	}
+
+	// Anonymous clients are rejected
+	if _, _, err := testClient.Authentication.GetCurrentUser(); !errors.Is(err, ErrUnauthenticated) {
+		t.Error("Expected unauthenticated error")
+	}
+}
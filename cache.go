@@ -0,0 +1,404 @@
+package openproject
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response along with its validators.
+// Vary holds the request headers named by the Vary header of the response, the entry is used for requests
+// having the same values only
+type CacheEntry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body,omitempty"`
+	Vary         http.Header `json:"vary,omitempty"`
+	StoredAt     time.Time   `json:"storedAt"`
+}
+
+// CacheStorage stores cached responses by key. Implementations must be safe for concurrent use
+type CacheStorage interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// CacheTTL lets responses of the paths matching Pattern be served from the cache, without asking the server,
+// while younger than TTL, i.e. {Pattern: "api/v3/statuses*", TTL: time.Hour} for reference data.
+// Pattern follows path.Match and is matched against the path of the request relative to the base URL
+type CacheTTL struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// CacheOptions configures the response cache of the Client, see Client.SetCache.
+// GET responses carrying an ETag or a Last-Modified header are stored, and requested again
+// with If-None-Match / If-Modified-Since: when the server answers 304 (Not Modified) the stored response is used.
+// Responses marked Cache-Control no-store or private are never stored.
+//
+// Responses are stored by URL and by credentials, those of the request headers (Authorization, Cookie)
+// and those added by the authentication transports of this package and the cookie jar of the http.Client,
+// so a storage can be shared by clients of different users. Other transports adding credentials are not known
+// to the cache: clients using them must not share a storage.
+//
+// Successful POST, PATCH, PUT and DELETE requests remove the stored response of their URL only, for the
+// credentials they are sent with. Collections including the resource, i.e. api/v3/work_packages?filters=...,
+// are not invalidated: they are revalidated with the server when requested, but served stale while within a TTL
+type CacheOptions struct {
+	Storage CacheStorage
+	// TTLs are checked in order, the first matching one is used
+	TTLs []CacheTTL
+}
+
+// SetCache enables the response cache of the client. nil disables it, which is the default.
+// It must not be called while requests are being sent
+func (c *Client) SetCache(options *CacheOptions) {
+	if options != nil && options.Storage == nil {
+		options.Storage = NewMemoryCache(0)
+	}
+	c.cache = options
+}
+
+// doCached sends a request through the response cache of the client, if any
+func (c *Client) doCached(req *http.Request) (*http.Response, error) {
+	cache := c.cache
+	if cache == nil || req.Header.Get("Range") != "" {
+		return c.doWithRetry(req)
+	}
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return c.doWithRetry(req)
+	}
+
+	if req.Method != "GET" {
+		resp, err := c.doWithRetry(req)
+		if err == nil && resp.StatusCode < 300 {
+			cache.Storage.Delete(key)
+		}
+		return resp, err
+	}
+
+	entry, cached := cache.Storage.Get(key)
+	cached = cached && entry.matches(req)
+	ttl := cache.ttl(strings.TrimLeft(strings.TrimPrefix(req.URL.Path, c.baseURL.Path), "/"))
+	if cached && ttl > 0 && time.Since(entry.StoredAt) < ttl {
+		return entry.response(req), nil
+	}
+	if cached {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached {
+		resp.Body.Close()
+		if storable(resp) {
+			entry.StoredAt = time.Now()
+			cache.Storage.Set(key, entry)
+		} else {
+			cache.Storage.Delete(key)
+		}
+		return entry.response(req), nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "" && ttl <= 0) {
+		return resp, nil
+	}
+	if !storable(resp) {
+		cache.Storage.Delete(key)
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	cache.Storage.Set(key, &CacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		Vary:         varyHeaders(req, resp),
+		StoredAt:     time.Now(),
+	})
+	return resp, nil
+}
+
+// cacheKey returns the key of the response to a request: its URL along with a hash of its credentials.
+// ok is false if the credentials are not known yet, i.e. before the first token of an OAuth2Transport
+func (c *Client) cacheKey(req *http.Request) (key string, ok bool) {
+	credentials, ok := transportCredentials(c.client, req)
+	if !ok {
+		return "", false
+	}
+	credentials = append(credentials, req.Header.Get("Authorization"), strings.Join(req.Header.Values("Cookie"), "; "))
+
+	hash := sha256.New()
+	for _, credential := range credentials {
+		hash.Write([]byte(credential))
+		hash.Write([]byte{0})
+	}
+	return req.URL.String() + " " + hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// transportCredentials returns the credentials added to a request by the cookie jar and the authentication
+// transports of httpClient, after the cache. ok is false if they are not known yet
+func transportCredentials(httpClient httpClient, req *http.Request) (credentials []string, ok bool) {
+	client, isHTTPClient := httpClient.(*http.Client)
+	if !isHTTPClient {
+		return nil, true
+	}
+	if client.Jar != nil {
+		for _, cookie := range client.Jar.Cookies(req.URL) {
+			credentials = append(credentials, "cookie:"+cookie.String())
+		}
+	}
+
+	transport := client.Transport
+	for {
+		switch t := transport.(type) {
+		case *APIKeyTransport:
+			credentials = append(credentials, "apikey:"+t.APIKey)
+			transport = t.Transport
+		case *BasicAuthTransport:
+			credentials = append(credentials, "basic:"+t.Username+":"+t.Password)
+			transport = t.Transport
+		case *CookieAuthTransport:
+			// The session changes on every login, the user behind it does not
+			credentials = append(credentials, "session:"+t.AuthURL+":"+t.Username)
+			transport = t.Transport
+		case *JWTAuthTransport:
+			credentials = append(credentials, "jwt:"+t.Issuer+":"+string(t.Secret))
+			transport = t.Transport
+		case *OAuth2Transport:
+			token := t.storedToken()
+			if token == nil || token.AccessToken == "" {
+				return nil, false
+			}
+			credentials = append(credentials, "oauth2:"+token.AccessToken)
+			transport = t.Transport
+		default:
+			return credentials, true
+		}
+	}
+}
+
+// storable tells whether the Cache-Control header of a response allows to store it
+func storable(resp *http.Response) bool {
+	for _, directive := range strings.Split(strings.Join(resp.Header.Values("Cache-Control"), ","), ",") {
+		switch strings.ToLower(strings.TrimSpace(strings.SplitN(directive, "=", 2)[0])) {
+		case "no-store", "private":
+			return false
+		}
+	}
+	return !strings.Contains(resp.Header.Get("Vary"), "*")
+}
+
+// varyHeaders returns the request headers named by the Vary header of a response
+func varyHeaders(req *http.Request, resp *http.Response) http.Header {
+	var vary http.Header
+	for _, names := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				if vary == nil {
+					vary = make(http.Header)
+				}
+				vary[http.CanonicalHeaderKey(name)] = req.Header.Values(name)
+			}
+		}
+	}
+	return vary
+}
+
+// matches tells whether a request has the values of the entry for the headers its response varies on
+func (e *CacheEntry) matches(req *http.Request) bool {
+	for name, values := range e.Vary {
+		if strings.Join(values, ",") != strings.Join(req.Header.Values(name), ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// ttl returns the TTL of a path, 0 if it has none
+func (o *CacheOptions) ttl(reqPath string) time.Duration {
+	for _, rule := range o.TTLs {
+		if matched, _ := path.Match(strings.TrimLeft(rule.Pattern, "/"), reqPath); matched {
+			return rule.TTL
+		}
+	}
+	return 0
+}
+
+// response builds an HTTP response out of a cache entry
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// memoryCache is an in-memory CacheStorage evicting the least recently used entries
+type memoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+}
+
+// memoryCacheItem is an element of the LRU list of memoryCache
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns an in-memory CacheStorage keeping up to maxEntries responses (1000 if not positive),
+// evicting the least recently used ones
+func NewMemoryCache(maxEntries int) CacheStorage {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &memoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get returns a stored entry
+func (m *memoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(element)
+	entry := *element.Value.(*memoryCacheItem).entry
+	return &entry, true
+}
+
+// Set stores an entry, evicting the least recently used one if the cache is full
+func (m *memoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		m.lru.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(&memoryCacheItem{key: key, entry: entry})
+	for m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes an entry
+func (m *memoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		m.lru.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+// diskCache is a CacheStorage keeping every entry as a JSON file within a directory
+type diskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a CacheStorage keeping responses as files within dir, so they survive restarts.
+// dir is created if it does not exist
+func NewDiskCache(dir string) (CacheStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &diskCache{dir: dir}, nil
+}
+
+// file returns the file of an entry
+func (d *diskCache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns a stored entry. Unreadable entries are reported as missing
+func (d *diskCache) Get(key string) (*CacheEntry, bool) {
+	raw, err := ioutil.ReadFile(d.file(key))
+	if err != nil {
+		return nil, false
+	}
+	entry := new(CacheEntry)
+	if err := json.Unmarshal(raw, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set stores an entry, writing a temporary file first so readers never see a partial entry
+func (d *diskCache) Set(key string, entry *CacheEntry) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(d.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.file(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes an entry
+func (d *diskCache) Delete(key string) {
+	os.Remove(d.file(key))
+}
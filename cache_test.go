@@ -0,0 +1,165 @@
+package openproject
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Cache_ETag(t *testing.T) {
+	setup()
+	defer teardown()
+	calls, notModified := 0, 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testClient.SetCache(&CacheOptions{})
+
+	for i := 0; i < 3; i++ {
+		status, _, err := testClient.Status.Get("1")
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if status.Name != "New" {
+			t.Errorf("Expected status served from cache, %+v given", status)
+		}
+	}
+	if calls != 3 || notModified != 2 {
+		t.Errorf("Expected 3 calls, 2 of them not modified, %d and %d given", calls, notModified)
+	}
+}
+
+func TestClient_Cache_TTLAndInvalidation(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/api/v3/queries/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Last-Modified", "Mon, 01 Feb 2021 10:00:00 GMT")
+		fmt.Fprint(w, `{"_type":"Query","id":1,"name":"Project plan"}`)
+	})
+	testClient.SetCache(&CacheOptions{
+		Storage: NewMemoryCache(10),
+		TTLs:    []CacheTTL{{Pattern: "api/v3/queries/*", TTL: time.Hour}},
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := testClient.Query.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single call within the TTL, %d done", calls)
+	}
+
+	// Updates invalidate the cached response
+	if _, _, err := testClient.Query.Update("1", &QueryPayload{Name: "Project plan"}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Query.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected the query requested again after the update, %d calls done", calls)
+	}
+}
+
+func TestClient_Cache_Credentials(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/users/me", func(w http.ResponseWriter, r *http.Request) {
+		_, key, _ := r.BasicAuth()
+		w.Header().Set("ETag", `"`+key+`"`)
+		fmt.Fprintf(w, `{"_type":"User","id":1,"login":%q}`, key)
+	})
+	storage := NewMemoryCache(10)
+	options := []CacheTTL{{Pattern: "api/v3/users/*", TTL: time.Hour}}
+
+	// Clients of different users share the storage without seeing the responses of each other
+	for _, key := range []string{"alice", "bob", "alice"} {
+		client, _ := NewClient((&APIKeyTransport{APIKey: key}).Client(), testServer.URL)
+		client.SetCache(&CacheOptions{Storage: storage, TTLs: options})
+		user, _, err := client.User.Get("me")
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if user.Login != key {
+			t.Errorf("Expected the user of %s, %s given", key, user.Login)
+		}
+	}
+}
+
+func TestClient_Cache_NoStore(t *testing.T) {
+	setup()
+	defer teardown()
+	conditional := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "private, max-age=0")
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testClient.SetCache(&CacheOptions{})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := testClient.Status.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if conditional != 0 {
+		t.Errorf("Expected private responses not stored, %d conditional requests sent", conditional)
+	}
+}
+
+func TestMemoryCache_LRU(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{ETag: "a"})
+	cache.Set("b", &CacheEntry{ETag: "b"})
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{ETag: "c"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected least recently used entry evicted")
+	}
+	if entry, ok := cache.Get("a"); !ok || entry.ETag != "a" {
+		t.Error("Expected recently used entry kept")
+	}
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected entry deleted")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Set("https://op.example.com/api/v3/statuses/1", &CacheEntry{
+		ETag:       `"v1"`,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/hal+json"}},
+		Body:       []byte(`{"id":1}`),
+		StoredAt:   time.Now(),
+	})
+
+	entry, ok := cache.Get("https://op.example.com/api/v3/statuses/1")
+	if !ok || entry.ETag != `"v1"` || string(entry.Body) != `{"id":1}` || entry.Header.Get("Content-Type") != "application/hal+json" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	cache.Delete("https://op.example.com/api/v3/statuses/1")
+	if _, ok := cache.Get("https://op.example.com/api/v3/statuses/1"); ok {
+		t.Error("Expected entry deleted")
+	}
+}
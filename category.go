@@ -31,6 +31,9 @@ type Category struct {
 func (s *CategoryService) GetWithContext(ctx context.Context, categoryID string) (*Category, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/categories/%s", categoryID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*Category), Resp, err
 }
 
@@ -43,6 +46,9 @@ func (s *CategoryService) Get(categoryID string) (*Category, *Response, error) {
 func (s *CategoryService) GetListWithContext(ctx context.Context, projectID string) (*CategoryList, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/categories", projectID)
 	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*CategoryList), Resp, err
 }
 
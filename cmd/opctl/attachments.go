@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"text/tabwriter"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"github.com/pkg/errors"
+)
+
+// attachmentCommand uploads and downloads attachments of work packages
+func attachmentCommand() *command {
+	return &command{
+		name:    "attachment",
+		summary: "List, upload and download attachments of work packages",
+		subcommands: []*command{
+			{
+				name:    "list",
+				args:    "<work package id>",
+				summary: "List the attachments of a work package",
+				setup:   listAttachments,
+			},
+			{
+				name:    "upload",
+				args:    "<work package id> <file>...",
+				summary: "Upload files as attachments of a work package",
+				setup:   uploadAttachments,
+			},
+			{
+				name:    "download",
+				args:    "<attachment id>",
+				summary: "Download an attachment, into a file named after it unless --out is given",
+				setup:   downloadAttachment,
+			},
+		},
+	}
+}
+
+// printAttachments writes attachments as table rows
+func printAttachments(tw *tabwriter.Writer, attachments []openproject.Attachment) {
+	row(tw, "ID", "FILE NAME", "SIZE", "CONTENT TYPE", "CREATED")
+	for _, attachment := range attachments {
+		row(tw, attachment.ID, attachment.FileName, attachment.FileSize, attachment.ContentType, formatTime(attachment.CreatedAt))
+	}
+}
+
+// listAttachments lists the attachments of a work package
+func listAttachments(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		attachments, err := getWorkPackageAttachments(a.ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		return a.print(attachments, func(tw *tabwriter.Writer) {
+			printAttachments(tw, attachments)
+		})
+	}
+}
+
+// uploadAttachments uploads files as attachments of a work package
+func uploadAttachments(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) < 2 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		endpoint := fmt.Sprintf("api/v3/work_packages/%s/attachments", args[0])
+		uploaded := make([]openproject.Attachment, 0, len(args)-1)
+		for _, path := range args[1:] {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			attachment, _, err := client.Attachments().UploadWithContext(a.ctx, endpoint, filepath.Base(path), content)
+			if err != nil {
+				return errors.Wrapf(err, "could not upload %s", path)
+			}
+			uploaded = append(uploaded, *attachment)
+		}
+		return a.print(uploaded, func(tw *tabwriter.Writer) {
+			printAttachments(tw, uploaded)
+		})
+	}
+}
+
+// downloadAttachment downloads an attachment into a file, or to the standard output with --out -
+func downloadAttachment(fs *flag.FlagSet) runner {
+	out := fs.String("out", "", "file to write, \"-\" for the standard output (default the file name of the attachment)")
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		path := *out
+		if path == "" {
+			attachment, _, err := client.Attachments().GetWithContext(a.ctx, args[0])
+			if err != nil {
+				return err
+			}
+			// Never write outside of the working directory because of the name of an attachment
+			path = filepath.Base(attachment.FileName)
+		}
+		content, err := client.Attachments().DownloadWithContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if path == "-" {
+			_, err := a.stdout.Write(*content)
+			return err
+		}
+		if err := ioutil.WriteFile(path, *content, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "Downloaded %s (%d bytes)\n", path, len(*content))
+		return nil
+	}
+}
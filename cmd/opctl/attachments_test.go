@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+func TestAttachment_UploadListDownload(t *testing.T) {
+	c := newTestCLI(t)
+	seedWorkPackages(c)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	if err := ioutil.WriteFile(path, []byte("Steps to reproduce"), 0644); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	var uploaded []openproject.Attachment
+	if err := json.Unmarshal([]byte(c.run("attachment", "upload", "1", path, "-o", "json")), &uploaded); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].FileName != "notes.txt" || uploaded[0].FileSize != 18 {
+		t.Errorf("Unexpected attachments uploaded: %+v", uploaded)
+	}
+
+	stdout := c.run("attachment", "list", "1")
+	if !strings.Contains(stdout, "notes.txt") || !strings.Contains(stdout, "18") {
+		t.Errorf("Expected the attachment to be listed, got:\n%s", stdout)
+	}
+
+	if stdout := c.run("attachment", "download", "1", "--out", "-"); stdout != "Steps to reproduce" {
+		t.Errorf("Expected the content, got %q", stdout)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(dir)
+	os.Remove(path)
+	c.run("attachment", "download", "1")
+	if content, err := ioutil.ReadFile(path); err != nil || string(content) != "Steps to reproduce" {
+		t.Errorf("Expected the attachment to be downloaded into its file name, got %q, %v", content, err)
+	}
+}
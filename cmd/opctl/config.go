@@ -0,0 +1,259 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the configuration file of opctl, holding a profile per OpenProject instance
+type config struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile holds how to reach an OpenProject instance
+type profile struct {
+	URL    string `yaml:"url"`
+	APIKey string `yaml:"apiKey,omitempty"`
+	Output string `yaml:"output,omitempty"`
+}
+
+// defaultConfigPath returns the path of the configuration file, $OPCTL_CONFIG or opctl/config.yaml
+// within the configuration directory of the user (i.e. ~/.config on Linux)
+func defaultConfigPath() (string, error) {
+	if path := os.Getenv(envConfig); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "opctl", "config.yaml"), nil
+}
+
+// loadConfig reads the configuration file, a missing file is an empty configuration
+func (a *app) loadConfig() (*config, error) {
+	if a.config != nil {
+		return a.config, nil
+	}
+	if a.configPath == "" {
+		path, err := defaultConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		a.configPath = path
+	}
+
+	cfg := &config{Profiles: make(map[string]*profile)}
+	raw, err := ioutil.ReadFile(a.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %s", a.configPath, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*profile)
+	}
+	a.config = cfg
+	return cfg, nil
+}
+
+// saveConfig writes the configuration file. It holds API keys, so only the user can read it
+func (a *app) saveConfig() error {
+	raw, err := yaml.Marshal(a.config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(a.configPath), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(a.configPath, raw, 0600)
+}
+
+// currentProfile returns the profile selected by --profile, $OPCTL_PROFILE or the configuration,
+// overridden by --url, --api-key, $OPENPROJECT_URL and $OPENPROJECT_API_KEY
+func (a *app) currentProfile() (*profile, error) {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstNonEmpty(a.profile, os.Getenv(envProfile), cfg.Current)
+	selected := new(profile)
+	if name != "" {
+		p, ok := cfg.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found in %s", name, a.configPath)
+		}
+		*selected = *p
+	}
+	selected.URL = firstNonEmpty(a.url, os.Getenv(envURL), selected.URL)
+	selected.APIKey = firstNonEmpty(a.apiKey, os.Getenv(envAPIKey), selected.APIKey)
+	selected.Output = firstNonEmpty(a.output, selected.Output)
+	return selected, nil
+}
+
+// connect returns the client of the current profile
+func (a *app) connect() (*openproject.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	p, err := a.currentProfile()
+	if err != nil {
+		return nil, err
+	}
+	if p.URL == "" {
+		return nil, fmt.Errorf("no OpenProject instance configured, run \"opctl config set <profile> --url <url> --api-key <key>\" or set $%s", envURL)
+	}
+
+	httpClient := http.DefaultClient
+	if p.APIKey != "" {
+		httpClient = (&openproject.APIKeyTransport{APIKey: p.APIKey}).Client()
+	}
+	client, err := openproject.NewClient(httpClient, p.URL)
+	if err != nil {
+		return nil, err
+	}
+	a.client = client
+	return client, nil
+}
+
+// firstNonEmpty returns the first of values which is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// configCommand manages the profiles of the configuration file
+func configCommand() *command {
+	return &command{
+		name:    "config",
+		summary: "Manage the profiles of OpenProject instances",
+		subcommands: []*command{
+			{
+				name:    "list",
+				summary: "List the profiles, the current one is marked with *",
+				setup: func(fs *flag.FlagSet) runner {
+					return func(a *app, args []string) error {
+						if len(args) != 0 {
+							return errUsage
+						}
+						cfg, err := a.loadConfig()
+						if err != nil {
+							return err
+						}
+						names := make([]string, 0, len(cfg.Profiles))
+						for name := range cfg.Profiles {
+							names = append(names, name)
+						}
+						sort.Strings(names)
+
+						tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+						fmt.Fprintln(tw, "CURRENT\tNAME\tURL\tOUTPUT")
+						for _, name := range names {
+							current := ""
+							if name == cfg.Current {
+								current = "*"
+							}
+							fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", current, name, cfg.Profiles[name].URL, cfg.Profiles[name].Output)
+						}
+						return tw.Flush()
+					}
+				},
+			},
+			{
+				name:    "set",
+				args:    "<profile>",
+				summary: "Create or change a profile, the first one created becomes the current one",
+				setup: func(fs *flag.FlagSet) runner {
+					return func(a *app, args []string) error {
+						if len(args) != 1 {
+							return errUsage
+						}
+						if a.output != "" && !validOutput(a.output) {
+							return fmt.Errorf("unknown output format %q", a.output)
+						}
+						cfg, err := a.loadConfig()
+						if err != nil {
+							return err
+						}
+						p, ok := cfg.Profiles[args[0]]
+						if !ok {
+							if a.url == "" {
+								return fmt.Errorf("the URL of a new profile is required")
+							}
+							p = new(profile)
+							cfg.Profiles[args[0]] = p
+						}
+						p.URL = firstNonEmpty(a.url, p.URL)
+						p.APIKey = firstNonEmpty(a.apiKey, p.APIKey)
+						p.Output = firstNonEmpty(a.output, p.Output)
+						if cfg.Current == "" {
+							cfg.Current = args[0]
+						}
+						return a.saveConfig()
+					}
+				},
+			},
+			{
+				name:    "use",
+				args:    "<profile>",
+				summary: "Select the current profile",
+				setup: func(fs *flag.FlagSet) runner {
+					return func(a *app, args []string) error {
+						if len(args) != 1 {
+							return errUsage
+						}
+						cfg, err := a.loadConfig()
+						if err != nil {
+							return err
+						}
+						if _, ok := cfg.Profiles[args[0]]; !ok {
+							return fmt.Errorf("profile %q not found in %s", args[0], a.configPath)
+						}
+						cfg.Current = args[0]
+						return a.saveConfig()
+					}
+				},
+			},
+			{
+				name:    "delete",
+				args:    "<profile>",
+				summary: "Delete a profile",
+				setup: func(fs *flag.FlagSet) runner {
+					return func(a *app, args []string) error {
+						if len(args) != 1 {
+							return errUsage
+						}
+						cfg, err := a.loadConfig()
+						if err != nil {
+							return err
+						}
+						if _, ok := cfg.Profiles[args[0]]; !ok {
+							return fmt.Errorf("profile %q not found in %s", args[0], a.configPath)
+						}
+						delete(cfg.Profiles, args[0])
+						if cfg.Current == args[0] {
+							cfg.Current = ""
+						}
+						return a.saveConfig()
+					}
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+func TestConfig_Profiles(t *testing.T) {
+	c := newTestCLI(t)
+	c.server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+
+	for _, args := range [][]string{
+		{"config", "set", "work", "--url", c.server.URL, "--api-key", "s3cr3t"},
+		{"config", "set", "staging", "--url", "http://staging.invalid", "-o", "json"},
+	} {
+		if _, stderr, code := c.exec(args...); code != 0 {
+			t.Fatalf("opctl %s exited with %d: %s", strings.Join(args, " "), code, stderr)
+		}
+	}
+	info, err := os.Stat(c.config)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the configuration to be readable by the user only, got %s", info.Mode())
+	}
+
+	stdout, _, _ := c.exec("config", "list")
+	if !strings.Contains(stdout, "*        work") || strings.Contains(stdout, "s3cr3t") {
+		t.Errorf("Expected the first profile to be the current one, got:\n%s", stdout)
+	}
+
+	// The current profile is used
+	stdout, stderr, code := c.exec("project", "show", "demo")
+	if code != 0 || !strings.Contains(stdout, "Identifier:  demo") {
+		t.Errorf("Expected the project, got %d: %s%s", code, stdout, stderr)
+	}
+
+	// Selected by flag or environment, its output format is used
+	_, stderr, code = c.exec("--profile", "staging", "project", "show", "demo")
+	if code != 1 || !strings.Contains(stderr, "staging.invalid") {
+		t.Errorf("Expected the staging instance to be requested, got %d: %s", code, stderr)
+	}
+	setenv(t, envProfile, "staging")
+	stdout, stderr, code = c.exec("project", "show", "demo", "--url", c.server.URL)
+	if code != 0 || !strings.Contains(stdout, `"identifier": "demo"`) {
+		t.Errorf("Expected the project as JSON, got %d: %s%s", code, stdout, stderr)
+	}
+	setenv(t, envProfile, "")
+
+	if _, stderr, code := c.exec("config", "use", "staging"); code != 0 {
+		t.Fatalf("Expected the profile to be selected, got %d: %s", code, stderr)
+	}
+	stdout, _, _ = c.exec("config", "list")
+	if !strings.Contains(stdout, "*        staging") {
+		t.Errorf("Expected staging to be the current profile, got:\n%s", stdout)
+	}
+	if _, stderr, code := c.exec("config", "use", "production"); code != 1 || !strings.Contains(stderr, `profile "production" not found`) {
+		t.Errorf("Expected a missing profile, got %d: %s", code, stderr)
+	}
+
+	if _, stderr, code := c.exec("config", "delete", "staging"); code != 0 {
+		t.Fatalf("Expected the profile to be deleted, got %d: %s", code, stderr)
+	}
+	_, stderr, code = c.exec("project", "list")
+	if code != 1 || !strings.Contains(stderr, "no OpenProject instance configured") {
+		t.Errorf("Expected no current profile, got %d: %s", code, stderr)
+	}
+}
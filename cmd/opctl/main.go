@@ -0,0 +1,253 @@
+// opctl is a command line client for OpenProject built on go-openproject.
+//
+//	opctl config set work --url https://openproject.example.com --api-key 0123abcd
+//	opctl wp list status:open assignee:me sort:-updated
+//	opctl wp show 42 -o yaml
+//	opctl wp transition 42 "In progress"
+//	opctl time log 42 1h30m --comment "Code review"
+//
+// Run "opctl help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// Environment variables overriding the selected profile
+const (
+	envConfig  = "OPCTL_CONFIG"
+	envProfile = "OPCTL_PROFILE"
+	envURL     = "OPENPROJECT_URL"
+	envAPIKey  = "OPENPROJECT_API_KEY"
+)
+
+// errUsage is returned when the command line is wrong, the usage of the command has been printed already
+var errUsage = errors.New("invalid usage")
+
+// command is a command of opctl, either running something or grouping subcommands.
+// setup defines the flags of the command and returns the function running it with the positional arguments
+type command struct {
+	name        string
+	args        string
+	summary     string
+	setup       func(fs *flag.FlagSet) runner
+	subcommands []*command
+}
+
+// runner runs a command with its positional arguments
+type runner func(a *app, args []string) error
+
+// app holds what commands share: the options common to every command, the output and the client
+type app struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+
+	configPath string
+	profile    string
+	url        string
+	apiKey     string
+	output     string
+
+	config *config
+	client *openproject.Client
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line given as args and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{ctx: ctx, stdout: stdout, stderr: stderr}
+	if err := a.run(rootCommand(), nil, args); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "opctl: %s\n", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// rootCommand returns the tree of commands of opctl
+func rootCommand() *command {
+	return &command{
+		name: "opctl",
+		subcommands: []*command{
+			workPackageCommand(),
+			timeCommand(),
+			attachmentCommand(),
+			projectCommand(),
+			userCommand(),
+			configCommand(),
+		},
+	}
+}
+
+// run finds the command named by args within cmd and runs it
+func (a *app) run(cmd *command, path []string, args []string) error {
+	path = append(path, cmd.name)
+	if cmd.setup == nil {
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			a.usage(cmd, path)
+			if len(args) == 0 {
+				return errUsage
+			}
+			return nil
+		}
+		for _, sub := range cmd.subcommands {
+			if sub.name == args[0] {
+				return a.run(sub, path, args[1:])
+			}
+		}
+		if strings.HasPrefix(args[0], "-") {
+			// Common flags given before the command
+			fs := a.flagSet(cmd, path)
+			rest, err := parseFlags(fs, args, true)
+			if err != nil {
+				return err
+			}
+			return a.run(cmd, path[:len(path)-1], rest)
+		}
+		fmt.Fprintf(a.stderr, "opctl: unknown command %q\n", strings.Join(append(path[1:], args[0]), " "))
+		a.usage(cmd, path)
+		return errUsage
+	}
+
+	fs := a.flagSet(cmd, path)
+	run := cmd.setup(fs)
+	positional, err := parseFlags(fs, args, false)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if err := run(a, positional); err != nil {
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+		}
+		return err
+	}
+	return nil
+}
+
+// flagSet returns a flag set holding the flags common to every command
+func (a *app) flagSet(cmd *command, path []string) *flag.FlagSet {
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.configPath, "config", a.configPath, "path of the configuration file")
+	fs.StringVar(&a.profile, "profile", a.profile, "profile of the configuration to use")
+	fs.StringVar(&a.url, "url", a.url, "URL of the OpenProject instance, overrides the profile")
+	fs.StringVar(&a.apiKey, "api-key", a.apiKey, "API key, overrides the profile")
+	fs.StringVar(&a.output, "o", a.output, "output format: table, json or yaml")
+	fs.StringVar(&a.output, "output", a.output, "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: %s", strings.Join(path, " "))
+		if cmd.args != "" {
+			fmt.Fprintf(a.stderr, " %s", cmd.args)
+		}
+		fmt.Fprintf(a.stderr, " [flags]\n\n")
+		if cmd.summary != "" {
+			fmt.Fprintf(a.stderr, "%s\n\n", cmd.summary)
+		}
+		fmt.Fprintf(a.stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// usage prints the subcommands of cmd
+func (a *app) usage(cmd *command, path []string) {
+	fmt.Fprintf(a.stderr, "Usage: %s <command> [flags]\n\nCommands:\n", strings.Join(path, " "))
+	for _, sub := range cmd.subcommands {
+		fmt.Fprintf(a.stderr, "  %-12s %s\n", sub.name, sub.summary)
+	}
+	fmt.Fprintf(a.stderr, "\nRun \"%s <command> -h\" for the flags of a command.\n", strings.Join(path, " "))
+}
+
+// parseFlags parses flags placed anywhere within args and returns the positional arguments.
+// Arguments looking like flags which are not defined, i.e. "-status:closed" negating a filter, are positional.
+// If leading is set, parsing stops at the first positional argument, which is returned along with the rest
+func parseFlags(fs *flag.FlagSet, args []string, leading bool) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" || !definesFlag(fs, arg) {
+			if leading {
+				return args, nil
+			}
+			positional = append(positional, arg)
+			args = args[1:]
+			continue
+		}
+		// One flag at a time, the next argument may be an undefined one
+		n := flagLength(fs, args)
+		if err := fs.Parse(args[:n]); err != nil {
+			return nil, err
+		}
+		args = args[n:]
+	}
+	return positional, nil
+}
+
+// flagLength returns the number of arguments taken by the flag at the start of args, its value included
+func flagLength(fs *flag.FlagSet, args []string) int {
+	name := strings.TrimLeft(args[0], "-")
+	if strings.Contains(name, "=") || len(args) == 1 {
+		return 1
+	}
+	if f := fs.Lookup(name); f != nil {
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			return 1
+		}
+		return 2
+	}
+	return 1
+}
+
+// definesFlag reports whether arg, like "-o" or "--output=json", is a flag of fs
+func definesFlag(fs *flag.FlagSet, arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	if name == "h" || name == "help" {
+		return true
+	}
+	return fs.Lookup(name) != nil
+}
+
+// queryCondition splits an argument like `subject~"login page"` into field with operator and value
+var queryCondition = regexp.MustCompile(`^(-?[A-Za-z][A-Za-z0-9_.]*(?:>=|<=|<>|!~|\*\*|:|>|<|~))(.*)$`)
+
+// joinQuery joins the arguments of a text query. Arguments holding spaces were quoted within
+// the shell, so their value is quoted again for the query parser
+func joinQuery(args []string) string {
+	words := make([]string, len(args))
+	for i, arg := range args {
+		words[i] = arg
+		if !strings.ContainsAny(arg, " \t") || strings.Contains(arg, `"`) {
+			continue
+		}
+		prefix, value := "", arg
+		if m := queryCondition.FindStringSubmatch(arg); m != nil {
+			prefix, value = m[1], m[2]
+		}
+		words[i] = prefix + strconv.Quote(value)
+	}
+	return strings.Join(words, " ")
+}
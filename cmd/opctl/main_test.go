@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manuelbcd/go-openproject/openprojecttest"
+)
+
+// testCLI runs opctl against a fake server, with a configuration file of its own.
+// Endpoints the fake server lacks can be served by routes, in front of it
+type testCLI struct {
+	t      *testing.T
+	server *openprojecttest.Server
+	config string
+	url    string
+	routes map[string]http.HandlerFunc
+}
+
+// setenv sets an environment variable for the duration of a test
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	previous, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// newTestCLI returns a testCLI, the fake server is closed at the end of the test
+func newTestCLI(t *testing.T) *testCLI {
+	for _, env := range []string{envConfig, envProfile, envURL, envAPIKey} {
+		setenv(t, env, "")
+	}
+	server := openprojecttest.NewServer()
+	t.Cleanup(server.Close)
+	return &testCLI{t: t, server: server, config: filepath.Join(t.TempDir(), "config.yaml"), url: server.URL}
+}
+
+// route serves requests to path with handler instead of the fake server
+func (c *testCLI) route(path string, handler http.HandlerFunc) {
+	if c.routes == nil {
+		c.routes = make(map[string]http.HandlerFunc)
+		target, _ := url.Parse(c.server.URL)
+		proxy := httputil.NewSingleHostReverseProxy(target)
+		front := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if handler, ok := c.routes[r.URL.Path]; ok {
+				handler(w, r)
+				return
+			}
+			proxy.ServeHTTP(w, r)
+		}))
+		c.t.Cleanup(front.Close)
+		c.url = front.URL
+	}
+	c.routes[path] = handler
+}
+
+// run runs opctl with the URL of the fake server and returns its standard output.
+// The test fails if opctl fails
+func (c *testCLI) run(args ...string) string {
+	c.t.Helper()
+	stdout, stderr, code := c.exec(append([]string{"--url", c.url}, args...)...)
+	if code != 0 {
+		c.t.Fatalf("opctl %s exited with %d: %s", strings.Join(args, " "), code, stderr)
+	}
+	return stdout
+}
+
+// exec runs opctl with the configuration file of the test only
+func (c *testCLI) exec(args ...string) (stdout string, stderr string, code int) {
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), append([]string{"--config", c.config}, args...), &out, &errOut)
+	return out.String(), errOut.String(), code
+}
+
+func TestRun_Usage(t *testing.T) {
+	c := newTestCLI(t)
+
+	_, stderr, code := c.exec()
+	if code != 1 || !strings.Contains(stderr, "Usage: opctl <command>") {
+		t.Errorf("Expected the usage, got %d: %s", code, stderr)
+	}
+	_, stderr, code = c.exec("wp", "frobnicate")
+	if code != 1 || !strings.Contains(stderr, `unknown command "wp frobnicate"`) {
+		t.Errorf("Expected an unknown command, got %d: %s", code, stderr)
+	}
+	_, stderr, code = c.exec("wp", "show")
+	if code != 1 || !strings.Contains(stderr, "Usage: opctl wp show <id> [flags]") {
+		t.Errorf("Expected the usage of the command, got %d: %s", code, stderr)
+	}
+	_, stderr, code = c.exec("wp", "show", "1")
+	if code != 1 || !strings.Contains(stderr, "no OpenProject instance configured") {
+		t.Errorf("Expected a missing instance, got %d: %s", code, stderr)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args       []string
+		positional []string
+		output     string
+		verbose    bool
+	}{
+		{[]string{"status:open", "-o", "json", "assignee:me"}, []string{"status:open", "assignee:me"}, "json", false},
+		{[]string{"--output=yaml", "-status:closed", "-v", "42"}, []string{"-status:closed", "42"}, "yaml", true},
+		{[]string{"-v", "--", "-o", "json"}, []string{"-o", "json"}, "", true},
+	}
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		output := fs.String("o", "", "")
+		fs.StringVar(output, "output", "", "")
+		verbose := fs.Bool("v", false, "")
+		positional, err := parseFlags(fs, test.args, false)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if !reflect.DeepEqual(positional, test.positional) || *output != test.output || *verbose != test.verbose {
+			t.Errorf("Parsing %q, expected %q, %q and %v, got %q, %q and %v", test.args,
+				test.positional, test.output, test.verbose, positional, *output, *verbose)
+		}
+	}
+}
+
+func TestJoinQuery(t *testing.T) {
+	tests := map[string][]string{
+		`status:open "login page"`:              {"status:open", "login page"},
+		`subject~"login page" -type:1,2`:        {"subject~login page", "-type:1,2"},
+		`subject~"already \"quoted\"" sort:-id`: {`subject~"already \"quoted\""`, "sort:-id"},
+		``:                                      nil,
+	}
+	for expected, args := range tests {
+		if query := joinQuery(args); query != expected {
+			t.Errorf("Expected %s, got %s", expected, query)
+		}
+	}
+}
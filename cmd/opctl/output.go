@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"gopkg.in/yaml.v3"
+)
+
+// Constants to represent the output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// validOutput reports whether format is a known output format
+func validOutput(format string) bool {
+	return format == outputTable || format == outputJSON || format == outputYAML
+}
+
+// print writes value to the standard output in the format selected by --output or the profile.
+// Tables are written by table, JSON and YAML render value with the field names of the API
+func (a *app) print(value interface{}, table func(tw *tabwriter.Writer)) error {
+	p, err := a.currentProfile()
+	if err != nil {
+		return err
+	}
+	format := firstNonEmpty(p.Output, outputTable)
+
+	switch format {
+	case outputTable:
+		tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	case outputJSON:
+		return writeJSON(a.stdout, value)
+	case outputYAML:
+		return writeYAML(a.stdout, value)
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json or yaml", format)
+}
+
+// writeJSON writes value as indented JSON
+func writeJSON(w io.Writer, value interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(value)
+}
+
+// writeYAML writes value as YAML. It goes through JSON first so the keys are the ones of the API
+// and types like openproject.Time are rendered as they are by the API
+func writeYAML(w io.Writer, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// row writes the cells of a table row
+func row(tw *tabwriter.Writer, cells ...interface{}) {
+	values := make([]string, len(cells))
+	for i, cell := range cells {
+		values[i] = strings.ReplaceAll(fmt.Sprint(cell), "\t", " ")
+	}
+	fmt.Fprintln(tw, strings.Join(values, "\t"))
+}
+
+// linkTitle returns the title of a link, or the ID at the end of its href if it has no title
+func linkTitle(link *openproject.OPGenericLink) string {
+	if link == nil {
+		return ""
+	}
+	if link.Title != "" {
+		return link.Title
+	}
+	return hrefID(link.Href)
+}
+
+// fieldTitle is linkTitle for the links of a work package
+func fieldTitle(field openproject.WPLinksField) string {
+	return linkTitle(&openproject.OPGenericLink{Href: field.Href, Title: field.Title})
+}
+
+// hrefID returns the ID at the end of a resource href, i.e. "42" for "/api/v3/work_packages/42"
+func hrefID(href string) string {
+	if href == "" {
+		return ""
+	}
+	return href[strings.LastIndex(href, "/")+1:]
+}
+
+// formatTime renders an OpenProject time in the local time zone
+func formatTime(t *openproject.Time) string {
+	if t == nil {
+		return ""
+	}
+	return time.Time(*t).Local().Format("2006-01-02 15:04")
+}
+
+// formatDate renders an OpenProject date
+func formatDate(d *openproject.Date) string {
+	if d == nil {
+		return ""
+	}
+	return time.Time(*d).Format("2006-01-02")
+}
+
+// truncate shortens text to n runes for table cells, keeping its first line only
+func truncate(text string, n int) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		text = text[:i] + " …"
+	}
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// indent prefixes every line of text, for descriptions and comments within tables
+func indent(text string, prefix string) string {
+	var buf bytes.Buffer
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		buf.WriteString(prefix)
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
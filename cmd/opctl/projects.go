@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"text/tabwriter"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// projectCommand manages projects
+func projectCommand() *command {
+	return &command{
+		name:    "project",
+		summary: "List, show and create projects",
+		subcommands: []*command{
+			{
+				name:    "list",
+				summary: "List projects",
+				setup:   listProjects,
+			},
+			{
+				name:    "show",
+				args:    "<id or identifier>",
+				summary: "Show a project",
+				setup:   showProject,
+			},
+			{
+				name:    "create",
+				summary: "Create a project",
+				setup:   createProject,
+			},
+		},
+	}
+}
+
+// printProject writes the fields of a project as table rows
+func printProject(tw *tabwriter.Writer, project *openproject.Project) {
+	row(tw, "ID:", project.ID)
+	row(tw, "Identifier:", project.Identifier)
+	row(tw, "Name:", project.Name)
+	row(tw, "Active:", project.Active)
+	row(tw, "Public:", project.Public)
+	row(tw, "Created:", formatTime(project.CreatedAt))
+	row(tw, "Updated:", formatTime(project.UpdatedAt))
+	if project.Description != nil && project.Description.Raw != "" {
+		row(tw)
+		tw.Write([]byte(indent(project.Description.Raw, "  ")))
+	}
+}
+
+// listProjects lists the projects visible to the user
+func listProjects(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 0 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		list, _, err := client.Projects().GetListWithContext(a.ctx)
+		if err != nil {
+			return err
+		}
+		projects := list.Embedded.Elements
+		return a.print(projects, func(tw *tabwriter.Writer) {
+			row(tw, "ID", "IDENTIFIER", "NAME", "ACTIVE", "PUBLIC")
+			for _, project := range projects {
+				row(tw, project.ID, project.Identifier, project.Name, project.Active, project.Public)
+			}
+		})
+	}
+}
+
+// showProject shows a project
+func showProject(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		project, _, err := client.Projects().GetWithContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.print(project, func(tw *tabwriter.Writer) {
+			printProject(tw, project)
+		})
+	}
+}
+
+// createProject creates a project
+func createProject(fs *flag.FlagSet) runner {
+	identifier := fs.String("identifier", "", "identifier, used within URLs (required)")
+	name := fs.String("name", "", "name (required)")
+	description := fs.String("description", "", "description, in markdown")
+	public := fs.Bool("public", false, "make the project visible to everyone")
+	return func(a *app, args []string) error {
+		if len(args) != 0 || *identifier == "" || *name == "" {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		project := &openproject.Project{Identifier: *identifier, Name: *name, Public: *public}
+		if *description != "" {
+			project.Description = &openproject.ProjDescription{Format: "markdown", Raw: *description}
+		}
+		created, _, err := client.Projects().CreateWithContext(a.ctx, project)
+		if err != nil {
+			return err
+		}
+		return a.print(created, func(tw *tabwriter.Writer) {
+			printProject(tw, created)
+		})
+	}
+}
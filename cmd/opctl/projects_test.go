@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_CreateListShow(t *testing.T) {
+	c := newTestCLI(t)
+
+	stdout := c.run("project", "create", "--identifier", "demo", "--name", "Demo project", "--description", "For *demos*", "--public")
+	if !strings.Contains(stdout, "Identifier:  demo") || !strings.Contains(stdout, "Public:      true") ||
+		!strings.Contains(stdout, "  For *demos*") {
+		t.Errorf("Expected the project to be created, got:\n%s", stdout)
+	}
+
+	stdout = c.run("project", "list")
+	if !strings.Contains(stdout, "1   demo        Demo project") {
+		t.Errorf("Expected the project to be listed, got:\n%s", stdout)
+	}
+
+	stdout = c.run("project", "show", "1", "-o", "yaml")
+	if !strings.Contains(stdout, "identifier: demo\n") || !strings.Contains(stdout, "raw: For *demos*\n") {
+		t.Errorf("Expected the project as YAML, got:\n%s", stdout)
+	}
+}
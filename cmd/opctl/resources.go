@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// The library has no services for relations, activities and time entries yet,
+// their endpoints are requested through the client
+
+// relation is a relation between two work packages, "from" relates to "to"
+type relation struct {
+	Type        string        `json:"_type,omitempty"`
+	ID          int           `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	RelType     string        `json:"type,omitempty"`
+	ReverseType string        `json:"reverseType,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Lag         int           `json:"lag,omitempty"`
+	Links       relationLinks `json:"_links"`
+}
+
+// relationLinks are relation Links
+type relationLinks struct {
+	From *openproject.OPGenericLink `json:"from,omitempty"`
+	To   *openproject.OPGenericLink `json:"to,omitempty"`
+}
+
+// activity is a journal entry of a work package: a comment and the changes of an update
+type activity struct {
+	Type      string                             `json:"_type,omitempty"`
+	ID        int                                `json:"id,omitempty"`
+	Version   int                                `json:"version,omitempty"`
+	Comment   *openproject.OPGenericDescription  `json:"comment,omitempty"`
+	Details   []openproject.OPGenericDescription `json:"details,omitempty"`
+	CreatedAt *openproject.Time                  `json:"createdAt,omitempty"`
+	Links     activityLinks                      `json:"_links"`
+}
+
+// activityLinks are activity Links
+type activityLinks struct {
+	User *openproject.OPGenericLink `json:"user,omitempty"`
+}
+
+// relationList is a collection of relations
+type relationList struct {
+	Total    int `json:"total"`
+	Embedded struct {
+		Elements []relation `json:"elements"`
+	} `json:"_embedded"`
+}
+
+// activityList is a collection of activities
+type activityList struct {
+	Total    int `json:"total"`
+	Embedded struct {
+		Elements []activity `json:"elements"`
+	} `json:"_embedded"`
+}
+
+// call sends a request to an endpoint of the API and decodes the response into v
+func call(ctx context.Context, client *openproject.Client, method string, endpoint string, body interface{}, v interface{}) error {
+	req, err := client.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(req, v)
+	return err
+}
+
+// getRelations returns the relations of a work package
+func getRelations(ctx context.Context, client *openproject.Client, workPackageID string) ([]relation, error) {
+	list := new(relationList)
+	if err := call(ctx, client, "GET", fmt.Sprintf("api/v3/work_packages/%s/relations", workPackageID), nil, list); err != nil {
+		return nil, err
+	}
+	return list.Embedded.Elements, nil
+}
+
+// getActivities returns the activities of a work package, oldest first
+func getActivities(ctx context.Context, client *openproject.Client, workPackageID string) ([]activity, error) {
+	list := new(activityList)
+	if err := call(ctx, client, "GET", fmt.Sprintf("api/v3/work_packages/%s/activities", workPackageID), nil, list); err != nil {
+		return nil, err
+	}
+	return list.Embedded.Elements, nil
+}
+
+// createTimeEntry logs time spent
+func createTimeEntry(ctx context.Context, client *openproject.Client, entry *openproject.TimeEntry) (*openproject.TimeEntry, error) {
+	created := new(openproject.TimeEntry)
+	if err := call(ctx, client, "POST", "api/v3/time_entries", entry, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// getWorkPackageAttachments returns the attachments of a work package
+func getWorkPackageAttachments(ctx context.Context, client *openproject.Client, workPackageID string) ([]openproject.Attachment, error) {
+	list := new(openproject.SearchResultAttachment)
+	if err := call(ctx, client, "GET", fmt.Sprintf("api/v3/work_packages/%s/attachments", workPackageID), nil, list); err != nil {
+		return nil, err
+	}
+	return list.Embedded.Elements, nil
+}
+
+// href returns the link to a resource of the API, i.e. "/api/v3/statuses/7"
+func href(collection string, id interface{}) *openproject.OPGenericLink {
+	return &openproject.OPGenericLink{Href: fmt.Sprintf("/api/v3/%s/%v", collection, id)}
+}
+
+// wpLink is href for the links of a work package
+func wpLink(collection string, id interface{}) openproject.WPLinksField {
+	return openproject.WPLinksField{Href: href(collection, id).Href}
+}
+
+// resolveStatus returns the status named or identified by nameOrID, names are compared case-insensitively
+func resolveStatus(ctx context.Context, client *openproject.Client, nameOrID string) (*openproject.Status, error) {
+	list, _, err := client.Statuses().GetListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Embedded.Elements))
+	for i, status := range list.Embedded.Elements {
+		if strings.EqualFold(status.Name, nameOrID) || strconv.Itoa(status.ID) == nameOrID {
+			return &list.Embedded.Elements[i], nil
+		}
+		names = append(names, status.Name)
+	}
+	return nil, fmt.Errorf("unknown status %q, expected one of: %s", nameOrID, strings.Join(names, ", "))
+}
+
+// resolveUser returns the ID of a user given by ID, login or "me"
+func resolveUser(ctx context.Context, client *openproject.Client, user string) (string, error) {
+	if _, err := strconv.Atoi(user); err == nil {
+		return user, nil
+	}
+	if user == "me" {
+		me, _, err := client.Auth().GetCurrentUserWithContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(me.ID), nil
+	}
+	list, _, err := client.Users().GetListWithContext(ctx, &openproject.FilterOptions{
+		Fields: []openproject.OptionsFields{{Field: "login", Operator: openproject.Equal, Value: user}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(list.Embedded.Elements) == 0 {
+		return "", fmt.Errorf("unknown user %q", user)
+	}
+	return strconv.Itoa(list.Embedded.Elements[0].ID), nil
+}
+
+// resolveProject returns the ID of a project given by ID or identifier
+func resolveProject(ctx context.Context, client *openproject.Client, project string) (string, error) {
+	if _, err := strconv.Atoi(project); err == nil {
+		return project, nil
+	}
+	p, _, err := client.Projects().GetWithContext(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(p.ID), nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// timeCommand logs time spent on work packages
+func timeCommand() *command {
+	return &command{
+		name:    "time",
+		summary: "Log time spent on work packages",
+		subcommands: []*command{
+			{
+				name:    "log",
+				args:    "<work package id> <duration>",
+				summary: "Log time spent on a work package, the duration in hours (1.5) or like 1h30m",
+				setup:   logTime,
+			},
+		},
+	}
+}
+
+// logTime creates a time entry for a work package
+func logTime(fs *flag.FlagSet) runner {
+	date := fs.String("date", "", "day the time was spent, like 2021-01-31 (default today)")
+	comment := fs.String("comment", "", "comment")
+	activity := fs.String("activity", "", "time entry activity ID, required by some instances")
+	return func(a *app, args []string) error {
+		if len(args) != 2 {
+			return errUsage
+		}
+		spent, err := parseHours(args[1])
+		if err != nil {
+			return err
+		}
+		spentOn := openproject.Date(time.Now())
+		if *date != "" {
+			t, err := time.Parse("2006-01-02", *date)
+			if err != nil {
+				return fmt.Errorf("expected a date like 2021-01-31, %q given", *date)
+			}
+			spentOn = openproject.Date(t)
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+
+		entry := &openproject.TimeEntry{
+			Hours:   isoDuration(spent),
+			SpentOn: &spentOn,
+			Links: &openproject.TimeEntryLinks{
+				WorkPackage: href("work_packages", args[0]),
+			},
+		}
+		if *comment != "" {
+			entry.Comment = &openproject.OPGenericDescription{Format: "plain", Raw: *comment}
+		}
+		if *activity != "" {
+			entry.Links.Activity = href("time_entries/activities", *activity)
+		}
+		created, err := createTimeEntry(a.ctx, client, entry)
+		if err != nil {
+			return err
+		}
+		return a.print(created, func(tw *tabwriter.Writer) {
+			row(tw, "ID:", created.ID)
+			if created.Links != nil {
+				row(tw, "Work package:", linkTitle(created.Links.WorkPackage))
+			}
+			row(tw, "Spent on:", formatDate(created.SpentOn))
+			row(tw, "Hours:", created.Hours)
+			if created.Comment != nil {
+				row(tw, "Comment:", created.Comment.Raw)
+			}
+		})
+	}
+}
+
+// parseHours parses a duration given as hours ("1.5") or as Go duration ("1h30m")
+// It is rounded to minutes, and must be one minute at least
+func parseHours(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if hours, parseErr := strconv.ParseFloat(value, 64); parseErr == nil {
+		d, err = time.Duration(hours*float64(time.Hour)), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("expected a duration like 1.5 or 1h30m, %q given", value)
+	}
+	if d = d.Round(time.Minute); d <= 0 {
+		return 0, fmt.Errorf("expected a duration of one minute at least, %q given", value)
+	}
+	return d, nil
+}
+
+// isoDuration renders a duration as ISO 8601, i.e. "PT1H30M"
+func isoDuration(d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case minutes == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	case hours == 0:
+		return fmt.Sprintf("PT%dM", minutes)
+	}
+	return fmt.Sprintf("PT%dH%dM", hours, minutes)
+}
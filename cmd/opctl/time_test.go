@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTime_Log(t *testing.T) {
+	c := newTestCLI(t)
+	var logged map[string]interface{}
+	c.route("/api/v3/time_entries", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&logged)
+		logged["id"] = 5
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(logged)
+	})
+
+	stdout := c.run("time", "log", "42", "1h30m", "--comment", "Code review", "--date", "2021-01-31", "--activity", "3")
+	if !strings.Contains(stdout, "Hours:         PT1H30M") || !strings.Contains(stdout, "Work package:  42") {
+		t.Errorf("Expected the time entry, got:\n%s", stdout)
+	}
+	raw, _ := json.Marshal(logged)
+	expected := `{"_links":{"activity":{"href":"/api/v3/time_entries/activities/3"},"workPackage":{"href":"/api/v3/work_packages/42"}},` +
+		`"comment":{"format":"plain","raw":"Code review"},"hours":"PT1H30M","id":5,"spentOn":"2021-01-31"}`
+	if string(raw) != expected {
+		t.Errorf("Expected %s, got %s", expected, raw)
+	}
+
+	c.run("time", "log", "42", "0.25")
+	if logged["hours"] != "PT15M" || logged["spentOn"] != time.Now().Format("2006-01-02") {
+		t.Errorf("Expected 15 minutes today, got %v", logged)
+	}
+
+	_, stderr, code := c.exec("--url", c.url, "time", "log", "42", "soon")
+	if code != 1 || !strings.Contains(stderr, `expected a duration like 1.5 or 1h30m, "soon" given`) {
+		t.Errorf("Expected an invalid duration, got %d: %s", code, stderr)
+	}
+}
+
+func TestParseHours(t *testing.T) {
+	tests := map[string]string{
+		"1":      "PT1H",
+		"1.5":    "PT1H30M",
+		"0.1":    "PT6M",
+		"2h":     "PT2H",
+		"45m":    "PT45M",
+		"1h5m9s": "PT1H5M",
+	}
+	for value, expected := range tests {
+		d, err := parseHours(value)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if iso := isoDuration(d); iso != expected {
+			t.Errorf("Parsing %s, expected %s, got %s", value, expected, iso)
+		}
+	}
+	for _, value := range []string{"-1", "0", "10s", "1d"} {
+		if _, err := parseHours(value); err == nil {
+			t.Errorf("Expected %s to be invalid", value)
+		}
+	}
+}
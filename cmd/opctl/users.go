@@ -0,0 +1,248 @@
+package main
+
+import (
+	"flag"
+	"text/tabwriter"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// userCommand manages users
+func userCommand() *command {
+	return &command{
+		name:    "user",
+		summary: "List, show, create, invite, lock, unlock and delete users",
+		subcommands: []*command{
+			{
+				name:    "list",
+				args:    "[query...]",
+				summary: "List users matching a query like: status:active name~doe",
+				setup:   listUsers,
+			},
+			{
+				name:    "show",
+				args:    "<id, login or me>",
+				summary: "Show a user",
+				setup:   showUser,
+			},
+			{
+				name:    "create",
+				summary: "Create a user",
+				setup:   createUser,
+			},
+			{
+				name:    "invite",
+				summary: "Invite a user by email",
+				setup:   inviteUser,
+			},
+			{
+				name:    "lock",
+				args:    "<id or login>",
+				summary: "Lock a user, who cannot log in anymore",
+				setup:   lockUser(true),
+			},
+			{
+				name:    "unlock",
+				args:    "<id or login>",
+				summary: "Unlock a user",
+				setup:   lockUser(false),
+			},
+			{
+				name:    "delete",
+				args:    "<id or login>",
+				summary: "Delete a user",
+				setup:   deleteUser,
+			},
+		},
+	}
+}
+
+// printUser writes the fields of a user as table rows
+func printUser(tw *tabwriter.Writer, user *openproject.User) {
+	row(tw, "ID:", user.ID)
+	row(tw, "Login:", user.Login)
+	row(tw, "Name:", user.Name)
+	row(tw, "Email:", user.Email)
+	row(tw, "Status:", user.Status)
+	row(tw, "Admin:", user.Admin)
+	row(tw, "Created:", formatTime(user.CreatedAt))
+}
+
+// listUsers lists users matching a text query, see openproject.ParseFilterQuery
+func listUsers(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		options, err := openproject.ParseFilterQuery(joinQuery(args))
+		if err != nil {
+			return err
+		}
+		list, _, err := client.Users().GetListWithContext(a.ctx, options)
+		if err != nil {
+			return err
+		}
+		users := list.Embedded.Elements
+		return a.print(users, func(tw *tabwriter.Writer) {
+			row(tw, "ID", "LOGIN", "NAME", "EMAIL", "STATUS")
+			for _, user := range users {
+				row(tw, user.ID, user.Login, user.Name, user.Email, user.Status)
+			}
+		})
+	}
+}
+
+// showUser shows a user
+func showUser(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		id, err := resolveUser(a.ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		user, _, err := client.Users().GetWithContext(a.ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.print(user, func(tw *tabwriter.Writer) {
+			printUser(tw, user)
+		})
+	}
+}
+
+// userFields are the flags setting the fields of a user
+type userFields struct {
+	login     string
+	firstName string
+	lastName  string
+	email     string
+	language  string
+	admin     bool
+}
+
+// define adds the flags of the fields to fs
+func (f *userFields) define(fs *flag.FlagSet) {
+	fs.StringVar(&f.login, "login", "", "login")
+	fs.StringVar(&f.firstName, "first-name", "", "first name")
+	fs.StringVar(&f.lastName, "last-name", "", "last name")
+	fs.StringVar(&f.email, "email", "", "email address")
+	fs.StringVar(&f.language, "language", "", "language, like \"en\"")
+	fs.BoolVar(&f.admin, "admin", false, "make the user an administrator")
+}
+
+// user returns the user described by the flags
+func (f *userFields) user() *openproject.User {
+	return &openproject.User{
+		Login:     f.login,
+		FirstName: f.firstName,
+		LastName:  f.lastName,
+		Email:     f.email,
+		Language:  f.language,
+		Admin:     f.admin,
+	}
+}
+
+// createUser creates an active user
+func createUser(fs *flag.FlagSet) runner {
+	var fields userFields
+	fields.define(fs)
+	password := fs.String("password", "", "password, the user has to set one at first login if empty")
+	return func(a *app, args []string) error {
+		if len(args) != 0 || fields.login == "" || fields.email == "" {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		user := fields.user()
+		user.Password = *password
+		user.Status = openproject.UserStatusActive
+		created, _, err := client.Users().CreateWithContext(a.ctx, user)
+		if err != nil {
+			return err
+		}
+		return a.print(created, func(tw *tabwriter.Writer) {
+			printUser(tw, created)
+		})
+	}
+}
+
+// inviteUser invites a user by email
+func inviteUser(fs *flag.FlagSet) runner {
+	var fields userFields
+	fields.define(fs)
+	return func(a *app, args []string) error {
+		if len(args) != 0 || fields.email == "" {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		invited, _, err := client.Users().InviteWithContext(a.ctx, fields.user())
+		if err != nil {
+			return err
+		}
+		return a.print(invited, func(tw *tabwriter.Writer) {
+			printUser(tw, invited)
+		})
+	}
+}
+
+// lockUser returns the command locking or unlocking a user
+func lockUser(lock bool) func(fs *flag.FlagSet) runner {
+	return func(fs *flag.FlagSet) runner {
+		return func(a *app, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			client, err := a.connect()
+			if err != nil {
+				return err
+			}
+			id, err := resolveUser(a.ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			var user *openproject.User
+			if lock {
+				user, _, err = client.Users().LockWithContext(a.ctx, id)
+			} else {
+				user, _, err = client.Users().UnlockWithContext(a.ctx, id)
+			}
+			if err != nil {
+				return err
+			}
+			return a.print(user, func(tw *tabwriter.Writer) {
+				printUser(tw, user)
+			})
+		}
+	}
+}
+
+// deleteUser deletes a user
+func deleteUser(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		id, err := resolveUser(a.ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		_, err = client.Users().DeleteWithContext(a.ctx, id)
+		return err
+	}
+}
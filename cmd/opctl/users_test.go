@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUser_Manage(t *testing.T) {
+	c := newTestCLI(t)
+
+	stdout := c.run("user", "create", "--login", "jdoe", "--first-name", "John", "--last-name", "Doe", "--email", "john@example.com")
+	if !strings.Contains(stdout, "Name:     John Doe") || !strings.Contains(stdout, "Status:   active") {
+		t.Errorf("Expected the user to be created, got:\n%s", stdout)
+	}
+	c.run("user", "invite", "--email", "jane@example.com", "--first-name", "Jane")
+
+	stdout = c.run("user", "list", "status:invited")
+	if !strings.Contains(stdout, "jane@example.com") || strings.Contains(stdout, "jdoe") {
+		t.Errorf("Expected the invited user only, got:\n%s", stdout)
+	}
+
+	if stdout := c.run("user", "lock", "jdoe"); !strings.Contains(stdout, "Status:   locked") {
+		t.Errorf("Expected the user to be locked, got:\n%s", stdout)
+	}
+	if stdout := c.run("user", "unlock", "1"); !strings.Contains(stdout, "Status:   active") {
+		t.Errorf("Expected the user to be unlocked, got:\n%s", stdout)
+	}
+
+	c.server.SetCurrentUser(2)
+	if stdout := c.run("user", "show", "me"); !strings.Contains(stdout, "Login:    jane@example.com") {
+		t.Errorf("Expected the current user, got:\n%s", stdout)
+	}
+
+	c.run("user", "delete", "jdoe")
+	_, stderr, code := c.exec("--url", c.url, "user", "show", "jdoe")
+	if code != 1 || !strings.Contains(stderr, `unknown user "jdoe"`) {
+		t.Errorf("Expected the user to be deleted, got %d: %s", code, stderr)
+	}
+}
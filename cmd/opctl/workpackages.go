@@ -0,0 +1,359 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// workPackageCommand manages work packages
+func workPackageCommand() *command {
+	return &command{
+		name:    "wp",
+		summary: "List, show, create, update and transition work packages",
+		subcommands: []*command{
+			{
+				name:    "list",
+				args:    "[query...]",
+				summary: "List work packages matching a query like: status:open assignee:me project:demo updated>-7d sort:-updated",
+				setup:   listWorkPackages,
+			},
+			{
+				name:    "show",
+				args:    "<id>",
+				summary: "Show a work package with its relations and activities",
+				setup:   showWorkPackage,
+			},
+			{
+				name:    "create",
+				summary: "Create a work package",
+				setup:   createWorkPackage,
+			},
+			{
+				name:    "update",
+				args:    "<id>",
+				summary: "Update the given fields of a work package",
+				setup:   updateWorkPackage,
+			},
+			{
+				name:    "transition",
+				args:    "<id> <status>",
+				summary: "Change the status of a work package, given by name or ID",
+				setup:   transitionWorkPackage,
+			},
+			{
+				name:    "delete",
+				args:    "<id>",
+				summary: "Delete a work package",
+				setup:   deleteWorkPackage,
+			},
+		},
+	}
+}
+
+// listWorkPackages lists work packages matching a text query, see openproject.ParseFilterQuery
+func listWorkPackages(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		parser := &openproject.FilterQueryParser{
+			Resolve: func(field string, value string) (string, error) {
+				switch field {
+				case "status":
+					if _, err := strconv.Atoi(value); err == nil {
+						return value, nil
+					}
+					status, err := resolveStatus(a.ctx, client, value)
+					if err != nil {
+						return "", err
+					}
+					return strconv.Itoa(status.ID), nil
+				case "project":
+					return resolveProject(a.ctx, client, value)
+				case "assignee", "responsible", "author":
+					if value == "me" {
+						return value, nil
+					}
+					return resolveUser(a.ctx, client, value)
+				}
+				return value, nil
+			},
+		}
+		options, err := parser.Parse(joinQuery(args))
+		if err != nil {
+			return err
+		}
+
+		workPackages, _, err := client.WorkPackages().GetListWithContext(a.ctx, options)
+		if err != nil {
+			return err
+		}
+		return a.print(workPackages, func(tw *tabwriter.Writer) {
+			row(tw, "ID", "TYPE", "STATUS", "SUBJECT", "ASSIGNEE", "UPDATED")
+			for _, wp := range workPackages {
+				wpType, status, assignee := "", "", ""
+				if wp.Links != nil {
+					wpType = fieldTitle(wp.Links.Type)
+					status = fieldTitle(wp.Links.Status)
+					assignee = fieldTitle(wp.Links.Assignee)
+				}
+				row(tw, wp.ID, wpType, status, truncate(wp.Subject, 60), assignee, formatTime(wp.UpdatedAt))
+			}
+		})
+	}
+}
+
+// workPackageDetails is a work package along with its relations and activities
+type workPackageDetails struct {
+	WorkPackage *openproject.WorkPackage `json:"workPackage"`
+	Relations   []relation               `json:"relations"`
+	Activities  []activity               `json:"activities"`
+}
+
+// showWorkPackage shows a work package with its relations and activities
+func showWorkPackage(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		wp, _, err := client.WorkPackages().GetWithContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		relations, err := getRelations(a.ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		activities, err := getActivities(a.ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		details := &workPackageDetails{WorkPackage: wp, Relations: relations, Activities: activities}
+		return a.print(details, func(tw *tabwriter.Writer) {
+			printWorkPackage(tw, wp)
+			if len(relations) > 0 {
+				fmt.Fprintln(tw, "\nRelations:")
+				self := strconv.Itoa(wp.ID)
+				for _, rel := range relations {
+					relType, other := rel.RelType, rel.Links.To
+					if hrefID(linkHref(rel.Links.To)) == self {
+						relType, other = rel.ReverseType, rel.Links.From
+					}
+					row(tw, "  "+relType, "#"+hrefID(linkHref(other)), linkTitle(other))
+				}
+			}
+			if len(activities) > 0 {
+				fmt.Fprintln(tw, "\nActivities:")
+				for _, act := range activities {
+					row(tw, fmt.Sprintf("  #%d", act.Version), formatTime(act.CreatedAt), linkTitle(act.Links.User))
+					for _, detail := range act.Details {
+						fmt.Fprint(tw, indent(detail.Raw, "    - "))
+					}
+					if act.Comment != nil && act.Comment.Raw != "" {
+						fmt.Fprint(tw, indent(act.Comment.Raw, "    "))
+					}
+				}
+			}
+		})
+	}
+}
+
+// printWorkPackage writes the fields of a work package as table rows
+func printWorkPackage(tw *tabwriter.Writer, wp *openproject.WorkPackage) {
+	row(tw, "ID:", wp.ID)
+	row(tw, "Subject:", wp.Subject)
+	if wp.Links != nil {
+		row(tw, "Project:", fieldTitle(wp.Links.Project))
+		row(tw, "Type:", fieldTitle(wp.Links.Type))
+		row(tw, "Status:", fieldTitle(wp.Links.Status))
+		row(tw, "Priority:", fieldTitle(wp.Links.Priority))
+		row(tw, "Assignee:", fieldTitle(wp.Links.Assignee))
+		row(tw, "Responsible:", fieldTitle(wp.Links.Responsible))
+	}
+	row(tw, "Start date:", formatDate(wp.StartDate))
+	row(tw, "Due date:", formatDate(wp.DueDate))
+	row(tw, "Created:", formatTime(wp.CreatedAt))
+	row(tw, "Updated:", formatTime(wp.UpdatedAt))
+	row(tw, "Lock version:", wp.LockVersion)
+	if wp.Description != nil && wp.Description.Raw != "" {
+		fmt.Fprintf(tw, "\n%s", indent(wp.Description.Raw, "  "))
+	}
+}
+
+// linkHref returns the href of a link, empty if there is no link
+func linkHref(link *openproject.OPGenericLink) string {
+	if link == nil {
+		return ""
+	}
+	return link.Href
+}
+
+// workPackageFields are the flags setting the fields of a work package
+type workPackageFields struct {
+	subject     string
+	description string
+	wpType      string
+	assignee    string
+	startDate   string
+	dueDate     string
+}
+
+// define adds the flags of the fields to fs
+func (f *workPackageFields) define(fs *flag.FlagSet) {
+	fs.StringVar(&f.subject, "subject", "", "subject")
+	fs.StringVar(&f.description, "description", "", "description, in markdown")
+	fs.StringVar(&f.wpType, "type", "", "type ID")
+	fs.StringVar(&f.assignee, "assignee", "", "assignee, by user ID, login or \"me\"")
+	fs.StringVar(&f.startDate, "start", "", "start date, like 2021-01-31")
+	fs.StringVar(&f.dueDate, "due", "", "due date, like 2021-01-31")
+}
+
+// apply sets the fields given by flags on wp
+func (f *workPackageFields) apply(a *app, client *openproject.Client, wp *openproject.WorkPackage) error {
+	wp.Subject = f.subject
+	if f.description != "" {
+		wp.Description = &openproject.WPDescription{Format: "markdown", Raw: f.description}
+	}
+	for _, date := range []struct {
+		value  string
+		target **openproject.Date
+	}{{f.startDate, &wp.StartDate}, {f.dueDate, &wp.DueDate}} {
+		if date.value == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", date.value)
+		if err != nil {
+			return fmt.Errorf("expected a date like 2021-01-31, %q given", date.value)
+		}
+		d := openproject.Date(t)
+		*date.target = &d
+	}
+	if f.wpType != "" || f.assignee != "" {
+		wp.Links = new(openproject.WPLinks)
+	}
+	if f.wpType != "" {
+		wp.Links.Type = wpLink("types", f.wpType)
+	}
+	if f.assignee != "" {
+		id, err := resolveUser(a.ctx, client, f.assignee)
+		if err != nil {
+			return err
+		}
+		wp.Links.Assignee = wpLink("users", id)
+	}
+	return nil
+}
+
+// createWorkPackage creates a work package within a project
+func createWorkPackage(fs *flag.FlagSet) runner {
+	var fields workPackageFields
+	fields.define(fs)
+	project := fs.String("project", "", "project, by ID or identifier (required)")
+	return func(a *app, args []string) error {
+		if len(args) != 0 || *project == "" || fields.subject == "" {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		wp := new(openproject.WorkPackage)
+		if err := fields.apply(a, client, wp); err != nil {
+			return err
+		}
+		created, _, err := client.WorkPackages().CreateWithContext(a.ctx, wp, *project)
+		if err != nil {
+			return err
+		}
+		return a.print(created, func(tw *tabwriter.Writer) {
+			printWorkPackage(tw, created)
+		})
+	}
+}
+
+// updateWorkPackage updates the fields given by flags, based on the current version of the work package
+func updateWorkPackage(fs *flag.FlagSet) runner {
+	var fields workPackageFields
+	fields.define(fs)
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		current, _, err := client.WorkPackages().GetWithContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		changes := &openproject.WorkPackage{LockVersion: current.LockVersion}
+		if err := fields.apply(a, client, changes); err != nil {
+			return err
+		}
+		updated, _, err := client.WorkPackages().UpdateWithContext(a.ctx, args[0], changes)
+		if err != nil {
+			return err
+		}
+		return a.print(updated, func(tw *tabwriter.Writer) {
+			printWorkPackage(tw, updated)
+		})
+	}
+}
+
+// transitionWorkPackage changes the status of a work package
+func transitionWorkPackage(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 2 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		status, err := resolveStatus(a.ctx, client, args[1])
+		if err != nil {
+			return err
+		}
+		current, _, err := client.WorkPackages().GetWithContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		updated, _, err := client.WorkPackages().UpdateWithContext(a.ctx, args[0], &openproject.WorkPackage{
+			LockVersion: current.LockVersion,
+			Links:       &openproject.WPLinks{Status: wpLink("statuses", status.ID)},
+		})
+		if err != nil {
+			return err
+		}
+		return a.print(updated, func(tw *tabwriter.Writer) {
+			printWorkPackage(tw, updated)
+		})
+	}
+}
+
+// deleteWorkPackage deletes a work package
+func deleteWorkPackage(fs *flag.FlagSet) runner {
+	return func(a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := a.connect()
+		if err != nil {
+			return err
+		}
+		_, err = client.WorkPackages().DeleteWithContext(a.ctx, args[0])
+		return err
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// seedWorkPackages adds a project with an open and a closed work package to the fake server
+func seedWorkPackages(c *testCLI) {
+	c.server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	c.server.AddStatus(&openproject.Status{Name: "New", IsDefault: true})
+	c.server.AddStatus(&openproject.Status{Name: "Closed", IsClosed: true})
+	c.server.AddWorkPackage("demo", &openproject.WorkPackage{Subject: "Fix login page"})
+	c.server.AddWorkPackage("demo", &openproject.WorkPackage{
+		Subject: "Write docs",
+		Links:   &openproject.WPLinks{Status: openproject.WPLinksField{Href: "/api/v3/statuses/2"}},
+	})
+}
+
+func TestWorkPackage_List(t *testing.T) {
+	c := newTestCLI(t)
+	seedWorkPackages(c)
+
+	stdout := c.run("wp", "list", "status:new")
+	if !strings.Contains(stdout, "Fix login page") || strings.Contains(stdout, "Write docs") {
+		t.Errorf("Expected the new work package only, got:\n%s", stdout)
+	}
+	if !strings.HasPrefix(stdout, "ID  TYPE  STATUS  SUBJECT") {
+		t.Errorf("Expected a table, got:\n%s", stdout)
+	}
+
+	var workPackages []openproject.WorkPackage
+	if err := json.Unmarshal([]byte(c.run("wp", "list", "project:demo", "-status:closed", "-o", "json")), &workPackages); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(workPackages) != 1 || workPackages[0].Subject != "Fix login page" {
+		t.Errorf("Expected the open work package, got %+v", workPackages)
+	}
+
+	stdout = c.run("wp", "list", "status:closed", "--output", "yaml")
+	if !strings.Contains(stdout, "- _links:") || !strings.Contains(stdout, "subject: Write docs") {
+		t.Errorf("Expected the closed work package as YAML, got:\n%s", stdout)
+	}
+
+	_, stderr, code := c.exec("--url", c.url, "wp", "list", "status:rejected")
+	if code != 1 || !strings.Contains(stderr, `unknown status "rejected", expected one of: New, Closed`) {
+		t.Errorf("Expected an unknown status, got %d: %s", code, stderr)
+	}
+}
+
+func TestWorkPackage_CreateUpdateTransition(t *testing.T) {
+	c := newTestCLI(t)
+	seedWorkPackages(c)
+
+	created := new(openproject.WorkPackage)
+	stdout := c.run("wp", "create", "--project", "demo", "--subject", "Add dark mode", "--due", "2021-01-31", "-o", "json")
+	if err := json.Unmarshal([]byte(stdout), created); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if created.ID != 3 || created.DueDate == nil {
+		t.Errorf("Unexpected work package created: %s", stdout)
+	}
+	id := fmt.Sprint(created.ID)
+
+	stdout = c.run("wp", "update", id, "--subject", "Add a dark theme")
+	if !strings.Contains(stdout, "Subject:       Add a dark theme") || !strings.Contains(stdout, "Due date:      2021-01-31") {
+		t.Errorf("Expected the work package to be updated, got:\n%s", stdout)
+	}
+
+	stdout = c.run("wp", "transition", id, "closed")
+	if !strings.Contains(stdout, "Status:        Closed") || !strings.Contains(stdout, "Lock version:  2") {
+		t.Errorf("Expected the work package to be closed, got:\n%s", stdout)
+	}
+
+	c.run("wp", "delete", id)
+	_, stderr, code := c.exec("--url", c.url, "wp", "show", id)
+	if code != 1 || !strings.Contains(stderr, "404 Not Found") {
+		t.Errorf("Expected the work package to be deleted, got %d: %s", code, stderr)
+	}
+
+	_, stderr, code = c.exec("--url", c.url, "wp", "create", "--subject", "No project")
+	if code != 1 || !strings.Contains(stderr, "Usage: opctl wp create [flags]") {
+		t.Errorf("Expected the usage, got %d: %s", code, stderr)
+	}
+}
+
+func TestWorkPackage_Show(t *testing.T) {
+	c := newTestCLI(t)
+	seedWorkPackages(c)
+	c.route("/api/v3/work_packages/1/relations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type": "Collection", "total": 2, "_embedded": {"elements": [
+			{"_type": "Relation", "id": 1, "type": "blocks", "reverseType": "blocked",
+			 "_links": {"from": {"href": "/api/v3/work_packages/1"}, "to": {"href": "/api/v3/work_packages/2", "title": "Write docs"}}},
+			{"_type": "Relation", "id": 2, "type": "follows", "reverseType": "precedes",
+			 "_links": {"from": {"href": "/api/v3/work_packages/7", "title": "Design login"}, "to": {"href": "/api/v3/work_packages/1"}}}
+		]}}`)
+	})
+	c.route("/api/v3/work_packages/1/activities", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type": "Collection", "total": 1, "_embedded": {"elements": [
+			{"_type": "Activity::Comment", "id": 10, "version": 2, "createdAt": "2021-01-31T10:00:00Z",
+			 "comment": {"format": "markdown", "raw": "Works on my machine"},
+			 "details": [{"format": "custom", "raw": "Status changed from New to In progress"}],
+			 "_links": {"user": {"href": "/api/v3/users/1", "title": "John Doe"}}}
+		]}}`)
+	})
+
+	stdout := c.run("wp", "show", "1")
+	for _, expected := range []string{
+		"Subject:       Fix login page",
+		"Status:        New",
+		"  blocks    #2  Write docs",
+		"  precedes  #7  Design login",
+		"John Doe",
+		"    - Status changed from New to In progress\n",
+		"    Works on my machine\n",
+	} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected %q within:\n%s", expected, stdout)
+		}
+	}
+
+	details := new(workPackageDetails)
+	if err := json.Unmarshal([]byte(c.run("wp", "show", "1", "-o", "json")), details); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if details.WorkPackage.Subject != "Fix login page" || len(details.Relations) != 2 || len(details.Activities) != 1 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
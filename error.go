@@ -6,83 +6,368 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"io/ioutil"
+	"net/http"
 	"strings"
 )
 
-// Error message from OpenProject
+// ErrorIdentifier identifies the kind of an OpenProject error, i.e. "urn:openproject-org:api:v3:errors:NotFound".
+// Identifiers can be used as targets of errors.Is:
+//
+//	if errors.Is(err, openproject.ErrNotFound) { ... }
+type ErrorIdentifier string
+
+// Error identifiers returned by OpenProject
+const (
+	ErrNotFound                    ErrorIdentifier = "urn:openproject-org:api:v3:errors:NotFound"
+	ErrUnauthenticated             ErrorIdentifier = "urn:openproject-org:api:v3:errors:Unauthenticated"
+	ErrMissingPermission           ErrorIdentifier = "urn:openproject-org:api:v3:errors:MissingPermission"
+	ErrUpdateConflict              ErrorIdentifier = "urn:openproject-org:api:v3:errors:UpdateConflict"
+	ErrPropertyConstraintViolation ErrorIdentifier = "urn:openproject-org:api:v3:errors:PropertyConstraintViolation"
+	ErrMultipleErrors              ErrorIdentifier = "urn:openproject-org:api:v3:errors:MultipleErrors"
+)
+
+// statusIdentifiers are the identifiers matched by errors of responses lacking an identifier
+var statusIdentifiers = map[int]ErrorIdentifier{
+	http.StatusUnauthorized: ErrUnauthenticated,
+	http.StatusForbidden:    ErrMissingPermission,
+	http.StatusNotFound:     ErrNotFound,
+	http.StatusConflict:     ErrUpdateConflict,
+}
+
+// Error implements error, so identifiers can be compared to errors
+func (id ErrorIdentifier) Error() string {
+	return id.Name()
+}
+
+// Name returns the short name of the identifier, i.e. "NotFound"
+func (id ErrorIdentifier) Name() string {
+	return string(id)[strings.LastIndex(string(id), ":")+1:]
+}
+
+// Error is returned by every request failing at the OpenProject API. It carries the HTTP status, the request
+// and the message of the server, decoded from its HAL error format when given:
+//
+//	{"_type": "Error", "errorIdentifier": "urn:openproject-org:api:v3:errors:PropertyConstraintViolation",
+//	 "message": "Subject can't be blank.", "_embedded": {"details": {"attribute": "subject"}}}
+//
+// MultipleErrors carry every violation within Embedded.Errors.
+// Kinds are matched with errors.Is against identifiers, or with errors.As against typed errors like NotFoundError.
+// Requests failing before a response is received have no StatusCode, the cause is given as HTTPError
 type Error struct {
-	HTTPError     error
-	ErrorMessages []string          `json:"errorMessages"`
-	Errors        map[string]string `json:"errors"`
+	HTTPError  error  `json:"-"`
+	StatusCode int    `json:"-"`
+	Method     string `json:"-"`
+	URL        string `json:"-"`
+
+	Type       string          `json:"_type"`
+	Identifier ErrorIdentifier `json:"errorIdentifier"`
+	Message    string          `json:"message"`
+	Embedded   ErrorEmbedded   `json:"_embedded"`
+}
+
+// ErrorEmbedded holds the details of an Error
+type ErrorEmbedded struct {
+	Details *ErrorDetails `json:"details,omitempty"`
+	Errors  []*Error      `json:"errors,omitempty"`
 }
 
-// NewOpenProjectError creates a new OpenProject Error
+// ErrorDetails tells which attribute of the resource caused an Error
+type ErrorDetails struct {
+	Attribute string `json:"attribute"`
+}
+
+// NewOpenProjectError creates a new OpenProject Error out of a failed response.
+// Errors returned by Client.Do already are OpenProject errors and are returned as they are
 func NewOpenProjectError(resp *Response, httpError error) error {
+	var opErr *Error
+	if errors.As(httpError, &opErr) {
+		return httpError
+	}
 	if resp == nil {
 		return errors.Wrap(httpError, "No response returned")
 	}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	opErr = newResponseError(resp.Response)
+	opErr.HTTPError = httpError
+	return opErr
+}
+
+// newRequestError returns the Error of a request failing before a response is received
+func newRequestError(req *http.Request, err error) *Error {
+	return &Error{
+		HTTPError: err,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+	}
+}
+
+// newResponseError returns the Error of a failed response, decoding its body.
+// The body is closed and replaced by a copy, so callers can still read it
+func newResponseError(r *http.Response) *Error {
+	opErr := &Error{StatusCode: r.StatusCode}
+	if r.Request != nil {
+		opErr.Method = r.Request.Method
+		opErr.URL = r.Request.URL.String()
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
 	if err != nil {
-		return errors.Wrap(err, httpError.Error())
-	}
-	jerr := Error{HTTPError: httpError}
-	contentType := resp.Header.Get("Content-Type")
-	if strings.HasPrefix(contentType, "application/json") {
-		err = json.Unmarshal(body, &jerr)
-		if err != nil {
-			httpError = errors.Wrap(errors.New("could not parse JSON"), httpError.Error())
-			return errors.Wrap(err, httpError.Error())
-		}
-	} else {
-		if httpError == nil {
-			return fmt.Errorf("got response status %s:%s", resp.Status, string(body))
+		opErr.HTTPError = err
+		return opErr
+	}
+
+	if isJSONContentType(r.Header.Get("Content-Type")) {
+		if err := json.Unmarshal(body, opErr); err != nil {
+			opErr.HTTPError = errors.Wrap(err, "could not parse JSON")
 		}
-		return errors.Wrap(httpError, fmt.Sprintf("%s: %s", resp.Status, string(body)))
 	}
+	if opErr.Message == "" {
+		opErr.Message = strings.TrimSpace(string(body))
+	}
+	return opErr
+}
 
-	return &jerr
+// isJSONContentType reports whether a Content-Type header is JSON, HAL included
+func isJSONContentType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
 }
 
-// Error is a short string representing the error
+// Error is a short string representing the error, i.e. "GET https://op.example.com/api/v3/statuses/99: 404 Not Found: The requested resource could not be found."
 func (e *Error) Error() string {
-	if len(e.ErrorMessages) > 0 {
-		// return fmt.Sprintf("%v", e.HTTPError)
-		return fmt.Sprintf("%s: %v", e.ErrorMessages[0], e.HTTPError)
+	var msg bytes.Buffer
+	if e.Method != "" {
+		msg.WriteString(e.Method)
+		msg.WriteString(" ")
+		msg.WriteString(e.URL)
+		msg.WriteString(": ")
+	}
+	if e.StatusCode != 0 {
+		msg.WriteString(fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)))
+	}
+	message := e.Message
+	if message == "" && e.Identifier != "" {
+		message = e.Identifier.Name()
 	}
-	if len(e.Errors) > 0 {
-		for key, value := range e.Errors {
-			return fmt.Sprintf("%s - %s: %v", key, value, e.HTTPError)
+	if message != "" {
+		if e.StatusCode != 0 {
+			msg.WriteString(": ")
 		}
+		msg.WriteString(message)
+	}
+	if e.HTTPError != nil {
+		if e.StatusCode != 0 || message != "" {
+			msg.WriteString(": ")
+		}
+		msg.WriteString(e.HTTPError.Error())
+	}
+	return msg.String()
+}
+
+// Is reports whether the error has the identifier given as target.
+// MultipleErrors also match the identifiers of the errors they contain
+func (e *Error) Is(target error) bool {
+	id, ok := target.(ErrorIdentifier)
+	return ok && e.find(id) != nil
+}
+
+// As sets target to the typed error of its kind, i.e. *NotFoundError, when the error has the matching identifier.
+// For MultipleErrors, the typed error wraps the contained error having the identifier
+func (e *Error) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *NotFoundError:
+		return e.as(ErrNotFound, func(found *Error) { *t = NotFoundError{found} })
+	case *UnauthenticatedError:
+		return e.as(ErrUnauthenticated, func(found *Error) { *t = UnauthenticatedError{found} })
+	case *MissingPermissionError:
+		return e.as(ErrMissingPermission, func(found *Error) { *t = MissingPermissionError{found} })
+	case *UpdateConflictError:
+		return e.as(ErrUpdateConflict, func(found *Error) { *t = UpdateConflictError{found} })
+	case *PropertyConstraintViolationError:
+		return e.as(ErrPropertyConstraintViolation, func(found *Error) { *t = PropertyConstraintViolationError{found} })
+	case *MultipleErrorsError:
+		return e.as(ErrMultipleErrors, func(found *Error) { *t = MultipleErrorsError{found} })
+	}
+	return false
+}
+
+// as calls set with the error having the identifier, if any
+func (e *Error) as(id ErrorIdentifier, set func(*Error)) bool {
+	found := e.find(id)
+	if found != nil {
+		set(found)
 	}
-	return e.HTTPError.Error()
+	return found != nil
+}
+
+// find returns the error having the identifier, either the error itself or one of the errors it contains
+func (e *Error) find(id ErrorIdentifier) *Error {
+	if e.Identifier == id {
+		return e
+	}
+	if e.Identifier == "" && statusIdentifiers[e.StatusCode] == id {
+		// Responses without HAL error body, i.e. from a proxy
+		return e
+	}
+	for _, embedded := range e.Embedded.Errors {
+		if embedded == nil {
+			continue
+		}
+		if found := embedded.find(id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Unwrap returns the HTTP error the OpenProject error comes with
+func (e *Error) Unwrap() error {
+	return e.HTTPError
+}
+
+// Attribute returns the attribute causing the error, if any
+func (e *Error) Attribute() string {
+	if e.Embedded.Details == nil {
+		return ""
+	}
+	return e.Embedded.Details.Attribute
+}
+
+// Attributes returns the attributes causing the error and the errors it contains, without duplicates
+func (e *Error) Attributes() []string {
+	attributes := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(attribute string) {
+		if attribute != "" && !seen[attribute] {
+			seen[attribute] = true
+			attributes = append(attributes, attribute)
+		}
+	}
+	add(e.Attribute())
+	for _, embedded := range e.Embedded.Errors {
+		if embedded != nil {
+			for _, attribute := range embedded.Attributes() {
+				add(attribute)
+			}
+		}
+	}
+	return attributes
 }
 
 // LongError is a full representation of the error as a string
 func (e *Error) LongError() string {
 	var msg bytes.Buffer
+	if e.Method != "" {
+		msg.WriteString("Request: ")
+		msg.WriteString(e.Method)
+		msg.WriteString(" ")
+		msg.WriteString(e.URL)
+		msg.WriteString("\n")
+	}
+	if e.StatusCode != 0 {
+		msg.WriteString(fmt.Sprintf("Status: %d %s\n", e.StatusCode, http.StatusText(e.StatusCode)))
+	}
 	if e.HTTPError != nil {
 		msg.WriteString("Original:\n")
 		msg.WriteString(e.HTTPError.Error())
 		msg.WriteString("\n")
 	}
-	if len(e.ErrorMessages) > 0 {
-		msg.WriteString("Messages:\n")
-		for _, v := range e.ErrorMessages {
-			msg.WriteString(" - ")
-			msg.WriteString(v)
-			msg.WriteString("\n")
-		}
+	if e.Identifier != "" {
+		msg.WriteString("Identifier: ")
+		msg.WriteString(string(e.Identifier))
+		msg.WriteString("\n")
 	}
-	if len(e.Errors) > 0 {
-		for key, value := range e.Errors {
-			msg.WriteString(" - ")
-			msg.WriteString(key)
+	if e.Message != "" {
+		msg.WriteString("Message: ")
+		msg.WriteString(e.Message)
+		msg.WriteString("\n")
+	}
+	if len(e.Embedded.Errors) > 0 {
+		msg.WriteString("Errors:\n")
+		for _, embedded := range e.Embedded.Errors {
+			if embedded == nil {
+				continue
+			}
 			msg.WriteString(" - ")
-			msg.WriteString(value)
+			if attribute := embedded.Attribute(); attribute != "" {
+				msg.WriteString(attribute)
+				msg.WriteString(" - ")
+			}
+			msg.WriteString(embedded.Message)
 			msg.WriteString("\n")
 		}
 	}
 	return msg.String()
 }
+
+// NotFoundError is the typed error of ErrNotFound, obtained with errors.As:
+//
+//	var notFound openproject.NotFoundError
+//	if errors.As(err, &notFound) { ... }
+type NotFoundError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e NotFoundError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e NotFoundError) Unwrap() error { return e.Err }
+
+// UnauthenticatedError is the typed error of ErrUnauthenticated, see NotFoundError
+type UnauthenticatedError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e UnauthenticatedError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e UnauthenticatedError) Unwrap() error { return e.Err }
+
+// MissingPermissionError is the typed error of ErrMissingPermission, see NotFoundError
+type MissingPermissionError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e MissingPermissionError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e MissingPermissionError) Unwrap() error { return e.Err }
+
+// UpdateConflictError is the typed error of ErrUpdateConflict, see NotFoundError
+type UpdateConflictError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e UpdateConflictError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e UpdateConflictError) Unwrap() error { return e.Err }
+
+// PropertyConstraintViolationError is the typed error of ErrPropertyConstraintViolation, see NotFoundError.
+// Within MultipleErrors, Err is the first violation
+type PropertyConstraintViolationError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e PropertyConstraintViolationError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e PropertyConstraintViolationError) Unwrap() error { return e.Err }
+
+// MultipleErrorsError is the typed error of ErrMultipleErrors, see NotFoundError
+type MultipleErrorsError struct {
+	Err *Error
+}
+
+// Error returns the message of the OpenProject error
+func (e MultipleErrorsError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the OpenProject error
+func (e MultipleErrorsError) Unwrap() error { return e.Err }
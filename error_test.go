@@ -0,0 +1,189 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestError_NotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/99", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:NotFound","message":"The requested resource could not be found."}`)
+	})
+
+	_, _, err := GetWithContext(context.Background(), testClient.Status, "api/v3/statuses/99")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected NotFound, %v given", err)
+	}
+	if errors.Is(err, ErrUnauthenticated) {
+		t.Error("Expected not Unauthenticated")
+	}
+	var opErr *Error
+	if !errors.As(err, &opErr) || opErr.Message != "The requested resource could not be found." || opErr.Identifier.Name() != "NotFound" {
+		t.Errorf("Unexpected error %+v", opErr)
+	}
+}
+
+func TestError_MultipleErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:MultipleErrors","message":"Multiple field constraints have been violated.","_embedded":{"errors":[
+			{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Subject can't be blank.","_embedded":{"details":{"attribute":"subject"}}},
+			{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Type is not set to one of the allowed values.","_embedded":{"details":{"attribute":"type"}}},
+			{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Subject is too long.","_embedded":{"details":{"attribute":"subject"}}}
+		]}}`)
+	})
+
+	_, _, err := UpdateWithContext(context.Background(), testClient.WorkPackage, "api/v3/work_packages/1", &WorkPackage{})
+	if !errors.Is(err, ErrMultipleErrors) || !errors.Is(err, ErrPropertyConstraintViolation) {
+		t.Fatalf("Expected multiple constraint violations, %v given", err)
+	}
+	var opErr *Error
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Expected *Error, %T given", err)
+	}
+	if attributes := opErr.Attributes(); !reflect.DeepEqual(attributes, []string{"subject", "type"}) {
+		t.Errorf("Unexpected attributes %v", attributes)
+	}
+	if len(opErr.Embedded.Errors) != 3 || opErr.Embedded.Errors[1].Attribute() != "type" {
+		t.Errorf("Unexpected embedded errors %+v", opErr.Embedded.Errors)
+	}
+}
+
+func TestError_ServiceMethods(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/99", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "Not Found\n")
+	})
+	testMux.HandleFunc("/api/v3/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:MissingPermission","message":"You are not authorized to access this resource."}`)
+	})
+
+	// Errors are returned instead of panicking on the type of the result
+	status, resp, err := testClient.Status.Get("99")
+	if status != nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected no status along with the response, %+v given", status)
+	}
+	var opErr *Error
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Expected *Error, %T given", err)
+	}
+	if opErr.StatusCode != http.StatusNotFound || opErr.Method != "GET" || opErr.URL != testServer.URL+"/api/v3/statuses/99" || opErr.Message != "Not Found" {
+		t.Errorf("Unexpected error %+v", opErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("Expected errors without identifier matched by status")
+	}
+
+	project, _, err := testClient.Project.Create(&Project{Name: "Demo"})
+	if project != nil || !errors.As(err, &opErr) || !errors.Is(err, ErrMissingPermission) || opErr.Method != "POST" {
+		t.Errorf("Expected missing permission, %v given", err)
+	}
+	if err.Error() != "POST "+testServer.URL+"/api/v3/projects: 403 Forbidden: You are not authorized to access this resource." {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+}
+
+func TestError_Transport(t *testing.T) {
+	client, _ := NewClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}), "https://op.example.com/")
+
+	_, _, err := client.Status.Get("1")
+	var opErr *Error
+	if !errors.As(err, &opErr) || opErr.StatusCode != 0 || opErr.URL != "https://op.example.com/api/v3/statuses/1" || opErr.HTTPError == nil {
+		t.Errorf("Unexpected error %+v", err)
+	}
+}
+
+func TestClient_Do_SuccessfulBodies(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/api/v3/work_packages/1/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<p>Done</p>")
+	})
+
+	// Empty bodies are not decoded
+	req, _ := testClient.NewRequest("DELETE", "api/v3/work_packages/1", nil)
+	if _, err := testClient.Do(req, new(WorkPackage)); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.WorkPackage.Delete("1"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	// Non-JSON bodies can be read as text
+	var text string
+	req, _ = testClient.NewRequest("GET", "api/v3/work_packages/1/text", nil)
+	if _, err := testClient.Do(req, &text); err != nil || text != "<p>Done</p>" {
+		t.Errorf("Expected text body, %q given (%v)", text, err)
+	}
+
+	// and are reported along with their content type otherwise
+	req, _ = testClient.NewRequest("GET", "api/v3/work_packages/1/text", nil)
+	_, err := testClient.Do(req, new(WorkPackage))
+	var opErr *Error
+	if !errors.As(err, &opErr) || opErr.StatusCode != http.StatusOK || opErr.Message != "expected a JSON response, text/html given" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestError_Typed(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/99", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "Not Found")
+	})
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:MultipleErrors","message":"Multiple field constraints have been violated.","_embedded":{"errors":[
+			{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Subject can't be blank.","_embedded":{"details":{"attribute":"subject"}}}
+		]}}`)
+	})
+
+	// Identified by the status of a response without HAL error
+	_, _, err := GetWithContext(context.Background(), testClient.Status, "api/v3/statuses/99")
+	var notFound NotFoundError
+	if !errors.As(err, &notFound) || notFound.Err.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected NotFoundError, %v given", err)
+	}
+	if !errors.Is(notFound, ErrNotFound) || notFound.Error() != err.Error() {
+		t.Errorf("Expected NotFoundError to wrap the OpenProject error, %v given", notFound)
+	}
+	if errors.As(err, &UnauthenticatedError{}) || errors.As(err, &MissingPermissionError{}) || errors.As(err, &UpdateConflictError{}) {
+		t.Error("Expected NotFound only")
+	}
+
+	_, _, err = UpdateWithContext(context.Background(), testClient.WorkPackage, "api/v3/work_packages/1", &WorkPackage{})
+	var multiple MultipleErrorsError
+	if !errors.As(err, &multiple) || len(multiple.Err.Embedded.Errors) != 1 {
+		t.Fatalf("Expected MultipleErrorsError, %v given", err)
+	}
+	var violation PropertyConstraintViolationError
+	if !errors.As(err, &violation) || violation.Err.Attribute() != "subject" {
+		t.Errorf("Expected the contained PropertyConstraintViolationError, %+v given", violation.Err)
+	}
+	if errors.As(err, &NotFoundError{}) {
+		t.Error("Expected not NotFound")
+	}
+}
@@ -0,0 +1,405 @@
+package openproject
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Constants to represent the layout of dates within filters
+const filterDateLayout = "2006-01-02"
+
+// FilterBuilder builds FilterOptions with typed conditions, i.e.
+//
+//	filter := NewFilterBuilder().Status().Open().AssignedTo().Me().UpdatedAt().Within(7 * 24 * time.Hour)
+//
+// Conditions are combined with AND. Or() starts a new group of conditions, so
+//
+//	NewFilterBuilder().Status().Open().Or().AssignedTo().Me()
+//
+// matches open work packages or those assigned to the current user.
+// Values are checked against the operators as conditions are added, and the first mismatch is returned by Build.
+type FilterBuilder struct {
+	groups [][]OptionsFields
+	err    error
+}
+
+// NewFilterBuilder returns an empty FilterBuilder
+func NewFilterBuilder() *FilterBuilder {
+	return &FilterBuilder{groups: [][]OptionsFields{{}}}
+}
+
+// Raw adds a condition on any field (i.e. "customField3") with any operator and values.
+// Values are still checked against the operator
+func (b *FilterBuilder) Raw(field string, operator SearchOperator, values ...string) *FilterBuilder {
+	condition := OptionsFields{Field: field, Operator: operator, Values: values}
+	if b.err == nil {
+		b.err = condition.validate()
+	}
+	last := len(b.groups) - 1
+	b.groups[last] = append(b.groups[last], condition)
+	return b
+}
+
+// Or starts a new group of conditions, matched as an alternative to the previous ones.
+// OpenProject does not support OR filters yet, so builders using Or must be built with BuildGroups
+func (b *FilterBuilder) Or() *FilterBuilder {
+	b.groups = append(b.groups, []OptionsFields{})
+	return b
+}
+
+// Build returns the FilterOptions of the builder, or the first mismatch between operators and values.
+// It fails if Or has been used, since OpenProject only allows "AND" combinations (see FilterOptions)
+func (b *FilterBuilder) Build() (*FilterOptions, error) {
+	groups, err := b.BuildGroups()
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 1 {
+		return nil, fmt.Errorf("filter has %d OR groups, but OpenProject only supports AND filters: use BuildGroups", len(groups))
+	}
+	return groups[0], nil
+}
+
+// BuildGroups returns one FilterOptions per OR group. A work package matches the filter if it matches any of them,
+// so until OpenProject supports OR filters each group has to be requested, and paged through, on its own
+func (b *FilterBuilder) BuildGroups() ([]*FilterOptions, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	groups := make([]*FilterOptions, 0, len(b.groups))
+	for i, group := range b.groups {
+		if len(group) == 0 && len(b.groups) > 1 {
+			return nil, fmt.Errorf("OR group %d has no conditions", i+1)
+		}
+		fields := make([]OptionsFields, len(group))
+		copy(fields, group)
+		groups = append(groups, &FilterOptions{Fields: fields})
+	}
+	return groups, nil
+}
+
+// ValidateWith checks every condition against the filter instance schemas of the server
+// (see QueryService.GetFilterInstanceSchemas): the filter must exist and accept the operator and its values
+func (b *FilterBuilder) ValidateWith(schemas []QueryFilterInstanceSchema) error {
+	if b.err != nil {
+		return b.err
+	}
+	byName := make(map[string]*QueryFilterInstanceSchema, len(schemas))
+	for i := range schemas {
+		byName[schemas[i].FilterName()] = &schemas[i]
+	}
+
+	for _, group := range b.groups {
+		for _, condition := range group {
+			schema, ok := byName[condition.Field]
+			if !ok {
+				return fmt.Errorf("filter %q is not available", condition.Field)
+			}
+			values, ok := schema.ValuesSchema(condition.Operator)
+			if !ok {
+				return fmt.Errorf("filter %q does not accept operator %q", condition.Field, interpretOperator(condition.Operator))
+			}
+			if values == nil && len(condition.Values) > 0 {
+				return fmt.Errorf("filter %q takes no values with operator %q", condition.Field, interpretOperator(condition.Operator))
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the values of every field match its operator,
+// i.e. "o" takes no values and ">t-" takes a number of days
+func (fops *FilterOptions) Validate() error {
+	for _, field := range fops.Fields {
+		if err := field.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that the values of a field match its operator
+func (field OptionsFields) validate() error {
+	if field.Field == "" {
+		return fmt.Errorf("filter with operator %q has no field", interpretOperator(field.Operator))
+	}
+	symbol, ok := operatorSymbols[field.Operator]
+	if !ok {
+		return fmt.Errorf("filter %q has unknown operator %d", field.Field, field.Operator)
+	}
+	values := field.filterValues()
+
+	switch field.Operator {
+	case Open, Closed, All, None, Today, ThisWeek:
+		if len(values) > 0 {
+			return fmt.Errorf("filter %q: operator %q takes no values, %d given", field.Field, symbol, len(values))
+		}
+	case DaysAgo, LessThanDaysAgo, MoreThanDaysAgo, InDays, InLessThanDays, InMoreThanDays:
+		if len(values) != 1 {
+			return fmt.Errorf("filter %q: operator %q takes a number of days, %d values given", field.Field, symbol, len(values))
+		}
+		if days, err := strconv.Atoi(values[0]); err != nil || days < 0 {
+			return fmt.Errorf("filter %q: operator %q takes a number of days, %q given", field.Field, symbol, values[0])
+		}
+	case OnDate:
+		if len(values) != 1 {
+			return fmt.Errorf("filter %q: operator %q takes a date, %d values given", field.Field, symbol, len(values))
+		}
+		if _, err := time.Parse(filterDateLayout, values[0]); err != nil {
+			return fmt.Errorf("filter %q: operator %q takes a date like 2021-01-31, %q given", field.Field, symbol, values[0])
+		}
+	case BetweenDates:
+		if len(values) != 2 || (values[0] == "" && values[1] == "") {
+			return fmt.Errorf("filter %q: operator %q takes two dates, either can be empty", field.Field, symbol)
+		}
+		for _, value := range values {
+			if _, err := time.Parse(filterDateLayout, value); value != "" && err != nil {
+				return fmt.Errorf("filter %q: operator %q takes dates like 2021-01-31, %q given", field.Field, symbol, value)
+			}
+		}
+	default:
+		if len(values) == 0 {
+			return fmt.Errorf("filter %q: operator %q needs at least one value", field.Field, symbol)
+		}
+	}
+	return nil
+}
+
+// StatusFilter adds conditions on the status of work packages
+type StatusFilter struct {
+	b *FilterBuilder
+}
+
+// Status adds a condition on the status
+func (b *FilterBuilder) Status() StatusFilter {
+	return StatusFilter{b}
+}
+
+// Open matches work packages with any open status
+func (f StatusFilter) Open() *FilterBuilder {
+	return f.b.Raw("status", Open)
+}
+
+// Closed matches work packages with any closed status
+func (f StatusFilter) Closed() *FilterBuilder {
+	return f.b.Raw("status", Closed)
+}
+
+// Is matches work packages with any of the given status IDs
+func (f StatusFilter) Is(statusIDs ...string) *FilterBuilder {
+	return f.b.Raw("status", Equal, statusIDs...)
+}
+
+// IsNot matches work packages with none of the given status IDs
+func (f StatusFilter) IsNot(statusIDs ...string) *FilterBuilder {
+	return f.b.Raw("status", Not, statusIDs...)
+}
+
+// PrincipalFilter adds conditions on users, groups or placeholder users (i.e. the assignee)
+type PrincipalFilter struct {
+	b     *FilterBuilder
+	field string
+}
+
+// AssignedTo adds a condition on the assignee
+func (b *FilterBuilder) AssignedTo() PrincipalFilter {
+	return PrincipalFilter{b, "assignee"}
+}
+
+// Responsible adds a condition on the accountable principal
+func (b *FilterBuilder) Responsible() PrincipalFilter {
+	return PrincipalFilter{b, "responsible"}
+}
+
+// Author adds a condition on the author
+func (b *FilterBuilder) Author() PrincipalFilter {
+	return PrincipalFilter{b, "author"}
+}
+
+// Watcher adds a condition on the watchers
+func (b *FilterBuilder) Watcher() PrincipalFilter {
+	return PrincipalFilter{b, "watcher"}
+}
+
+// Me matches the user the client is authenticated as
+func (f PrincipalFilter) Me() *FilterBuilder {
+	return f.b.Raw(f.field, Equal, "me")
+}
+
+// Is matches any of the given principal IDs ("me" included)
+func (f PrincipalFilter) Is(principalIDs ...string) *FilterBuilder {
+	return f.b.Raw(f.field, Equal, principalIDs...)
+}
+
+// IsNot matches none of the given principal IDs ("me" included)
+func (f PrincipalFilter) IsNot(principalIDs ...string) *FilterBuilder {
+	return f.b.Raw(f.field, Not, principalIDs...)
+}
+
+// Any matches work packages with the principal set
+func (f PrincipalFilter) Any() *FilterBuilder {
+	return f.b.Raw(f.field, All)
+}
+
+// None matches work packages without the principal set, i.e. unassigned ones
+func (f PrincipalFilter) None() *FilterBuilder {
+	return f.b.Raw(f.field, None)
+}
+
+// DateFilter adds conditions on dates
+type DateFilter struct {
+	b     *FilterBuilder
+	field string
+}
+
+// CreatedAt adds a condition on the creation date
+func (b *FilterBuilder) CreatedAt() DateFilter {
+	return DateFilter{b, "createdAt"}
+}
+
+// UpdatedAt adds a condition on the date of the last update
+func (b *FilterBuilder) UpdatedAt() DateFilter {
+	return DateFilter{b, "updatedAt"}
+}
+
+// StartDate adds a condition on the start date
+func (b *FilterBuilder) StartDate() DateFilter {
+	return DateFilter{b, "startDate"}
+}
+
+// DueDate adds a condition on the due date
+func (b *FilterBuilder) DueDate() DateFilter {
+	return DateFilter{b, "dueDate"}
+}
+
+// Within matches dates within the given past period. OpenProject counts in days, so d is rounded up to days
+func (f DateFilter) Within(d time.Duration) *FilterBuilder {
+	return f.b.Raw(f.field, LessThanDaysAgo, filterDays(d))
+}
+
+// OlderThan matches dates before the given past period. OpenProject counts in days, so d is rounded up to days
+func (f DateFilter) OlderThan(d time.Duration) *FilterBuilder {
+	return f.b.Raw(f.field, MoreThanDaysAgo, filterDays(d))
+}
+
+// WithinNext matches dates within the given future period. OpenProject counts in days, so d is rounded up to days
+func (f DateFilter) WithinNext(d time.Duration) *FilterBuilder {
+	return f.b.Raw(f.field, InLessThanDays, filterDays(d))
+}
+
+// Today matches dates of today
+func (f DateFilter) Today() *FilterBuilder {
+	return f.b.Raw(f.field, Today)
+}
+
+// ThisWeek matches dates of the current week
+func (f DateFilter) ThisWeek() *FilterBuilder {
+	return f.b.Raw(f.field, ThisWeek)
+}
+
+// On matches the day of t
+func (f DateFilter) On(t time.Time) *FilterBuilder {
+	return f.b.Raw(f.field, OnDate, t.Format(filterDateLayout))
+}
+
+// Between matches the days from "from" to "to" (both included). A zero time leaves that side open
+func (f DateFilter) Between(from, to time.Time) *FilterBuilder {
+	return f.b.Raw(f.field, BetweenDates, filterDate(from), filterDate(to))
+}
+
+// None matches work packages without the date set
+func (f DateFilter) None() *FilterBuilder {
+	return f.b.Raw(f.field, None)
+}
+
+// ListFilter adds conditions on resources like projects, types or priorities
+type ListFilter struct {
+	b     *FilterBuilder
+	field string
+}
+
+// Project adds a condition on the project
+func (b *FilterBuilder) Project() ListFilter {
+	return ListFilter{b, "project"}
+}
+
+// Type adds a condition on the type
+func (b *FilterBuilder) Type() ListFilter {
+	return ListFilter{b, "type"}
+}
+
+// Priority adds a condition on the priority
+func (b *FilterBuilder) Priority() ListFilter {
+	return ListFilter{b, "priority"}
+}
+
+// Version adds a condition on the version
+func (b *FilterBuilder) Version() ListFilter {
+	return ListFilter{b, "version"}
+}
+
+// Category adds a condition on the category
+func (b *FilterBuilder) Category() ListFilter {
+	return ListFilter{b, "category"}
+}
+
+// In matches any of the given IDs
+func (f ListFilter) In(ids ...string) *FilterBuilder {
+	return f.b.Raw(f.field, Equal, ids...)
+}
+
+// NotIn matches none of the given IDs
+func (f ListFilter) NotIn(ids ...string) *FilterBuilder {
+	return f.b.Raw(f.field, Not, ids...)
+}
+
+// Any matches work packages with the field set
+func (f ListFilter) Any() *FilterBuilder {
+	return f.b.Raw(f.field, All)
+}
+
+// None matches work packages without the field set
+func (f ListFilter) None() *FilterBuilder {
+	return f.b.Raw(f.field, None)
+}
+
+// TextFilter adds conditions on texts
+type TextFilter struct {
+	b     *FilterBuilder
+	field string
+}
+
+// Subject adds a condition on the subject
+func (b *FilterBuilder) Subject() TextFilter {
+	return TextFilter{b, "subject"}
+}
+
+// Contains matches texts containing s
+func (f TextFilter) Contains(s string) *FilterBuilder {
+	return f.b.Raw(f.field, Like, s)
+}
+
+// NotContains matches texts not containing s
+func (f TextFilter) NotContains(s string) *FilterBuilder {
+	return f.b.Raw(f.field, NotLike, s)
+}
+
+// Search adds a full-text search on subject, description and comments
+func (b *FilterBuilder) Search(text string) *FilterBuilder {
+	return b.Raw("search", SearchString, text)
+}
+
+// filterDays converts a duration into a number of days, rounding up
+func filterDays(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Hours() / 24)))
+}
+
+// filterDate formats a date of a filter, leaving zero times empty
+func filterDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(filterDateLayout)
+}
@@ -0,0 +1,90 @@
+package openproject
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFilterBuilder_Build(t *testing.T) {
+	options, err := NewFilterBuilder().
+		Status().Open().
+		AssignedTo().Me().
+		UpdatedAt().Within(36*time.Hour).
+		Project().In("1", "3").
+		CreatedAt().Between(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}).
+		Raw("customField3", Like, "backend").
+		Build()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	expected := []OptionsFields{
+		{Field: "status", Operator: Open},
+		{Field: "assignee", Operator: Equal, Values: []string{"me"}},
+		{Field: "updatedAt", Operator: LessThanDaysAgo, Values: []string{"2"}},
+		{Field: "project", Operator: Equal, Values: []string{"1", "3"}},
+		{Field: "createdAt", Operator: BetweenDates, Values: []string{"2021-01-01", ""}},
+		{Field: "customField3", Operator: Like, Values: []string{"backend"}},
+	}
+	if !reflect.DeepEqual(options.Fields, expected) {
+		t.Errorf("Unexpected options %+v", options.Fields)
+	}
+}
+
+func TestFilterBuilder_Mismatches(t *testing.T) {
+	builders := map[string]*FilterBuilder{
+		"values for no-values operator": NewFilterBuilder().Raw("status", Open, "1"),
+		"no values":                     NewFilterBuilder().Project().In(),
+		"days not a number":             NewFilterBuilder().Raw("updatedAt", LessThanDaysAgo, "week"),
+		"invalid date":                  NewFilterBuilder().Raw("dueDate", OnDate, "31/01/2021"),
+		"between without dates":         NewFilterBuilder().DueDate().Between(time.Time{}, time.Time{}),
+		"unknown operator":              NewFilterBuilder().Raw("status", SearchOperator(99)),
+		"empty field":                   NewFilterBuilder().Raw("", Equal, "1"),
+		"empty OR group":                NewFilterBuilder().Status().Open().Or(),
+	}
+	for name, builder := range builders {
+		if _, err := builder.BuildGroups(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFilterBuilder_OrGroups(t *testing.T) {
+	builder := NewFilterBuilder().Status().Open().AssignedTo().Me().Or().Responsible().Me()
+	if _, err := builder.Build(); err == nil {
+		t.Error("Expected error building OR groups as single FilterOptions")
+	}
+
+	groups, err := builder.BuildGroups()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(groups) != 2 || len(groups[0].Fields) != 2 || len(groups[1].Fields) != 1 || groups[1].Fields[0].Field != "responsible" {
+		t.Errorf("Unexpected groups %+v", groups)
+	}
+}
+
+func TestFilterBuilder_ValidateWith(t *testing.T) {
+	raw, err := ioutil.ReadFile("./mocks/get/get-query-filter-instance-schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := QueryFilterInstanceSchema{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatal(err)
+	}
+	schemas := []QueryFilterInstanceSchema{schema}
+
+	if err := NewFilterBuilder().Status().Open().ValidateWith(schemas); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if err := NewFilterBuilder().Raw("status", Like, "new").ValidateWith(schemas); err == nil {
+		t.Error("Expected error for operator not accepted by the filter")
+	}
+	if err := NewFilterBuilder().AssignedTo().Me().ValidateWith(schemas); err == nil {
+		t.Error("Expected error for filter not available")
+	}
+}
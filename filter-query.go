@@ -0,0 +1,520 @@
+package openproject
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FilterQueryError is returned when a text query cannot be parsed.
+// Column is the position (starting at 1) of the offending character within the query
+type FilterQueryError struct {
+	Column  int
+	Message string
+}
+
+// Error returns the message with its column
+func (e *FilterQueryError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// FilterValueResolver converts a value typed by the user into the one expected by OpenProject,
+// i.e. the type name "Bug" into its ID "7". It is called for values of "field:value" and "-field:value" conditions
+type FilterValueResolver func(field string, value string) (string, error)
+
+// FilterQueryParser parses text queries into FilterOptions, see ParseFilterQuery
+type FilterQueryParser struct {
+	// Resolve converts values like names into IDs. Values are used as typed if nil
+	Resolve FilterValueResolver
+}
+
+// filterQueryAliases maps short field names to OpenProject filters
+var filterQueryAliases = map[string]string{
+	"created": "createdAt",
+	"updated": "updatedAt",
+	"start":   "startDate",
+	"due":     "dueDate",
+}
+
+// filterQueryOperators are the operators between field and value, longest first
+var filterQueryOperators = []string{">=", "<=", "<>", "!~", "**", ":", ">", "<", "~"}
+
+// filterQuerySymbols maps operators written as is within text queries
+var filterQuerySymbols = map[string]SearchOperator{
+	">":  GreaterThan,
+	"<":  LowerThan,
+	">=": GreaterOrEqual,
+	"<=": LowerOrEqual,
+	"~":  Like,
+	"!~": NotLike,
+	"<>": Different,
+	"**": SearchString,
+}
+
+// filterQueryKeywords are the values of "field:keyword" conditions taking no values
+var filterQueryKeywords = map[string]SearchOperator{
+	"any":   All,
+	"none":  None,
+	"today": Today,
+	"week":  ThisWeek,
+}
+
+// filterQueryRelativeDays matches relative dates like "-7d" or "+2w"
+var filterQueryRelativeDays = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+
+// filterQueryToken is a word of a text query, quotes included
+type filterQueryToken struct {
+	text   []rune
+	column int
+}
+
+// filterQueryValue is an unquoted value of a condition.
+// Quoted values are taken literally, never as keywords, dates or relative dates
+type filterQueryValue struct {
+	text   string
+	column int
+	quoted bool
+}
+
+// ParseFilterQuery parses a text query like
+//
+//	status:open assignee:me type:7 updated>-7d sort:-updated "login page"
+//
+// into FilterOptions. Conditions are "field<operator>values", values separated by commas:
+//
+//	field:v1,v2       is any of the values (IDs, or "me" for principals)
+//	-field:v1,v2      is none of the values
+//	field:any         is set, field:none is not set
+//	status:open       any open status, status:closed any closed status
+//	date:today        today, date:week this week
+//	date:2021-01-31   on a day, date:2021-01-01..2021-01-31 between two days (either can be empty)
+//	date>=2021-01-01  from a day on, date<=2021-01-31 up to a day (> and < exclude the day)
+//	date>-7d          within the last 7 days, date<-7d more than 7 days ago, date:-7d exactly 7 days ago
+//	date<+2w          within the next 2 weeks, date>+2w in more than 2 weeks, date:+2w in exactly 2 weeks
+//	field~text        contains, field!~text does not contain
+//	field>n, field<n, field>=n, field<=n, field<>n compare values
+//	sort:-updated,id  sorts by the given fields, descending when prefixed with "-"
+//
+// created, updated, start and due are short names of createdAt, updatedAt, startDate and dueDate.
+// Any other word, or quoted text, is searched within subject, description and comments.
+// Values can be quoted to include spaces or commas, quoted values are never read as keywords or dates. Errors are *FilterQueryError, reporting the column
+func ParseFilterQuery(query string) (*FilterOptions, error) {
+	return new(FilterQueryParser).Parse(query)
+}
+
+// Parse parses a text query into FilterOptions, see ParseFilterQuery
+func (p *FilterQueryParser) Parse(query string) (*FilterOptions, error) {
+	tokens, err := tokenizeFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &FilterOptions{Fields: make([]OptionsFields, 0)}
+	search := make([]string, 0)
+	searchIndex := -1
+	for _, token := range tokens {
+		field, operator, value, valueColumn := splitFilterQueryToken(token)
+		if operator == "" {
+			// Full-text search, placed where the first word was found
+			if searchIndex < 0 {
+				searchIndex = len(options.Fields)
+				options.Fields = append(options.Fields, OptionsFields{Field: "search", Operator: SearchString})
+			}
+			values, err := splitFilterQueryValues(token.text, token.column, false)
+			if err != nil {
+				return nil, err
+			}
+			search = append(search, values[0].text)
+			continue
+		}
+
+		negated := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if alias, ok := filterQueryAliases[field]; ok {
+			field = alias
+		}
+		if negated && operator != ":" {
+			return nil, &FilterQueryError{token.column, fmt.Sprintf("only \"-%s:\" conditions can be negated", field)}
+		}
+		if value == "" {
+			return nil, &FilterQueryError{valueColumn, fmt.Sprintf("missing value for %q", field)}
+		}
+		values, err := splitFilterQueryValues([]rune(value), valueColumn, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if field == "sort" {
+			if operator != ":" || negated {
+				return nil, &FilterQueryError{token.column, "sort must be written as sort:field1,-field2"}
+			}
+			for _, v := range values {
+				option := SortOption{Field: strings.TrimPrefix(v.text, "-"), Descending: strings.HasPrefix(v.text, "-")}
+				if alias, ok := filterQueryAliases[option.Field]; ok {
+					option.Field = alias
+				}
+				if option.Field == "" {
+					return nil, &FilterQueryError{v.column, "missing sort field"}
+				}
+				options.SortBy = append(options.SortBy, option)
+			}
+			continue
+		}
+
+		condition, err := p.condition(field, operator, negated, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := condition.validate(); err != nil {
+			return nil, &FilterQueryError{token.column, err.Error()}
+		}
+		options.Fields = append(options.Fields, condition)
+	}
+
+	if searchIndex >= 0 {
+		options.Fields[searchIndex].Values = []string{strings.Join(search, " ")}
+	}
+	return options, nil
+}
+
+// condition converts a parsed "field<operator>values" into a filter field
+func (p *FilterQueryParser) condition(field string, operator string, negated bool, values []filterQueryValue) (OptionsFields, error) {
+	condition := OptionsFields{Field: field}
+	first := values[0]
+	single := len(values) == 1 && !first.quoted
+
+	if operator == ":" && !negated && single {
+		keyword := strings.ToLower(first.text)
+		if field == "status" && (keyword == "open" || keyword == "closed") {
+			condition.Operator = Open
+			if keyword == "closed" {
+				condition.Operator = Closed
+			}
+			return condition, nil
+		}
+		if op, ok := filterQueryKeywords[keyword]; ok {
+			condition.Operator = op
+			return condition, nil
+		}
+	}
+
+	if single {
+		if m := filterQueryRelativeDays.FindStringSubmatch(first.text); m != nil && !negated {
+			days, _ := strconv.Atoi(m[2])
+			if m[3] == "w" {
+				days *= 7
+			}
+			condition.Values = []string{strconv.Itoa(days)}
+			past := m[1] == "-"
+			switch {
+			case operator == ":" && past:
+				condition.Operator = DaysAgo
+			case operator == ":":
+				condition.Operator = InDays
+			case operator == ">" && past:
+				condition.Operator = LessThanDaysAgo
+			case operator == "<" && past:
+				condition.Operator = MoreThanDaysAgo
+			case operator == "<":
+				condition.Operator = InLessThanDays
+			case operator == ">":
+				condition.Operator = InMoreThanDays
+			default:
+				return condition, &FilterQueryError{first.column, fmt.Sprintf("relative dates can only be used with ':', '>' and '<', not %q", operator)}
+			}
+			return condition, nil
+		}
+
+		if date, err := time.Parse(filterDateLayout, first.text); err == nil && !negated {
+			switch operator {
+			case ":":
+				condition.Operator, condition.Values = OnDate, []string{first.text}
+			case ">=":
+				condition.Operator, condition.Values = BetweenDates, []string{first.text, ""}
+			case "<=":
+				condition.Operator, condition.Values = BetweenDates, []string{"", first.text}
+			case ">":
+				condition.Operator, condition.Values = BetweenDates, []string{date.AddDate(0, 0, 1).Format(filterDateLayout), ""}
+			case "<":
+				condition.Operator, condition.Values = BetweenDates, []string{"", date.AddDate(0, 0, -1).Format(filterDateLayout)}
+			}
+			if condition.Values != nil {
+				return condition, nil
+			}
+		}
+
+		if operator == ":" && !negated && strings.Contains(first.text, "..") {
+			bounds := strings.SplitN(first.text, "..", 2)
+			for i, bound := range bounds {
+				if _, err := time.Parse(filterDateLayout, bound); bound != "" && err != nil {
+					column := first.column
+					if i == 1 {
+						column += len([]rune(bounds[0])) + 2
+					}
+					return condition, &FilterQueryError{column, fmt.Sprintf("expected a date like 2021-01-31, %q given", bound)}
+				}
+			}
+			condition.Operator, condition.Values = BetweenDates, bounds
+			return condition, nil
+		}
+	}
+
+	switch {
+	case operator == ":" && negated:
+		condition.Operator = Not
+	case operator == ":":
+		condition.Operator = Equal
+	default:
+		condition.Operator = filterQuerySymbols[operator]
+	}
+
+	condition.Values = make([]string, 0, len(values))
+	for _, v := range values {
+		value := v.text
+		if p.Resolve != nil && (condition.Operator == Equal || condition.Operator == Not) {
+			resolved, err := p.Resolve(field, value)
+			if err != nil {
+				return condition, &FilterQueryError{v.column, err.Error()}
+			}
+			value = resolved
+		}
+		condition.Values = append(condition.Values, value)
+	}
+	return condition, nil
+}
+
+// tokenizeFilterQuery splits a text query into words, keeping quoted text together
+func tokenizeFilterQuery(query string) ([]filterQueryToken, error) {
+	runes := []rune(query)
+	tokens := make([]filterQueryToken, 0)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start, quote := i, -1
+		for ; i < len(runes) && (quote >= 0 || !unicode.IsSpace(runes[i])); i++ {
+			switch {
+			case runes[i] == '\\' && quote >= 0:
+				i++
+			case runes[i] == '"' && quote >= 0:
+				quote = -1
+			case runes[i] == '"':
+				quote = i
+			}
+		}
+		if quote >= 0 {
+			return nil, &FilterQueryError{quote + 1, "unterminated quote"}
+		}
+		tokens = append(tokens, filterQueryToken{text: runes[start:i], column: start + 1})
+	}
+	return tokens, nil
+}
+
+// splitFilterQueryToken splits a word into field, operator and value.
+// The operator is empty if the word is not a condition, so it is searched as text
+func splitFilterQueryToken(token filterQueryToken) (field string, operator string, value string, valueColumn int) {
+	i := 0
+	if len(token.text) > 1 && token.text[0] == '-' {
+		i++
+	}
+	for i < len(token.text) && (unicode.IsLetter(token.text[i]) || unicode.IsDigit(token.text[i]) || token.text[i] == '_') {
+		i++
+	}
+	if i == 0 || (i == 1 && token.text[0] == '-') {
+		return "", "", "", 0
+	}
+
+	rest := string(token.text[i:])
+	for _, op := range filterQueryOperators {
+		if strings.HasPrefix(rest, op) {
+			valueStart := i + len([]rune(op))
+			return string(token.text[:i]), op, string(token.text[valueStart:]), token.column + valueStart
+		}
+	}
+	return "", "", "", 0
+}
+
+// splitFilterQueryValues removes the quotes of a text and, if separated is set, splits it into values separated by commas
+func splitFilterQueryValues(text []rune, column int, separated bool) ([]filterQueryValue, error) {
+	values := make([]filterQueryValue, 0)
+	current := filterQueryValue{column: column}
+	var b strings.Builder
+	quoted := false
+
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || (separated && text[i] == ',' && !quoted) {
+			current.text = b.String()
+			if current.text == "" {
+				return nil, &FilterQueryError{current.column, "empty value"}
+			}
+			values = append(values, current)
+			b.Reset()
+			current = filterQueryValue{column: column + i + 1}
+			continue
+		}
+
+		switch {
+		case text[i] == '\\' && quoted && i+1 < len(text):
+			i++
+			b.WriteRune(text[i])
+		case text[i] == '"':
+			quoted = !quoted
+			current.quoted = true
+		default:
+			b.WriteRune(text[i])
+		}
+	}
+	return values, nil
+}
+
+// String renders FilterOptions as a text query, see ParseFilterQuery.
+// Parsing the result gives back the same FilterOptions
+func (fops *FilterOptions) String() string {
+	parts := make([]string, 0, len(fops.Fields)+1)
+	for _, field := range fops.Fields {
+		parts = append(parts, field.queryString())
+	}
+
+	if len(fops.SortBy) > 0 {
+		sortBy := make([]string, 0, len(fops.SortBy))
+		for _, option := range fops.SortBy {
+			name := filterQueryFieldName(option.Field)
+			if option.Descending {
+				name = "-" + name
+			}
+			sortBy = append(sortBy, name)
+		}
+		parts = append(parts, "sort:"+strings.Join(sortBy, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// queryString renders a single filter field as a text query condition
+func (field OptionsFields) queryString() string {
+	name := filterQueryFieldName(field.Field)
+	values := field.filterValues()
+	value := ""
+	if len(values) > 0 {
+		value = values[0]
+	}
+
+	switch field.Operator {
+	case SearchString:
+		if field.Field == "search" {
+			return quoteFilterQueryText(value)
+		}
+	case Open:
+		return name + ":open"
+	case Closed:
+		return name + ":closed"
+	case All:
+		return name + ":any"
+	case None:
+		return name + ":none"
+	case Today:
+		return name + ":today"
+	case ThisWeek:
+		return name + ":week"
+	case DaysAgo:
+		return name + ":-" + value + "d"
+	case InDays:
+		return name + ":+" + value + "d"
+	case LessThanDaysAgo:
+		return name + ">-" + value + "d"
+	case MoreThanDaysAgo:
+		return name + "<-" + value + "d"
+	case InLessThanDays:
+		return name + "<+" + value + "d"
+	case InMoreThanDays:
+		return name + ">+" + value + "d"
+	case BetweenDates:
+		if len(values) == 2 && values[1] == "" {
+			return name + ">=" + values[0]
+		}
+		if len(values) == 2 && values[0] == "" {
+			return name + "<=" + values[1]
+		}
+		return name + ":" + strings.Join(values, "..")
+	case OnDate:
+		return name + ":" + value
+	case Equal:
+		return name + ":" + joinFilterQueryValues(field.Field, values)
+	case Not:
+		return "-" + name + ":" + joinFilterQueryValues(field.Field, values)
+	}
+
+	for symbol, operator := range filterQuerySymbols {
+		if operator == field.Operator {
+			return name + symbol + joinFilterQueryValues(field.Field, values)
+		}
+	}
+	return name + ":" + joinFilterQueryValues(field.Field, values)
+}
+
+// filterQueryFieldName returns the short name of a field if any, i.e. "updated" for "updatedAt"
+func filterQueryFieldName(field string) string {
+	for alias, name := range filterQueryAliases {
+		if name == field {
+			return alias
+		}
+	}
+	return field
+}
+
+// joinFilterQueryValues renders values of a field separated by commas, quoting them when needed
+func joinFilterQueryValues(field string, values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		if filterQueryNeedsQuotes(field, value) {
+			value = quoteFilterQuery(value)
+		}
+		quoted = append(quoted, value)
+	}
+	return strings.Join(quoted, ",")
+}
+
+// filterQueryNeedsQuotes tells whether a value must be quoted to be parsed back as is,
+// that is if it holds separators or would be read as a keyword, a date, a relative date or a range of dates
+func filterQueryNeedsQuotes(field string, value string) bool {
+	if value == "" || strings.ContainsAny(value, " \t\n,\"\\") || strings.Contains(value, "..") {
+		return true
+	}
+	keyword := strings.ToLower(value)
+	if _, ok := filterQueryKeywords[keyword]; ok {
+		return true
+	}
+	if field == "status" && (keyword == "open" || keyword == "closed") {
+		return true
+	}
+	if filterQueryRelativeDays.MatchString(value) {
+		return true
+	}
+	_, err := time.Parse(filterDateLayout, value)
+	return err == nil
+}
+
+// quoteFilterQueryText renders full-text search, quoting it unless it is a single plain word
+func quoteFilterQueryText(text string) string {
+	if text == "" {
+		return quoteFilterQuery(text)
+	}
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return quoteFilterQuery(text)
+		}
+	}
+	return text
+}
+
+// quoteFilterQuery quotes a value, escaping quotes and backslashes
+func quoteFilterQuery(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
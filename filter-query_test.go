@@ -0,0 +1,153 @@
+package openproject
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseFilterQuery(t *testing.T) {
+	options, err := ParseFilterQuery(`status:open assignee:me type:1,2 updated>-7d "login page" due<+2w -priority:8 subject~"sign in" sort:-updated,id`)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	expected := &FilterOptions{
+		Fields: []OptionsFields{
+			{Field: "status", Operator: Open},
+			{Field: "assignee", Operator: Equal, Values: []string{"me"}},
+			{Field: "type", Operator: Equal, Values: []string{"1", "2"}},
+			{Field: "updatedAt", Operator: LessThanDaysAgo, Values: []string{"7"}},
+			{Field: "search", Operator: SearchString, Values: []string{"login page"}},
+			{Field: "dueDate", Operator: InLessThanDays, Values: []string{"14"}},
+			{Field: "priority", Operator: Not, Values: []string{"8"}},
+			{Field: "subject", Operator: Like, Values: []string{"sign in"}},
+		},
+		SortBy: []SortOption{{Field: "updatedAt", Descending: true}, {Field: "id"}},
+	}
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("Unexpected options\n%+v\nexpected\n%+v", options, expected)
+	}
+}
+
+func TestParseFilterQuery_Dates(t *testing.T) {
+	cases := map[string]OptionsFields{
+		"created:today":                  {Field: "createdAt", Operator: Today},
+		"created:week":                   {Field: "createdAt", Operator: ThisWeek},
+		"due:none":                       {Field: "dueDate", Operator: None},
+		"due:2021-01-31":                 {Field: "dueDate", Operator: OnDate, Values: []string{"2021-01-31"}},
+		"due:2021-01-01..2021-01-31":     {Field: "dueDate", Operator: BetweenDates, Values: []string{"2021-01-01", "2021-01-31"}},
+		"start>=2021-01-01":              {Field: "startDate", Operator: BetweenDates, Values: []string{"2021-01-01", ""}},
+		"start>2021-01-31":               {Field: "startDate", Operator: BetweenDates, Values: []string{"2021-02-01", ""}},
+		"start<2021-01-01":               {Field: "startDate", Operator: BetweenDates, Values: []string{"", "2020-12-31"}},
+		"updated<-30d":                   {Field: "updatedAt", Operator: MoreThanDaysAgo, Values: []string{"30"}},
+		"updated:-1d":                    {Field: "updatedAt", Operator: DaysAgo, Values: []string{"1"}},
+		"customField5>+1w":               {Field: "customField5", Operator: InMoreThanDays, Values: []string{"7"}},
+		"estimatedTime>=2":               {Field: "estimatedTime", Operator: GreaterOrEqual, Values: []string{"2"}},
+		`subject!~"draft, old"`:          {Field: "subject", Operator: NotLike, Values: []string{"draft, old"}},
+		`version:"Sprint \"1\"",Backlog`: {Field: "version", Operator: Equal, Values: []string{`Sprint "1"`, "Backlog"}},
+	}
+	for query, expected := range cases {
+		options, err := ParseFilterQuery(query)
+		if err != nil {
+			t.Errorf("%s: error given: %s", query, err)
+			continue
+		}
+		if len(options.Fields) != 1 || !reflect.DeepEqual(options.Fields[0], expected) {
+			t.Errorf("%s: unexpected options %+v", query, options.Fields)
+		}
+	}
+}
+
+func TestParseFilterQuery_Errors(t *testing.T) {
+	cases := map[string]int{
+		`status:open "login page`:    13,
+		`status:open assignee:`:      22,
+		`type:1,,2`:                  8,
+		`updated>-7d -updated>-1d`:   13,
+		`due:2021-01-01..31/01/2021`: 17,
+		`status:open due:"",1`:       17,
+		`sort>updated`:               1,
+	}
+	for query, column := range cases {
+		_, err := ParseFilterQuery(query)
+		qerr, ok := err.(*FilterQueryError)
+		if !ok {
+			t.Errorf("%s: expected *FilterQueryError, %v given", query, err)
+			continue
+		}
+		if qerr.Column != column {
+			t.Errorf("%s: expected error at column %d, %q given", query, column, qerr.Error())
+		}
+	}
+}
+
+func TestFilterQueryParser_Resolve(t *testing.T) {
+	parser := &FilterQueryParser{
+		Resolve: func(field string, value string) (string, error) {
+			if field == "type" && value == "Bug" {
+				return "7", nil
+			}
+			if field == "type" {
+				return "", fmt.Errorf("unknown type %q", value)
+			}
+			return value, nil
+		},
+	}
+
+	options, err := parser.Parse("assignee:me type:Bug")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(options.Fields[1].Values, []string{"7"}) {
+		t.Errorf("Expected type resolved into 7, %v given", options.Fields[1].Values)
+	}
+
+	_, err = parser.Parse("assignee:me type:Bug,Feature")
+	if qerr, ok := err.(*FilterQueryError); !ok || qerr.Column != 22 {
+		t.Errorf("Expected error at column 22, %v given", err)
+	}
+}
+
+func TestFilterOptions_String(t *testing.T) {
+	queries := []string{
+		`status:open assignee:me type:1,2 updated>-7d "login page" due<+14d -priority:8 subject~"sign in" sort:-updated,id`,
+		`created:2021-01-01..2021-01-31 start>=2021-01-01 due<=2021-02-01 updated:today status:closed`,
+		`version:"Sprint \"1\"",Backlog login estimatedTime<>3 responsible:none watcher:any`,
+	}
+	for _, query := range queries {
+		options, err := ParseFilterQuery(query)
+		if err != nil {
+			t.Errorf("%s: error given: %s", query, err)
+			continue
+		}
+		if s := options.String(); s != query {
+			t.Errorf("Expected round-trip to\n%s\n%s given", query, s)
+		}
+		again, err := ParseFilterQuery(options.String())
+		if err != nil || !reflect.DeepEqual(again, options) {
+			t.Errorf("%s: parsing the rendered query gives %+v (%v)", query, again, err)
+		}
+	}
+}
+
+func TestFilterOptions_String_LiteralValues(t *testing.T) {
+	for _, value := range []string{"today", "none", "Week", "+3d", "-2w", "2021-01-01", "1..5"} {
+		for _, operator := range []SearchOperator{Equal, Not, Like} {
+			options := &FilterOptions{Fields: []OptionsFields{{Field: "subject", Operator: operator, Values: []string{value}}}}
+			again, err := ParseFilterQuery(options.String())
+			if err != nil || !reflect.DeepEqual(again.Fields, options.Fields) {
+				t.Errorf("%s: parsing the rendered query gives %+v (%v)", options.String(), again, err)
+			}
+		}
+	}
+
+	options := &FilterOptions{Fields: []OptionsFields{{Field: "status", Operator: Equal, Values: []string{"open"}}}}
+	if s := options.String(); s != `status:"open"` {
+		t.Errorf("Expected status:\"open\", %s given", s)
+	}
+	options, err := ParseFilterQuery(`due:"2021-01-01" subject:"1..5"`)
+	if err != nil || options.Fields[0].Operator != Equal || options.Fields[1].Operator != Equal {
+		t.Errorf("Expected quoted values to be taken literally, %+v (%v) given", options, err)
+	}
+}
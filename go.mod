@@ -8,4 +8,5 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/trivago/tgo v1.0.7
 	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83
+	gopkg.in/yaml.v3 v3.0.1
 )
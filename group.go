@@ -0,0 +1,214 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GroupService handles groups for the OpenProject instance / API.
+type GroupService struct {
+	client *Client
+}
+
+// SearchResultGroup represent a list of Groups
+type SearchResultGroup struct {
+	Embedded groupElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int           `json:"total" structs:"total"`
+	Count    int           `json:"count" structs:"count"`
+	PageSize int           `json:"pageSize" structs:"pageSize"`
+	Offset   int           `json:"offset" structs:"offset"`
+}
+
+// groupElements represent elements within SearchResultGroup
+type groupElements struct {
+	Elements []Group `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// Group is the object representing OpenProject groups of users.
+type Group struct {
+	Type      string         `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int            `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string         `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt *Time          `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time          `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Embedded  *GroupEmbedded `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links     *GroupLinks    `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// GroupEmbedded wraps embedded fields of Group
+type GroupEmbedded struct {
+	Members []User `json:"members,omitempty" structs:"members,omitempty"`
+}
+
+// GroupLinks are Group Links
+// Members is a list of links to the users belonging to the group
+type GroupLinks struct {
+	Self              *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
+	Memberships       *OPGenericLink  `json:"memberships,omitempty" structs:"memberships,omitempty"`
+	UpdateImmediately *OPGenericLink  `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Delete            *OPGenericLink  `json:"delete,omitempty" structs:"delete,omitempty"`
+	Members           []OPGenericLink `json:"members,omitempty" structs:"members,omitempty"`
+}
+
+// groupMembersPayload is the body sent to replace the members of a group
+// members is always rendered, so an empty list removes every member
+type groupMembersPayload struct {
+	Links struct {
+		Members []OPGenericLink `json:"members" structs:"members"`
+	} `json:"_links" structs:"_links"`
+}
+
+// GetWithContext returns a single group for the given group ID.
+func (s *GroupService) GetWithContext(ctx context.Context, groupID string) (*Group, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Group), Resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *GroupService) Get(groupID string) (*Group, *Response, error) {
+	return s.GetWithContext(context.Background(), groupID)
+}
+
+// GetListWithContext will retrieve a list of groups using filters
+func (s *GroupService) GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultGroup, *Response, error) {
+	u := url.URL{
+		Path: "api/v3/groups",
+	}
+
+	objList, resp, err := GetListWithContext(ctx, s, u.String(), options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return objList.(*SearchResultGroup), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *GroupService) GetList(options *FilterOptions) (*SearchResultGroup, *Response, error) {
+	return s.GetListWithContext(context.Background(), options)
+}
+
+// CreateWithContext creates a group. Initial members can be given as Links.Members
+func (s *GroupService) CreateWithContext(ctx context.Context, group *Group) (*Group, *Response, error) {
+	apiEndpoint := "api/v3/groups"
+	groupResponse, resp, err := CreateWithContext(ctx, s, apiEndpoint, group)
+	if err != nil {
+		return nil, resp, err
+	}
+	return groupResponse.(*Group), resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *GroupService) Create(group *Group) (*Group, *Response, error) {
+	return s.CreateWithContext(context.Background(), group)
+}
+
+// UpdateWithContext updates a group (PATCH). If Links.Members is given it replaces the current members
+func (s *GroupService) UpdateWithContext(ctx context.Context, groupID string, group *Group) (*Group, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	groupResponse, resp, err := UpdateWithContext(ctx, s, apiEndpoint, group)
+	if err != nil {
+		return nil, resp, err
+	}
+	return groupResponse.(*Group), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *GroupService) Update(groupID string, group *Group) (*Group, *Response, error) {
+	return s.UpdateWithContext(context.Background(), groupID, group)
+}
+
+// DeleteWithContext will delete a single group.
+// OpenProject deletes groups asynchronously, so the response status is 202 (Accepted)
+func (s *GroupService) DeleteWithContext(ctx context.Context, groupID string) (*Response, error) {
+	apiEndPoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	resp, err := DeleteWithContext(ctx, s, apiEndPoint)
+	return resp, err
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *GroupService) Delete(groupID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), groupID)
+}
+
+// AddMembersWithContext adds users to a group keeping its current members
+func (s *GroupService) AddMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error) {
+	group, resp, err := s.GetWithContext(ctx, groupID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	members := groupMemberLinks(group)
+	present := make(map[string]bool, len(members))
+	for _, member := range members {
+		present[member.Href] = true
+	}
+	for _, userID := range userIDs {
+		href := fmt.Sprintf("/api/v3/users/%s", userID)
+		if !present[href] {
+			members = append(members, OPGenericLink{Href: href})
+			present[href] = true
+		}
+	}
+
+	return s.setMembersWithContext(ctx, groupID, members)
+}
+
+// AddMembers wraps AddMembersWithContext using the background context.
+func (s *GroupService) AddMembers(groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.AddMembersWithContext(context.Background(), groupID, userIDs...)
+}
+
+// RemoveMembersWithContext removes users from a group keeping the rest of its members
+func (s *GroupService) RemoveMembersWithContext(ctx context.Context, groupID string, userIDs ...string) (*Group, *Response, error) {
+	group, resp, err := s.GetWithContext(ctx, groupID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	removed := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		removed[fmt.Sprintf("/api/v3/users/%s", userID)] = true
+	}
+	members := make([]OPGenericLink, 0)
+	for _, member := range groupMemberLinks(group) {
+		if !removed[member.Href] {
+			members = append(members, OPGenericLink{Href: member.Href})
+		}
+	}
+
+	return s.setMembersWithContext(ctx, groupID, members)
+}
+
+// RemoveMembers wraps RemoveMembersWithContext using the background context.
+func (s *GroupService) RemoveMembers(groupID string, userIDs ...string) (*Group, *Response, error) {
+	return s.RemoveMembersWithContext(context.Background(), groupID, userIDs...)
+}
+
+// setMembersWithContext replaces the whole member list of a group
+func (s *GroupService) setMembersWithContext(ctx context.Context, groupID string, members []OPGenericLink) (*Group, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/groups/%s", groupID)
+	payload := new(groupMembersPayload)
+	payload.Links.Members = members
+	groupResponse, resp, err := UpdateWithContext(ctx, s, apiEndpoint, payload)
+	if err != nil {
+		return nil, resp, err
+	}
+	return groupResponse.(*Group), resp, err
+}
+
+// groupMemberLinks returns the member links of a group, only keeping the href of each one
+func groupMemberLinks(group *Group) []OPGenericLink {
+	members := make([]OPGenericLink, 0)
+	if group.Links == nil {
+		return members
+	}
+	for _, member := range group.Links.Members {
+		members = append(members, OPGenericLink{Href: member.Href})
+	}
+	return members
+}
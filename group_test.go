@@ -0,0 +1,166 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGroupService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/groups"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-groups-no-filters.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	groups, resp, err := testClient.Group.GetList(nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if groups == nil {
+		t.Error("Expected group list but received nil")
+		return
+	}
+	if resp.Total != 2 {
+		t.Errorf("Total should populate with 2, %v given", resp.Total)
+	}
+	if groups.Embedded.Elements[1].Name != "Frontend team" {
+		t.Errorf("Unexpected group name %s", groups.Embedded.Elements[1].Name)
+	}
+}
+
+func TestGroupService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/groups/9"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	group, _, err := testClient.Group.Get("9")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if group == nil {
+		t.Error("Expected group. Group is nil")
+		return
+	}
+	if len(group.Links.Members) != 2 || len(group.Embedded.Members) != 2 {
+		t.Errorf("Expected 2 members, got %+v", group.Links.Members)
+	}
+}
+
+func TestGroupService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/groups"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		body := new(Group)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Error(err.Error())
+		}
+		if body.Name != "Backend team" || len(body.Links.Members) != 1 {
+			t.Errorf("Unexpected group sent %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	g := &Group{
+		Name: "Backend team",
+		Links: &GroupLinks{
+			Members: []OPGenericLink{{Href: "/api/v3/users/1"}},
+		},
+	}
+	group, _, err := testClient.Group.Create(g)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if group == nil {
+		t.Error("Expected group. Group is nil")
+	}
+}
+
+func TestGroupService_AddAndRemoveMembers(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/groups/9"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	var sent []string
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testRequestURL(t, r, testAPIEdpoint)
+		if r.Method == "PATCH" {
+			body := new(groupMembersPayload)
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				t.Error(err.Error())
+			}
+			sent = []string{}
+			for _, member := range body.Links.Members {
+				sent = append(sent, member.Href)
+			}
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	if _, _, err := testClient.Group.AddMembers("9", "4", "7"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if fmt.Sprint(sent) != "[/api/v3/users/1 /api/v3/users/4 /api/v3/users/7]" {
+		t.Errorf("Unexpected members sent %v", sent)
+	}
+
+	if _, _, err := testClient.Group.RemoveMembers("9", "1", "4"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if sent == nil || len(sent) != 0 {
+		t.Errorf("Expected empty member list to be sent, got %v", sent)
+	}
+}
+
+func TestGroupService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/groups/9", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testRequestURL(t, r, "/api/v3/groups/9")
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	resp, err := testClient.Group.Delete("9")
+	if resp.StatusCode != 202 {
+		t.Error("Group not deleted.")
+	}
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
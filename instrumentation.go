@@ -0,0 +1,162 @@
+package openproject
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Instrumentation observes the API calls of a Client, i.e. to trace them or to record metrics,
+// see Client.SetInstrumentation and the otelopenproject package for OpenTelemetry.
+// StartCall is called before a call is sent and returns the context of the call, i.e. carrying its span,
+// along with the function to call once the call is over. Implementations must be safe for concurrent use
+type Instrumentation interface {
+	StartCall(ctx context.Context, call *CallInfo) (context.Context, func(result *CallResult))
+}
+
+// CallInfo describes an API call
+type CallInfo struct {
+	// Operation is the service method doing the call, i.e. "WorkPackageService.GetList".
+	// It is empty for requests sent through Client.Do directly
+	Operation string
+	// Request is the request of the call. Instrumentations can add headers, i.e. to propagate a trace
+	Request *http.Request
+	// Endpoint is the path of the request relative to the base URL, with resource IDs replaced by "{id}",
+	// i.e. "api/v3/work_packages/{id}/activities"
+	Endpoint string
+	// ResourceID is the ID of the resource the call is about, if any
+	ResourceID string
+}
+
+// CallResult describes how an API call ended
+type CallResult struct {
+	// StatusCode is 0 if no response was received
+	StatusCode int
+	// Count is the number of elements of the returned collection, -1 if a single resource was returned
+	Count int
+	// Total is the number of elements of the returned collection among every page
+	Total int
+	Err   error
+	// Duration of the call, retries included
+	Duration time.Duration
+}
+
+// endpointStaticSegments are path segments naming a sub-resource or an action where an ID could be
+var endpointStaticSegments = map[string]bool{
+	"available_assignees":           true,
+	"available_projects":            true,
+	"available_relation_candidates": true,
+	"available_watchers":            true,
+	"columns":                       true,
+	"default":                       true,
+	"filter_instance_schemas":       true,
+	"filters":                       true,
+	"form":                          true,
+	"group_bys":                     true,
+	"operators":                     true,
+	"prepare":                       true,
+	"schemas":                       true,
+	"sort_bys":                      true,
+}
+
+// operationPattern matches the functions of service methods
+var operationPattern = regexp.MustCompile(`\.\(\*(\w+Service)\)\.(\w+)$`)
+
+// SetInstrumentation sets the instrumentation observing the API calls of the client. nil removes it, which is the default.
+// It must not be called while requests are being sent
+func (c *Client) SetInstrumentation(instrumentation Instrumentation) {
+	c.instrumentation = instrumentation
+}
+
+// startCall starts observing a call, returning the request to send along with the function to call once done.
+// Without instrumentation the request is returned as it is
+func (c *Client) startCall(req *http.Request) (*http.Request, func(resp *http.Response, v interface{}, err error)) {
+	if c.instrumentation == nil {
+		return req, func(*http.Response, interface{}, error) {}
+	}
+
+	endpoint, resourceID := endpointTemplate(strings.TrimLeft(strings.TrimPrefix(req.URL.Path, c.baseURL.Path), "/"))
+	info := &CallInfo{
+		Operation:  callerOperation(),
+		Request:    req,
+		Endpoint:   endpoint,
+		ResourceID: resourceID,
+	}
+	start := time.Now()
+	ctx, end := c.instrumentation.StartCall(req.Context(), info)
+	req = info.Request.WithContext(ctx)
+
+	return req, func(resp *http.Response, v interface{}, err error) {
+		result := &CallResult{Count: -1, Err: err, Duration: time.Since(start)}
+		if resp != nil {
+			result.StatusCode = resp.StatusCode
+		}
+		if err == nil {
+			result.Count, result.Total = collectionSize(v)
+		}
+		end(result)
+	}
+}
+
+// endpointTemplate replaces the resource IDs of a path by "{id}" and returns the last one,
+// i.e. "api/v3/projects/demo/work_packages" gives "api/v3/projects/{id}/work_packages" and "demo"
+func endpointTemplate(reqPath string) (string, string) {
+	segments := strings.Split(strings.Trim(reqPath, "/"), "/")
+	resourceID := ""
+	expectID := false
+	for i, segment := range segments {
+		if i < 2 && (segment == "api" || segment == "v3") {
+			continue
+		}
+		if expectID && !endpointStaticSegments[segment] {
+			resourceID = segment
+			segments[i] = "{id}"
+			expectID = false
+			continue
+		}
+		expectID = true
+	}
+	return strings.Join(segments, "/"), resourceID
+}
+
+// callerOperation returns the innermost service method within the stack, i.e. "WorkPackageService.GetList"
+func callerOperation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	pkg := reflect.TypeOf(Client{}).PkgPath()
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, pkg+".") {
+			if match := operationPattern.FindStringSubmatch(frame.Function); match != nil {
+				return match[1] + "." + strings.TrimSuffix(match[2], "WithContext")
+			}
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// collectionSize returns the count and total of a decoded collection, -1 if v is not a collection
+func collectionSize(v interface{}) (int, int) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return -1, 0
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return -1, 0
+	}
+	count, total := value.FieldByName("Count"), value.FieldByName("Total")
+	if !count.IsValid() || !total.IsValid() || count.Kind() != reflect.Int || total.Kind() != reflect.Int {
+		return -1, 0
+	}
+	return int(count.Int()), int(total.Int())
+}
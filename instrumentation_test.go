@@ -0,0 +1,73 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// recordingInstrumentation records the calls it observes
+type recordingInstrumentation struct {
+	calls   []*CallInfo
+	results []*CallResult
+}
+
+func (r *recordingInstrumentation) StartCall(ctx context.Context, call *CallInfo) (context.Context, func(result *CallResult)) {
+	r.calls = append(r.calls, call)
+	return ctx, func(result *CallResult) {
+		r.results = append(r.results, result)
+	}
+}
+
+func TestClient_SetInstrumentation(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/projects/demo/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Collection","total":3,"count":1,"_embedded":{"elements":[{"id":1}]}}`)
+	})
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	recorder := new(recordingInstrumentation)
+	testClient.SetInstrumentation(recorder)
+
+	if _, _, err := GetListWithContext(context.Background(), testClient.WorkPackage, "api/v3/projects/demo/work_packages", nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	testClient.Status.Get("1")
+
+	if len(recorder.calls) != 2 || len(recorder.results) != 2 {
+		t.Fatalf("Expected 2 calls observed, %d given", len(recorder.calls))
+	}
+	list, get := recorder.calls[0], recorder.calls[1]
+	if list.Operation != "" || list.Endpoint != "api/v3/projects/{id}/work_packages" || list.ResourceID != "demo" {
+		t.Errorf("Unexpected call %+v", list)
+	}
+	if get.Operation != "StatusService.Get" || get.Endpoint != "api/v3/statuses/{id}" || get.ResourceID != "1" {
+		t.Errorf("Unexpected call %+v", get)
+	}
+	if result := recorder.results[0]; result.Count != 1 || result.Total != 3 || result.StatusCode != 200 || result.Err != nil {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if result := recorder.results[1]; result.Count != -1 || result.StatusCode != 404 || result.Err == nil {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	for reqPath, expected := range map[string][2]string{
+		"api/v3/work_packages":                          {"api/v3/work_packages", ""},
+		"api/v3/work_packages/42/activities":            {"api/v3/work_packages/{id}/activities", "42"},
+		"api/v3/queries/default":                        {"api/v3/queries/default", ""},
+		"api/v3/queries/filter_instance_schemas/status": {"api/v3/queries/filter_instance_schemas/{id}", "status"},
+		"api/v3/projects/demo/work_packages/form":       {"api/v3/projects/{id}/work_packages/form", "demo"},
+		"api/v3/attachments/5/content":                  {"api/v3/attachments/{id}/content", "5"},
+		"api/v3/users/me":                               {"api/v3/users/{id}", "me"},
+	} {
+		endpoint, id := endpointTemplate(reqPath)
+		if endpoint != expected[0] || id != expected[1] {
+			t.Errorf("%s: expected %v, %s and %s given", reqPath, expected, endpoint, id)
+		}
+	}
+}
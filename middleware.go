@@ -0,0 +1,268 @@
+package openproject
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// RequestHandler sends a request and returns its response, like http.Client.Do
+type RequestHandler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of requests, i.e. for logging, header injection, metrics or fault injection.
+// A middleware calls next to send the request, and can change the request before and the response after,
+// or answer without calling next at all:
+//
+//	client.Use(func(next openproject.RequestHandler) openproject.RequestHandler {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s took %s", req.Method, req.URL, time.Since(start))
+//			return resp, err
+//		}
+//	})
+//
+// Middlewares run for every attempt sent to the server: retried requests go through them again,
+// while responses served from the cache without asking the server do not
+type Middleware func(next RequestHandler) RequestHandler
+
+// Use appends middlewares to the chain of the client. The first middleware added is the outermost one.
+// It must not be called while requests are being sent
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// chain returns handler, the one sending requests with an HTTP client, wrapped by the middlewares of the client
+func (c *Client) chain(handler RequestHandler) RequestHandler {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	return handler
+}
+
+// HeaderMiddleware returns a middleware setting headers on every request, i.e. a correlation ID or a User-Agent
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			req2 := cloneRequest(req)
+			for key, values := range header {
+				req2.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next(req2)
+		}
+	}
+}
+
+// DumpOptions configures DumpMiddleware
+type DumpOptions struct {
+	// ErrorsOnly dumps only the requests failing or answered with a status from 400 on
+	ErrorsOnly bool
+	// MaxBodySize is the number of body bytes dumped, 64KiB if 0. Negative values leave bodies out
+	MaxBodySize int
+	// RedactHeaders are redacted in addition to Authorization, Cookie, Set-Cookie, Proxy-Authorization and X-CSRF-Token
+	RedactHeaders []string
+	// RedactFields are the JSON fields, form fields and query parameters redacted in addition to the ones
+	// carrying credentials, i.e. "password", "client_secret", "refresh_token" or "x-amz-signature"
+	RedactFields []string
+}
+
+// defaultRedactHeaders are always redacted by DumpMiddleware
+var defaultRedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-CSRF-Token"}
+
+// defaultRedactFields are always redacted by DumpMiddleware: credentials and the fields signing
+// the pre-signed storage forms of AttachmentService.Upload
+var defaultRedactFields = []string{"password", "apikey", "api_key", "token", "access_token", "refresh_token", "client_secret", "code", "code_verifier", "authenticity_token",
+	"policy", "signature", "x-amz-signature", "x-amz-credential", "x-amz-security-token"}
+
+// redacted replaces the redacted values
+const redacted = "REDACTED"
+
+// defaultDumpBodySize is the number of body bytes dumped by default
+const defaultDumpBodySize = 64 * 1024
+
+// DumpMiddleware returns a middleware writing requests and their responses to w, for debugging.
+// Credentials are redacted from headers, query parameters and bodies. Dumps of concurrent requests
+// are written one at a time. Storage uploads skip middlewares, wrap the storage client to dump them
+// (see Client.SetStorageClient):
+//
+//	--> PATCH https://openproject.example.com/api/v3/work_packages/42
+//	Authorization: REDACTED
+//	Content-Type: application/json
+//
+//	{"subject":"New subject","lockVersion":3}
+//	<-- 409 Conflict (35ms)
+//	Content-Type: application/hal+json; charset=utf-8
+//
+//	{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:UpdateConflict",...}
+func DumpMiddleware(w io.Writer, options *DumpOptions) Middleware {
+	if options == nil {
+		options = &DumpOptions{}
+	}
+	maxBody := options.MaxBodySize
+	if maxBody == 0 {
+		maxBody = defaultDumpBodySize
+	}
+	headers := make(map[string]bool)
+	for _, header := range append(append([]string(nil), defaultRedactHeaders...), options.RedactHeaders...) {
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+	redactor := newFieldRedactor(append(append([]string(nil), defaultRedactFields...), options.RedactFields...))
+	var mu sync.Mutex
+
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			reqBody, err := peekRequestBody(req, maxBody)
+			if err != nil {
+				return nil, err
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start).Round(time.Millisecond)
+			if options.ErrorsOnly && err == nil && resp.StatusCode < 400 {
+				return resp, err
+			}
+
+			var dump bytes.Buffer
+			fmt.Fprintf(&dump, "--> %s %s\n", req.Method, redactor.redactURL(req.URL))
+			writeDumpHeader(&dump, req.Header, headers)
+			writeDumpBody(&dump, reqBody, redactor)
+			if err != nil {
+				fmt.Fprintf(&dump, "<-- %s (%s)\n\n", err, elapsed)
+			} else {
+				var respBody []byte
+				resp.Body, respBody = peekBody(resp.Body, maxBody)
+				fmt.Fprintf(&dump, "<-- %s (%s)\n", resp.Status, elapsed)
+				writeDumpHeader(&dump, resp.Header, headers)
+				writeDumpBody(&dump, respBody, redactor)
+			}
+
+			mu.Lock()
+			w.Write(dump.Bytes())
+			mu.Unlock()
+			return resp, err
+		}
+	}
+}
+
+// peekRequestBody returns the first max bytes of the body of a request, leaving the body untouched
+func peekRequestBody(req *http.Request, max int) ([]byte, error) {
+	if max < 0 || req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(io.LimitReader(body, int64(max)))
+	}
+	var peeked []byte
+	req.Body, peeked = peekBody(req.Body, max)
+	return peeked, nil
+}
+
+// peekBody reads the first max bytes of a body and returns a body giving them back before the rest
+func peekBody(body io.ReadCloser, max int) (io.ReadCloser, []byte) {
+	if max < 0 || body == nil || body == http.NoBody {
+		return body, nil
+	}
+	peeked, _ := ioutil.ReadAll(io.LimitReader(body, int64(max)))
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), body), body}, peeked
+}
+
+// writeDumpHeader writes headers sorted by name, redacting the given ones
+func writeDumpHeader(dump *bytes.Buffer, header http.Header, redact map[string]bool) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if redact[http.CanonicalHeaderKey(key)] {
+				value = redacted
+			}
+			fmt.Fprintf(dump, "%s: %s\n", key, value)
+		}
+	}
+}
+
+// writeDumpBody writes a body after a blank line, redacting its fields
+func writeDumpBody(dump *bytes.Buffer, body []byte, redactor *fieldRedactor) {
+	dump.WriteString("\n")
+	if len(body) == 0 {
+		return
+	}
+	if !utf8.Valid(body) {
+		fmt.Fprintf(dump, "[%d bytes of binary data]\n\n", len(body))
+		return
+	}
+	dump.Write(redactor.redactBody(body))
+	dump.WriteString("\n\n")
+}
+
+// fieldRedactor redacts fields from JSON bodies, form bodies, multipart bodies and query strings
+type fieldRedactor struct {
+	fields    map[string]bool
+	json      *regexp.Regexp
+	form      *regexp.Regexp
+	multipart *regexp.Regexp
+}
+
+// newFieldRedactor returns a redactor of the given fields, case insensitive
+func newFieldRedactor(fields []string) *fieldRedactor {
+	r := &fieldRedactor{fields: make(map[string]bool)}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = true
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	names := strings.Join(quoted, "|")
+	// JSON values are strings, numbers, booleans, null, or objects and arrays without nested ones
+	r.json = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"|\{[^{}]*\}|\[[^\[\]]*\]|[\w.+-]+)`)
+	r.form = regexp.MustCompile(`(?i)(^|&)((?:` + names + `)=)[^&]*`)
+	r.multipart = regexp.MustCompile(`(?is)(Content-Disposition:\s*form-data;\s*name="(?:` + names + `)"\r?\n(?:[^\r\n]+\r?\n)*?\r?\n).*?(\r?\n--|$)`)
+	return r
+}
+
+// redactURL returns a URL with the redacted query parameters
+func (r *fieldRedactor) redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	u2 := *u
+	values := u.Query()
+	for key := range values {
+		if r.fields[strings.ToLower(key)] {
+			values.Set(key, redacted)
+		}
+	}
+	u2.RawQuery = values.Encode()
+	return u2.String()
+}
+
+// redactBody redacts the fields of a JSON, a multipart or a form body
+func (r *fieldRedactor) redactBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return r.json.ReplaceAll(body, []byte(`${1}"`+redacted+`"`))
+	}
+	if bytes.HasPrefix(trimmed, []byte("--")) {
+		return r.multipart.ReplaceAll(body, []byte(`${1}`+redacted+`${2}`))
+	}
+	return r.form.ReplaceAll(body, []byte(`${1}${2}`+redacted))
+}
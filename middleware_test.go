@@ -0,0 +1,184 @@
+package openproject
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Use(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Correlation-Id") != "abc" {
+			t.Errorf("Expected injected header, %v given", r.Header)
+		}
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	order := make([]string, 0)
+	trace := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" in")
+				resp, err := next(req)
+				order = append(order, name+" out")
+				return resp, err
+			}
+		}
+	}
+	testClient.Use(trace("first"), HeaderMiddleware(http.Header{"X-Correlation-Id": {"abc"}}))
+	testClient.Use(trace("second"))
+
+	if _, _, err := testClient.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if expected := []string{"first in", "second in", "second out", "first out"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected middlewares run in order %v, %v given", expected, order)
+	}
+}
+
+func TestClient_Use_FaultInjection(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	// Fail the first attempt, the retry goes through the chain again
+	faults := 0
+	testClient.Use(func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			if faults == 0 {
+				faults++
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Status:     "503 Service Unavailable",
+					Header:     make(http.Header),
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Request:    req,
+				}, nil
+			}
+			return next(req)
+		}
+	})
+	testClient.SetRetryPolicy(&RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond})
+
+	if status, _, err := testClient.Status.Get("1"); err != nil || status.Name != "New" || faults != 1 {
+		t.Errorf("Expected status after injected fault, error given: %v", err)
+	}
+}
+
+func TestDumpMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/users", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"password":"secret"`) {
+			t.Errorf("Expected body sent untouched, %s given", body)
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Email is invalid."}`)
+	})
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+
+	var dump bytes.Buffer
+	testClient.Use(DumpMiddleware(&dump, &DumpOptions{ErrorsOnly: true, RedactHeaders: []string{"X-Api-Key"}}))
+	testClient.Authentication.SetBasicAuth("admin", "admin")
+
+	if _, _, err := testClient.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if dump.Len() != 0 {
+		t.Errorf("Expected successful requests not dumped, %s given", dump.String())
+	}
+
+	req, _ := testClient.NewRequest("POST", "api/v3/users?api_key=k3y-abc", &User{Login: "j.doe", Password: "secret"})
+	req.Header.Set("X-Api-Key", "k3y-abc")
+	_, err := testClient.Do(req, nil)
+	if err == nil || !strings.Contains(err.Error(), "Email is invalid.") {
+		t.Errorf("Expected response body still decoded, %v given", err)
+	}
+
+	output := dump.String()
+	for _, expected := range []string{
+		"--> POST " + testServer.URL + "/api/v3/users?api_key=REDACTED\n",
+		"Authorization: REDACTED\n",
+		"X-Api-Key: REDACTED\n",
+		`"password":"REDACTED"`,
+		"<-- 422 Unprocessable Entity (",
+		`"message":"Email is invalid."`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q within dump:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "secret") || strings.Contains(output, "k3y-abc") {
+		t.Errorf("Expected credentials redacted:\n%s", output)
+	}
+}
+
+func TestDumpMiddleware_DirectUpload(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/work_packages/1/attachments/prepare", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"_type":"Attachment","id":22,"_links":{
+			"addAttachment":{"href":"%s/storage","method":"post","form_fields":{"key":"uploads/22/notes.md",
+				"policy":"s3-p0licy","x-amz-signature":"s3-s1gnature","x-amz-credential":"s3-cr3dential","x-amz-security-token":"s3-t0ken"}},
+			"completeUpload":{"href":"/api/v3/attachments/22/uploaded","method":"get"}}}`, testServer.URL)
+	})
+	testMux.HandleFunc("/storage", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/api/v3/attachments/22/uploaded", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Attachment","id":22}`)
+	})
+
+	var dump bytes.Buffer
+	testClient.Use(DumpMiddleware(&dump, nil))
+	testClient.SetStorageClient(httpClientFunc(DumpMiddleware(&dump, nil)(http.DefaultClient.Do)))
+	if _, _, err := testClient.Attachment.Upload("api/v3/work_packages/1/attachments", "notes.md", []byte("Release notes")); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	output := dump.String()
+	for _, expected := range []string{
+		"--> POST " + testServer.URL + "/storage\n",
+		`"policy":"REDACTED"`,
+		`"x-amz-signature":"REDACTED"`,
+		"name=\"x-amz-credential\"\r\n\r\nREDACTED\r\n--",
+		"name=\"key\"\r\n\r\nuploads/22/notes.md\r\n--",
+		"Release notes",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q within dump:\n%s", expected, output)
+		}
+	}
+	for _, secret := range []string{"s3-p0licy", "s3-s1gnature", "s3-cr3dential", "s3-t0ken"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %s redacted:\n%s", secret, output)
+		}
+	}
+
+	// Headers and non-string JSON values
+	req, _ := http.NewRequest("POST", testServer.URL+"/storage", strings.NewReader(`{"token":12345,"code":null,"password":true,"apikey":["a1","b2"],"name":"x"}`))
+	req.Header.Set("X-CSRF-Token", "csrf-t0ken")
+	dump.Reset()
+	if _, err := DumpMiddleware(&dump, nil)(http.DefaultClient.Do)(req); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	output = dump.String()
+	if !strings.Contains(output, `{"token":"REDACTED","code":"REDACTED","password":"REDACTED","apikey":"REDACTED","name":"x"}`) ||
+		!strings.Contains(output, "X-Csrf-Token: REDACTED\n") {
+		t.Errorf("Unexpected dump:\n%s", output)
+	}
+}
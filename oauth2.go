@@ -0,0 +1,545 @@
+package openproject
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// oauth2AuthorizePath is the authorization endpoint of OpenProject, relative to its base URL
+	oauth2AuthorizePath = "oauth/authorize"
+	// oauth2TokenPath is the token endpoint of OpenProject, relative to its base URL
+	oauth2TokenPath = "oauth/token"
+	// oauth2ExpiryDelta renews tokens a bit before they expire, so they do not expire on the way
+	oauth2ExpiryDelta = 10 * time.Second
+)
+
+// OAuth2Config describes an OAuth2 application registered in OpenProject
+// (Administration > Authentication > OAuth applications)
+type OAuth2Config struct {
+	// BaseURL is the URL of the OpenProject instance, i.e. "https://openproject.example.com/"
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the redirect URI registered for the application, used by the authorization code flow
+	RedirectURL string
+	// Scopes default to "api_v3"
+	Scopes []string
+
+	// HTTPClient sends the token requests. It will default to http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// OAuth2Token is a token issued by OpenProject
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token can be used, i.e. it is set and does not expire within the next seconds
+func (t *OAuth2Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(oauth2ExpiryDelta).Before(t.Expiry)
+}
+
+// OAuth2Error is an error returned by the token endpoint
+type OAuth2Error struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error is a short string representing the error
+func (e *OAuth2Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth2: %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("oauth2: %s (status %d)", e.Code, e.StatusCode)
+}
+
+// TokenStore keeps the current token of an OAuth2Transport. Refresh tokens are rotated on every refresh,
+// so stores persisting tokens allow a process to restart without asking the user again.
+// Implementations must be safe for concurrent use
+type TokenStore interface {
+	// Token returns the stored token, nil if there is none
+	Token() (*OAuth2Token, error)
+	SetToken(token *OAuth2Token) error
+}
+
+// memoryTokenStore is a TokenStore keeping the token in memory
+type memoryTokenStore struct {
+	mu    sync.Mutex
+	token *OAuth2Token
+}
+
+// NewMemoryTokenStore returns a TokenStore keeping the token in memory, starting with the given one (can be nil)
+func NewMemoryTokenStore(token *OAuth2Token) TokenStore {
+	return &memoryTokenStore{token: token}
+}
+
+// Token returns the stored token
+func (s *memoryTokenStore) Token() (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+// SetToken replaces the stored token
+func (s *memoryTokenStore) SetToken(token *OAuth2Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// fileTokenStore is a TokenStore keeping the token as JSON file
+type fileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore returns a TokenStore keeping the token as JSON within the file at path,
+// readable by the current user only
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{path: path}
+}
+
+// Token reads the stored token. A missing file means there is no token
+func (s *fileTokenStore) Token() (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token := new(OAuth2Token)
+	if err := json.Unmarshal(raw, token); err != nil {
+		return nil, errors.Wrapf(err, "oauth2: invalid token file %s", s.path)
+	}
+	return token, nil
+}
+
+// SetToken writes the token, writing a temporary file first so the stored token is never partially written
+func (s *fileTokenStore) SetToken(token *OAuth2Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(raw)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0600)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// NewPKCEVerifier returns a random code verifier for the PKCE extension of the authorization code flow
+func NewPKCEVerifier() (string, error) {
+	return randomURLString(32)
+}
+
+// PKCEChallenge returns the S256 code challenge of a code verifier
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomURLString returns n random bytes encoded for URLs
+func randomURLString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL of the authorization page the user has to visit.
+// state is given back to the redirect URL, codeChallenge comes from PKCEChallenge
+func (c *OAuth2Config) AuthCodeURL(state, codeChallenge string) string {
+	values := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.ClientID},
+		"scope":         {c.scope()},
+		"state":         {state},
+	}
+	if c.RedirectURL != "" {
+		values.Set("redirect_uri", c.RedirectURL)
+	}
+	if codeChallenge != "" {
+		values.Set("code_challenge", codeChallenge)
+		values.Set("code_challenge_method", "S256")
+	}
+	return c.endpoint(oauth2AuthorizePath) + "?" + values.Encode()
+}
+
+// ExchangeWithContext exchanges an authorization code, along with the PKCE code verifier, for a token
+func (c *OAuth2Config) ExchangeWithContext(ctx context.Context, code, codeVerifier string) (*OAuth2Token, error) {
+	values := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+	}
+	if c.RedirectURL != "" {
+		values.Set("redirect_uri", c.RedirectURL)
+	}
+	if codeVerifier != "" {
+		values.Set("code_verifier", codeVerifier)
+	}
+	return c.requestToken(ctx, values)
+}
+
+// Exchange wraps ExchangeWithContext using the background context.
+func (c *OAuth2Config) Exchange(code, codeVerifier string) (*OAuth2Token, error) {
+	return c.ExchangeWithContext(context.Background(), code, codeVerifier)
+}
+
+// ClientCredentialsTokenWithContext requests a token with the client credentials grant.
+// Requests are done on behalf of the user the OAuth application is configured to act as
+func (c *OAuth2Config) ClientCredentialsTokenWithContext(ctx context.Context) (*OAuth2Token, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {c.scope()},
+	})
+}
+
+// ClientCredentialsToken wraps ClientCredentialsTokenWithContext using the background context.
+func (c *OAuth2Config) ClientCredentialsToken() (*OAuth2Token, error) {
+	return c.ClientCredentialsTokenWithContext(context.Background())
+}
+
+// RefreshTokenWithContext requests a new token with a refresh token.
+// OpenProject rotates refresh tokens, the given one cannot be used anymore once refreshed
+func (c *OAuth2Config) RefreshTokenWithContext(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	token, err := c.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		// Servers not rotating refresh tokens do not send them again
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// RefreshToken wraps RefreshTokenWithContext using the background context.
+func (c *OAuth2Config) RefreshToken(refreshToken string) (*OAuth2Token, error) {
+	return c.RefreshTokenWithContext(context.Background(), refreshToken)
+}
+
+// requestToken posts a token request to the token endpoint
+func (c *OAuth2Config) requestToken(ctx context.Context, values url.Values) (*OAuth2Token, error) {
+	values.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		values.Set("client_secret", c.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(oauth2TokenPath), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oerr := &OAuth2Error{StatusCode: resp.StatusCode}
+		if json.Unmarshal(body, oerr) != nil || oerr.Code == "" {
+			oerr.Code = http.StatusText(resp.StatusCode)
+		}
+		return nil, oerr
+	}
+
+	token := new(OAuth2Token)
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, errors.Wrap(err, "oauth2: could not parse token response")
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("oauth2: no access token returned")
+	}
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// endpoint returns the URL of an OAuth endpoint
+func (c *OAuth2Config) endpoint(endpointPath string) string {
+	return strings.TrimRight(c.BaseURL, "/") + "/" + endpointPath
+}
+
+// scope returns the requested scopes
+func (c *OAuth2Config) scope() string {
+	if len(c.Scopes) == 0 {
+		return "api_v3"
+	}
+	return strings.Join(c.Scopes, " ")
+}
+
+// AuthorizeWithLoopback runs the authorization code flow with PKCE for command line and desktop tools.
+// It listens on the loopback address of RedirectURL (a random port of 127.0.0.1 if RedirectURL is empty),
+// calls open with the authorization URL the user has to visit, i.e. to launch a browser, and waits for
+// OpenProject to redirect the user back with the authorization code, which is exchanged for a token.
+// The redirect URL registered for the application must be a loopback one, i.e. "http://127.0.0.1:8085/callback"
+func (c *OAuth2Config) AuthorizeWithLoopback(ctx context.Context, open func(authURL string) error) (*OAuth2Token, error) {
+	redirect, err := url.Parse(c.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	if c.RedirectURL == "" {
+		redirect = &url.URL{Scheme: "http", Host: "127.0.0.1:0", Path: "/callback"}
+	}
+	if ip := net.ParseIP(redirect.Hostname()); redirect.Hostname() != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("oauth2: redirect URL %s is not a loopback address", c.RedirectURL)
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	// The port is known once listening
+	redirect.Host = net.JoinHostPort(redirect.Hostname(), fmt.Sprint(listener.Addr().(*net.TCPAddr).Port))
+	config := *c
+	config.RedirectURL = redirect.String()
+
+	state, err := randomURLString(16)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := NewPKCEVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	callbacks := make(chan callback, 1)
+	mux := http.NewServeMux()
+	callbackPath := redirect.Path
+	if callbackPath == "" {
+		callbackPath = "/"
+	}
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		result := callback{code: query.Get("code")}
+		switch {
+		case query.Get("state") != state:
+			result.err = errors.New("oauth2: state of the authorization response does not match")
+		case query.Get("error") != "":
+			result.err = &OAuth2Error{Code: query.Get("error"), Description: query.Get("error_description")}
+		case result.code == "":
+			result.err = errors.New("oauth2: no authorization code given")
+		}
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprint(w, "Authorization completed, you can close this window.")
+		}
+		select {
+		case callbacks <- result:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if err := open(config.AuthCodeURL(state, PKCEChallenge(verifier))); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-callbacks:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return config.ExchangeWithContext(ctx, result.code, verifier)
+	}
+}
+
+// OAuth2Transport is an http.RoundTripper that authenticates all requests
+// using OAuth2 bearer tokens, so applications can act on behalf of users without holding their passwords.
+// The current token is taken from Store (in memory if nil). Expired tokens are refreshed with their
+// refresh token, and the rotated token is saved back to Store. With ClientCredentials set, new tokens
+// are requested with the client credentials grant when there is no token to refresh.
+type OAuth2Transport struct {
+	Config *OAuth2Config
+	Store  TokenStore
+
+	// ClientCredentials requests tokens with the client credentials grant
+	ClientCredentials bool
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu sync.Mutex
+}
+
+// RoundTrip adds the bearer token to the request. If the server rejects a token
+// which looked valid, i.e. revoked, the token is renewed and the request sent again when possible
+func (t *OAuth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.token(req.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.send(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be sent again
+		return resp, nil
+	}
+
+	renewed, rerr := t.token(req.Context(), token)
+	if rerr != nil || renewed.AccessToken == token.AccessToken {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	req2 := req
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req2 = cloneRequest(req)
+		req2.Body = body
+	}
+	return t.send(req2, renewed)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated using OAuth2
+func (t *OAuth2Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// send sends a request along with a bearer token
+func (t *OAuth2Transport) send(req *http.Request, token *OAuth2Token) (*http.Response, error) {
+	req2 := cloneRequest(req) // per RoundTripper contract
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	req2.Header.Set("Authorization", tokenType+" "+token.AccessToken)
+	return t.transport().RoundTrip(req2)
+}
+
+// token returns a valid token, renewing the stored one if it expired or if it is the rejected one
+func (t *OAuth2Transport) token(ctx context.Context, rejected *OAuth2Token) (*OAuth2Token, error) {
+	if t.Config == nil {
+		return nil, errors.New("oauth2: no config given")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Store == nil {
+		t.Store = NewMemoryTokenStore(nil)
+	}
+
+	token, err := t.Store.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "oauth2: could not read token")
+	}
+	if token.Valid() && (rejected == nil || token.AccessToken != rejected.AccessToken) {
+		return token, nil
+	}
+
+	var renewed *OAuth2Token
+	switch {
+	case token != nil && token.RefreshToken != "":
+		renewed, err = t.Config.RefreshTokenWithContext(ctx, token.RefreshToken)
+	case t.ClientCredentials:
+		renewed, err = t.Config.ClientCredentialsTokenWithContext(ctx)
+	default:
+		return nil, errors.New("oauth2: no valid token, authorization required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Store.SetToken(renewed); err != nil {
+		return nil, errors.Wrap(err, "oauth2: could not store token")
+	}
+	return renewed, nil
+}
+
+// storedToken returns the stored token without renewing it, nil if there is none
+func (t *OAuth2Transport) storedToken() *OAuth2Token {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Store == nil {
+		return nil
+	}
+	token, err := t.Store.Token()
+	if err != nil {
+		return nil
+	}
+	return token
+}
+
+// transport OAuth2Transport
+func (t *OAuth2Transport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
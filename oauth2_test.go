@@ -0,0 +1,175 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newOAuth2Server returns an OpenProject-like server issuing tokens and answering API calls with a valid one
+func newOAuth2Server(t *testing.T) (*httptest.Server, *int) {
+	refreshes := 0
+	challenges := make(map[string]string)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != "app" {
+			t.Errorf("Unexpected authorization request %s", r.URL)
+		}
+		challenges["code-1"] = query.Get("code_challenge")
+		http.Redirect(w, r, query.Get("redirect_uri")+"?code=code-1&state="+url.QueryEscape(query.Get("state")), http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+			if r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_client","error_description":"Client authentication failed"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"access-cc","token_type":"Bearer","expires_in":7200}`)
+		case "authorization_code":
+			if PKCEChallenge(r.PostForm.Get("code_verifier")) != challenges[r.PostForm.Get("code")] {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"access-code","token_type":"Bearer","refresh_token":"refresh-0","expires_in":7200}`)
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != fmt.Sprintf("refresh-%d", refreshes) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"The refresh token is invalid"}`)
+				return
+			}
+			refreshes++
+			fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"Bearer","refresh_token":"refresh-%d","expires_in":7200}`, refreshes, refreshes)
+		}
+	})
+	mux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth == "Bearer revoked" || auth == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	return httptest.NewServer(mux), &refreshes
+}
+
+func TestOAuth2Transport_ClientCredentials(t *testing.T) {
+	server, _ := newOAuth2Server(t)
+	defer server.Close()
+
+	tp := &OAuth2Transport{
+		Config:            &OAuth2Config{BaseURL: server.URL, ClientID: "app", ClientSecret: "secret"},
+		ClientCredentials: true,
+	}
+	client, _ := NewClient(tp.Client(), server.URL)
+	if _, _, err := client.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if token, _ := tp.Store.Token(); token == nil || token.AccessToken != "access-cc" || !token.Valid() {
+		t.Errorf("Expected client credentials token stored, %+v given", token)
+	}
+
+	// Token endpoint errors are reported
+	tp = &OAuth2Transport{
+		Config:            &OAuth2Config{BaseURL: server.URL, ClientID: "app", ClientSecret: "wrong"},
+		ClientCredentials: true,
+	}
+	client, _ = NewClient(tp.Client(), server.URL)
+	_, _, err := client.Status.Get("1")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	var oerr *OAuth2Error
+	if !errors.As(err, &oerr) || oerr.Code != "invalid_client" {
+		t.Errorf("Expected invalid client, %v given", err)
+	}
+}
+
+func TestOAuth2Transport_RefreshRotation(t *testing.T) {
+	server, refreshes := newOAuth2Server(t)
+	defer server.Close()
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"))
+	store.SetToken(&OAuth2Token{AccessToken: "expired", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)})
+	tp := &OAuth2Transport{
+		Config: &OAuth2Config{BaseURL: server.URL, ClientID: "app"},
+		Store:  store,
+	}
+	client, _ := NewClient(tp.Client(), server.URL)
+
+	// Expired token is refreshed and the rotated refresh token saved
+	if _, _, err := client.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	token, err := store.Token()
+	if err != nil || token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" || *refreshes != 1 {
+		t.Errorf("Expected rotated token stored, %+v given (%v)", token, err)
+	}
+
+	// Valid tokens are reused
+	if _, _, err := client.Status.Get("1"); err != nil || *refreshes != 1 {
+		t.Errorf("Expected no refresh, %d done (%v)", *refreshes, err)
+	}
+
+	// Rejected tokens are refreshed and the request sent again
+	store.SetToken(&OAuth2Token{AccessToken: "revoked", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)})
+	if _, _, err := client.Status.Get("1"); err != nil || *refreshes != 2 {
+		t.Errorf("Expected refresh after rejection, %d done (%v)", *refreshes, err)
+	}
+
+	// Without refresh token authorization is required
+	tp.Store = NewMemoryTokenStore(nil)
+	if _, _, err := client.Status.Get("1"); err == nil {
+		t.Error("Expected error without token")
+	}
+}
+
+func TestOAuth2Config_AuthorizeWithLoopback(t *testing.T) {
+	server, _ := newOAuth2Server(t)
+	defer server.Close()
+
+	config := &OAuth2Config{BaseURL: server.URL, ClientID: "app"}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := config.AuthorizeWithLoopback(ctx, func(authURL string) error {
+		// The browser follows the redirect to the loopback server
+		go func() {
+			resp, err := http.Get(authURL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if token.AccessToken != "access-code" || token.RefreshToken != "refresh-0" {
+		t.Errorf("Unexpected token %+v", token)
+	}
+
+	config.RedirectURL = "https://example.com/callback"
+	if _, err := config.AuthorizeWithLoopback(ctx, func(string) error { return nil }); err == nil {
+		t.Error("Expected error for non-loopback redirect URL")
+	}
+}
+
+func TestOAuth2Token_JSON(t *testing.T) {
+	raw, _ := json.Marshal(&OAuth2Token{AccessToken: "a", Expiry: time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC)})
+	token := new(OAuth2Token)
+	if err := json.Unmarshal(raw, token); err != nil || token.Valid() {
+		t.Errorf("Expected expired token, %+v given (%v)", token, err)
+	}
+}
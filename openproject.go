@@ -28,6 +28,14 @@ type OPGenericDescription struct {
 	HTML   string `json:"html,omitempty" structs:"html,omitempty"`
 }
 
+// OPGenericLink is the HAL link structure widely used within "_links" of OpenProject API objects
+type OPGenericLink struct {
+	Href      string `json:"href,omitempty" structs:"href,omitempty"`
+	Title     string `json:"title,omitempty" structs:"title,omitempty"`
+	Method    string `json:"method,omitempty" structs:"method,omitempty"`
+	Templated bool   `json:"templated,omitempty" structs:"templated,omitempty"`
+}
+
 // Time represents the Time definition of OpenProject as a time.Time of go
 type Time time.Time
 
@@ -49,12 +57,30 @@ type Client struct {
 	// HTTP client used to communicate with the API.
 	client httpClient
 
+	// HTTP client used to upload files to object storages, see Client.SetStorageClient
+	storageClient httpClient
+
 	// Base URL for API requests.
 	baseURL *url.URL
 
 	// Session storage if the user authenticates with Session cookies
 	session *Session
 
+	// Retry policy of failed requests, nil if they are not retried
+	retry *RetryPolicy
+
+	// Rate and concurrency limits of requests
+	limits rateLimits
+
+	// Response cache, nil if responses are not cached
+	cache *CacheOptions
+
+	// Middlewares wrapping every request sent, see Client.Use
+	middlewares []Middleware
+
+	// Instrumentation observing API calls, nil if calls are not observed
+	instrumentation Instrumentation
+
 	// Services used for talking to different parts of OpenProject API.
 	Authentication *AuthenticationService
 	WorkPackage    *WorkPackageService
@@ -65,6 +91,9 @@ type Client struct {
 	Attachment     *AttachmentService
 	Category       *CategoryService
 	Query          *QueryService
+	Group          *GroupService
+	Principal      *PrincipalService
+	Placeholder    *PlaceholderUserService
 }
 
 // NewClient returns a new OpenProject API client.
@@ -97,6 +126,9 @@ func NewClient(httpClient httpClient, baseURL string) (*Client, error) {
 	c.Attachment = &AttachmentService{client: c}
 	c.Category = &CategoryService{client: c}
 	c.Query = &QueryService{client: c}
+	c.Group = &GroupService{client: c}
+	c.Principal = &PrincipalService{client: c}
+	c.Placeholder = &PlaceholderUserService{client: c}
 
 	return c, nil
 }
@@ -125,9 +157,7 @@ func (c *Client) NewRawRequestWithContext(ctx context.Context, method, urlStr st
 	if c.Authentication.authType == authTypeSession {
 		// Set session cookie if there is one
 		if c.session != nil {
-			for _, cookie := range c.session.Cookies {
-				req.AddCookie(cookie)
-			}
+			applySession(req, c.session.Cookies)
 		}
 	} else if c.Authentication.authType == authTypeBasic {
 		// Set basic auth information
@@ -177,9 +207,7 @@ func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr strin
 	if c.Authentication.authType == authTypeSession {
 		// Set session cookie if there is one
 		if c.session != nil {
-			for _, cookie := range c.session.Cookies {
-				req.AddCookie(cookie)
-			}
+			applySession(req, c.session.Cookies)
 		}
 	} else if c.Authentication.authType == authTypeBasic {
 		// Set basic auth information
@@ -240,9 +268,7 @@ func (c *Client) NewMultiPartRequestWithContext(ctx context.Context, method, url
 	if c.Authentication.authType == authTypeSession {
 		// Set session cookie if there is one
 		if c.session != nil {
-			for _, cookie := range c.session.Cookies {
-				req.AddCookie(cookie)
-			}
+			applySession(req, c.session.Cookies)
 		}
 	} else if c.Authentication.authType == authTypeBasic {
 		// Set basic auth information
@@ -261,18 +287,25 @@ func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (
 
 // Do sends an API request and returns the API response.
 // The API response is JSON decoded and stored in the value pointed to by v, or returned as an error if an API error has occurred.
+// Errors are *Error values carrying the status, the request and the message of the server.
+// If v is nil the body of a successful response is left open and must be closed by the caller
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	httpResp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	req, endCall := c.startCall(req)
+	resp, err := c.do(req, v)
+	if resp != nil {
+		endCall(resp.Response, v, err)
+	} else {
+		endCall(nil, v, err)
 	}
+	return resp, err
+}
 
-	// requestDump, err := httputil.DumpResponse(httpResp, true)
-	// if err != nil {
-	// 	fmt.Println(err.Error())
-	// } else {
-	// 	fmt.Println(requestDump)
-	// }
+// do sends an API request and decodes its response, see Do
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	httpResp, err := c.doCached(req)
+	if err != nil {
+		return nil, newRequestError(req, err)
+	}
 
 	err = CheckResponse(httpResp)
 	if err != nil {
@@ -281,44 +314,104 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	}
 
 	if v != nil {
-		// Open a NewDecoder and defer closing the reader only if there is a provided interface to decode to
+		// Decode and close the reader only if there is a provided interface to decode to
 		defer httpResp.Body.Close()
-		err = json.NewDecoder(httpResp.Body).Decode(v)
+		if err = decodeResponse(httpResp, v); err != nil {
+			return newResponse(httpResp, nil), err
+		}
 	}
 
 	resp := newResponse(httpResp, v)
-	return resp, err
+	return resp, nil
 }
 
-// Download request a file download
-func (c *Client) Download(req *http.Request) (*http.Response, error) {
-	httpResp, err := c.client.Do(req)
+// decodeResponse decodes the body of a successful response into v.
+// Empty bodies (i.e. 204 No Content) leave v untouched. Bodies which are not JSON can be read
+// into a *[]byte or a *string, otherwise the error tells the content type of the response
+func decodeResponse(r *http.Response, v interface{}) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return newResponseDecodeError(r, "could not read the returned data", err)
+	}
+	switch raw := v.(type) {
+	case *[]byte:
+		*raw = body
+		return nil
+	case *string:
+		*raw = string(body)
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		contentType := r.Header.Get("Content-Type")
+		if contentType != "" && !isJSONContentType(contentType) {
+			return newResponseDecodeError(r, fmt.Sprintf("expected a JSON response, %s given", contentType), err)
+		}
+		return newResponseDecodeError(r, "could not unmarshall the data into struct", err)
+	}
+	return nil
+}
+
+// newResponseDecodeError returns the Error of a successful response which could not be decoded
+func newResponseDecodeError(r *http.Response, message string, err error) *Error {
+	opErr := &Error{StatusCode: r.StatusCode, Message: message, HTTPError: err}
+	if r.Request != nil {
+		opErr.Method = r.Request.Method
+		opErr.URL = r.Request.URL.String()
+	}
+	return opErr
+}
+
+// send sends a single attempt of a request once the limits of the client allow it
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	return c.sendWith(req, c.chain(c.client.Do))
+}
+
+// sendWith sends a single attempt of a request with handler, once the limits of the client allow it.
+// The limits are held until the response body is closed or read to the end
+func (c *Client) sendWith(req *http.Request, handler RequestHandler) (*http.Response, error) {
+	release, err := c.acquireLimits(req)
 	if err != nil {
 		return nil, err
 	}
 
-	// requestDump, err := httputil.DumpResponse(httpResp, true)
-	// if err != nil {
-	// 	fmt.Println(err.Error())
-	// } else {
-	// 	fmt.Println(requestDump)
-	// }
+	resp, err := handler(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
 
-	err = CheckResponse(httpResp)
+// Download request a file download. The body of the response is left open and must be closed by the caller,
+// the request counts against RateLimit.MaxInFlight until then
+func (c *Client) Download(req *http.Request) (*http.Response, error) {
+	req, endCall := c.startCall(req)
+	httpResp, err := c.doCached(req)
+	if err != nil {
+		err = newRequestError(req, err)
+	} else {
+		err = CheckResponse(httpResp)
+	}
 
+	endCall(httpResp, nil, err)
 	return httpResp, err
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
-// The caller is responsible to analyze the response body.
+// The error is an *Error decoded from the response body, which is closed and replaced by a copy
+// so the caller can still analyze it.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 
-	err := fmt.Errorf("request failed. Please analyze the request body for more details. Status code: %d", r.StatusCode)
-	return err
+	return newResponseError(r)
 }
 
 // GetBaseURL will return you the Base URL.
@@ -364,6 +457,31 @@ func (r *Response) populatePageValues(v interface{}) {
 		r.Count = value.Count
 		r.PageSize = value.PageSize
 		r.Offset = value.Offset
+	case *SearchResultWikiPage:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultAttachment:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultGroup:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultPrincipal:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
+	case *SearchResultPlaceholderUser:
+		r.Total = value.Total
+		r.Count = value.Count
+		r.PageSize = value.PageSize
+		r.Offset = value.Offset
 	}
 }
 
@@ -404,13 +522,47 @@ func (t *BasicAuthTransport) transport() http.RoundTripper {
 	return http.DefaultTransport
 }
 
+// APIKeyTransport is an http.RoundTripper that authenticates all requests
+// using the API key of a user (My account > Access tokens), sent as the basic credentials "apikey:<key>".
+type APIKeyTransport struct {
+	APIKey string
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.  We just add the
+// API key as basic auth and return the RoundTripper for this transport type.
+func (t *APIKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := cloneRequest(req) // per RoundTripper contract
+
+	req2.SetBasicAuth(apiKeyUsername, t.APIKey)
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using the API key.
+func (t *APIKeyTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// transport APIKeyTransport
+func (t *APIKeyTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
 // CookieAuthTransport is an http.RoundTripper that authenticates all requests
-// using cookie-based authentication.
-// Note that it is generally preferable to use HTTP BASIC authentication with the REST API.
+// using cookie-based authentication, signing in through the login form of OpenProject.
+// Note that it is generally preferable to use an API key with the REST API, see APIKeyTransport.
 type CookieAuthTransport struct {
 	Username string
 	Password string
-	AuthURL  string
+	// AuthURL is the login form of OpenProject, i.e. "https://openproject.example.com/login"
+	AuthURL string
 
 	// SessionObject is the authenticated cookie string.s
 	// It's passed in each call to prove the client is authenticated.
@@ -431,12 +583,7 @@ func (t *CookieAuthTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	}
 
 	req2 := cloneRequest(req) // per RoundTripper contract
-	for _, cookie := range t.SessionObject {
-		// Don't add an empty value cookie to the request
-		if cookie.Value != "" {
-			req2.AddCookie(cookie)
-		}
-	}
+	applySession(req2, t.SessionObject)
 
 	return t.transport().RoundTrip(req2)
 }
@@ -447,48 +594,30 @@ func (t *CookieAuthTransport) Client() *http.Client {
 	return &http.Client{Transport: t}
 }
 
+// applySession adds the cookies of a session to a request. OpenProject only accepts API requests
+// of a session along with the X-Requested-With header, against CSRF
+func applySession(req *http.Request, cookies []*http.Cookie) {
+	for _, cookie := range cookies {
+		// Don't add an empty value cookie to the request
+		if cookie.Value != "" {
+			req.AddCookie(cookie)
+		}
+	}
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+}
+
 // setSessionObject attempts to authenticate the user and set
 // the session object (e.g. cookie)
 func (t *CookieAuthTransport) setSessionObject() error {
-	req, err := t.buildAuthRequest()
+	cookies, err := openProjectLogin(context.Background(), t.Transport, t.AuthURL, t.Username, t.Password)
 	if err != nil {
 		return err
 	}
 
-	var authClient = &http.Client{
-		Timeout: time.Second * 60,
-	}
-	resp, err := authClient.Do(req)
-	if err != nil {
-		return err
-	}
-
-	t.SessionObject = resp.Cookies()
+	t.SessionObject = cookies
 	return nil
 }
 
-// getAuthRequest assembles the request to get the authenticated cookie
-func (t *CookieAuthTransport) buildAuthRequest() (*http.Request, error) {
-	body := struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
-	}{
-		t.Username,
-		t.Password,
-	}
-
-	b := new(bytes.Buffer)
-	json.NewEncoder(b).Encode(body)
-
-	req, err := http.NewRequest("POST", t.AuthURL, b)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	return req, nil
-}
-
 func (t *CookieAuthTransport) transport() http.RoundTripper {
 	if t.Transport != nil {
 		return t.Transport
@@ -582,19 +711,20 @@ func cloneRequest(r *http.Request) *http.Request {
 
 // Interpret Operator collection and return its string ( Used in searches like GetList(...) )
 func interpretOperator(operator SearchOperator) string {
-	result := "="
-
-	switch operator {
-	case GreaterThan:
-		result = ">"
-	case LowerThan:
-		result = "<"
-	case Different:
-		result = "<>"
+	if symbol, ok := operatorSymbols[operator]; ok {
+		return symbol
 	}
-	//TODO: Complete list of operators
+	return "="
+}
 
-	return result
+// parseOperator returns the SearchOperator of an OpenProject operator symbol (i.e. "o", "<>d")
+func parseOperator(symbol string) (SearchOperator, bool) {
+	for operator, s := range operatorSymbols {
+		if s == symbol {
+			return operator, true
+		}
+	}
+	return Equal, false
 }
 
 // getObjectAndClient gets an inputObject (inputObject is an OpenProject object like WorkPackage, WikiPage, Status, etc.)
@@ -607,6 +737,15 @@ func getObjectAndClient(inputObj interface{}) (client *Client, resultObj interfa
 	case *CategoryService:
 		client = inputObj.(*CategoryService).client
 		resultObj = new(Category)
+	case *GroupService:
+		client = inputObj.(*GroupService).client
+		resultObj = new(Group)
+	case *PrincipalService:
+		client = inputObj.(*PrincipalService).client
+		resultObj = new(Principal)
+	case *PlaceholderUserService:
+		client = inputObj.(*PlaceholderUserService).client
+		resultObj = new(PlaceholderUser)
 	case *ProjectService:
 		client = inputObj.(*ProjectService).client
 		resultObj = new(Project)
@@ -636,10 +775,19 @@ func getObjectListAndClient(inputObj interface{}) (client *Client, resultObjList
 	switch inputObj.(type) {
 	case *AttachmentService:
 		client = inputObj.(*AttachmentService).client
-		// TODO implement
+		resultObjList = new(SearchResultAttachment)
 	case *CategoryService:
 		client = inputObj.(*CategoryService).client
 		resultObjList = new(CategoryList)
+	case *GroupService:
+		client = inputObj.(*GroupService).client
+		resultObjList = new(SearchResultGroup)
+	case *PrincipalService:
+		client = inputObj.(*PrincipalService).client
+		resultObjList = new(SearchResultPrincipal)
+	case *PlaceholderUserService:
+		client = inputObj.(*PlaceholderUserService).client
+		resultObjList = new(SearchResultPlaceholderUser)
 	case *ProjectService:
 		client = inputObj.(*ProjectService).client
 		resultObjList = new(SearchResultProject)
@@ -652,8 +800,9 @@ func getObjectListAndClient(inputObj interface{}) (client *Client, resultObjList
 	case *UserService:
 		client = inputObj.(*UserService).client
 		resultObjList = new(SearchResultUser)
-	// WikiPage endpoint does not support POST action
-	// case *WikiPageService:
+	case *WikiPageService:
+		client = inputObj.(*WikiPageService).client
+		resultObjList = new(SearchResultWikiPage)
 	case *WorkPackageService:
 		client = inputObj.(*WorkPackageService).client
 		resultObjList = new(SearchResultWP)
@@ -677,9 +826,8 @@ func GetWithContext(ctx context.Context, objService interface{}, apiEndPoint str
 	}
 
 	resp, err := client.Do(req, resultObj)
-
 	if err != nil {
-		return nil, resp, NewOpenProjectError(resp, err)
+		return nil, resp, err
 	}
 	return resultObj, resp, nil
 }
@@ -689,6 +837,10 @@ func GetWithContext(ctx context.Context, objService interface{}, apiEndPoint str
 func GetListWithContext(ctx context.Context, objService interface{}, apiEndPoint string, options *FilterOptions) (interface{}, *Response, error) {
 	client, resultObjList := getObjectListAndClient(objService)
 	apiEndPoint = strings.TrimRight(apiEndPoint, "/")
+	if client == nil {
+		return nil, nil, errors.New("Null client, object not identified")
+	}
+
 	req, err := client.NewRequestWithContext(ctx, "GET", apiEndPoint, nil)
 	if err != nil {
 		return nil, nil, err
@@ -701,35 +853,52 @@ func GetListWithContext(ctx context.Context, objService interface{}, apiEndPoint
 
 	resp, err := client.Do(req, resultObjList)
 	if err != nil {
-		oerr := NewOpenProjectError(resp, err)
-		return nil, resp, oerr
+		return nil, resp, err
 	}
 
 	return resultObjList, resp, nil
 }
 
 // CreateWithContext (generic) creates an instance af an object (HTTP POST verb)
+// requestObj is the object (or payload) to be sent in the request body
 // Return the instance of the object rendered into proper struct as interface{} to be cast in the caller
-func CreateWithContext(ctx context.Context, objService interface{}, apiEndPoint string) (interface{}, *Response, error) {
+func CreateWithContext(ctx context.Context, objService interface{}, apiEndPoint string, requestObj interface{}) (interface{}, *Response, error) {
 	client, resultObj := getObjectAndClient(objService)
-	req, err := client.NewRequestWithContext(ctx, "POST", apiEndPoint, resultObj)
+	if client == nil {
+		return nil, nil, errors.New("Null client, object not identified")
+	}
+
+	req, err := client.NewRequestWithContext(ctx, "POST", apiEndPoint, requestObj)
 	if err != nil {
 		return nil, nil, err
 	}
-	resp, err := client.Do(req, nil)
+
+	resp, err := client.Do(req, resultObj)
 	if err != nil {
 		// incase of error return the resp for further inspection
 		return nil, resp, err
 	}
+	return resultObj, resp, nil
+}
 
-	defer resp.Body.Close()
-	data, err := ioutil.ReadAll(resp.Body)
+// UpdateWithContext (generic) updates an instance of an object (HTTP PATCH verb)
+// requestObj is the object (or payload) carrying the modified fields
+// Return the updated instance of the object rendered into proper struct as interface{} to be cast in the caller
+func UpdateWithContext(ctx context.Context, objService interface{}, apiEndPoint string, requestObj interface{}) (interface{}, *Response, error) {
+	client, resultObj := getObjectAndClient(objService)
+	apiEndPoint = strings.TrimRight(apiEndPoint, "/")
+	if client == nil {
+		return nil, nil, errors.New("Null client, object not identified")
+	}
+
+	req, err := client.NewRequestWithContext(ctx, "PATCH", apiEndPoint, requestObj)
 	if err != nil {
-		return nil, resp, fmt.Errorf("could not read the returned data")
+		return nil, nil, err
 	}
-	err = json.Unmarshal(data, resultObj)
+
+	resp, err := client.Do(req, resultObj)
 	if err != nil {
-		return nil, resp, fmt.Errorf("could not unmarshall the data into struct")
+		return nil, resp, err
 	}
 	return resultObj, resp, nil
 }
@@ -739,11 +908,19 @@ func CreateWithContext(ctx context.Context, objService interface{}, apiEndPoint
 func DeleteWithContext(ctx context.Context, objService interface{}, apiEndPoint string) (*Response, error) {
 	client, _ := getObjectAndClient(objService)
 	apiEndPoint = strings.TrimRight(apiEndPoint, "/")
+	if client == nil {
+		return nil, errors.New("Null client, object not identified")
+	}
+
 	req, err := client.NewRequestWithContext(ctx, "DELETE", apiEndPoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := client.Do(req, nil)
-	return resp, err
+	if err != nil {
+		return resp, err
+	}
+	resp.Body.Close()
+	return resp, nil
 }
@@ -0,0 +1,3 @@
+package openprojectmock
+
+//go:generate mockgen -destination=openprojectmock.go -package=openprojectmock github.com/manuelbcd/go-openproject API,AuthenticationAPI,WorkPackageAPI,ProjectAPI,UserAPI,StatusAPI,WikiPageAPI,AttachmentAPI,CategoryAPI,QueryAPI,GroupAPI,PrincipalAPI,PlaceholderUserAPI
@@ -0,0 +1,17 @@
+module github.com/manuelbcd/go-openproject/openprojectmock
+
+go 1.20
+
+require (
+	github.com/manuelbcd/go-openproject v0.0.0
+	go.uber.org/mock v0.4.0
+)
+
+require (
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible // indirect
+	github.com/google/go-querystring v1.0.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/trivago/tgo v1.0.7 // indirect
+)
+
+replace github.com/manuelbcd/go-openproject => ../
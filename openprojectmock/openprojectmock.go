@@ -0,0 +1,2561 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/manuelbcd/go-openproject (interfaces: API,AuthenticationAPI,WorkPackageAPI,ProjectAPI,UserAPI,StatusAPI,WikiPageAPI,AttachmentAPI,CategoryAPI,QueryAPI,GroupAPI,PrincipalAPI,PlaceholderUserAPI)
+//
+// Generated by this command:
+//
+//	mockgen -destination=openprojectmock.go -package=openprojectmock github.com/manuelbcd/go-openproject API,AuthenticationAPI,WorkPackageAPI,ProjectAPI,UserAPI,StatusAPI,WikiPageAPI,AttachmentAPI,CategoryAPI,QueryAPI,GroupAPI,PrincipalAPI,PlaceholderUserAPI
+//
+
+// Package openprojectmock is a generated GoMock package.
+package openprojectmock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// Attachments mocks base method.
+func (m *MockAPI) Attachments() openproject.AttachmentAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attachments")
+	ret0, _ := ret[0].(openproject.AttachmentAPI)
+	return ret0
+}
+
+// Attachments indicates an expected call of Attachments.
+func (mr *MockAPIMockRecorder) Attachments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachments", reflect.TypeOf((*MockAPI)(nil).Attachments))
+}
+
+// Auth mocks base method.
+func (m *MockAPI) Auth() openproject.AuthenticationAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Auth")
+	ret0, _ := ret[0].(openproject.AuthenticationAPI)
+	return ret0
+}
+
+// Auth indicates an expected call of Auth.
+func (mr *MockAPIMockRecorder) Auth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockAPI)(nil).Auth))
+}
+
+// Categories mocks base method.
+func (m *MockAPI) Categories() openproject.CategoryAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories")
+	ret0, _ := ret[0].(openproject.CategoryAPI)
+	return ret0
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockAPIMockRecorder) Categories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockAPI)(nil).Categories))
+}
+
+// Groups mocks base method.
+func (m *MockAPI) Groups() openproject.GroupAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Groups")
+	ret0, _ := ret[0].(openproject.GroupAPI)
+	return ret0
+}
+
+// Groups indicates an expected call of Groups.
+func (mr *MockAPIMockRecorder) Groups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Groups", reflect.TypeOf((*MockAPI)(nil).Groups))
+}
+
+// PlaceholderUsers mocks base method.
+func (m *MockAPI) PlaceholderUsers() openproject.PlaceholderUserAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceholderUsers")
+	ret0, _ := ret[0].(openproject.PlaceholderUserAPI)
+	return ret0
+}
+
+// PlaceholderUsers indicates an expected call of PlaceholderUsers.
+func (mr *MockAPIMockRecorder) PlaceholderUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceholderUsers", reflect.TypeOf((*MockAPI)(nil).PlaceholderUsers))
+}
+
+// Principals mocks base method.
+func (m *MockAPI) Principals() openproject.PrincipalAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Principals")
+	ret0, _ := ret[0].(openproject.PrincipalAPI)
+	return ret0
+}
+
+// Principals indicates an expected call of Principals.
+func (mr *MockAPIMockRecorder) Principals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Principals", reflect.TypeOf((*MockAPI)(nil).Principals))
+}
+
+// Projects mocks base method.
+func (m *MockAPI) Projects() openproject.ProjectAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Projects")
+	ret0, _ := ret[0].(openproject.ProjectAPI)
+	return ret0
+}
+
+// Projects indicates an expected call of Projects.
+func (mr *MockAPIMockRecorder) Projects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projects", reflect.TypeOf((*MockAPI)(nil).Projects))
+}
+
+// Queries mocks base method.
+func (m *MockAPI) Queries() openproject.QueryAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queries")
+	ret0, _ := ret[0].(openproject.QueryAPI)
+	return ret0
+}
+
+// Queries indicates an expected call of Queries.
+func (mr *MockAPIMockRecorder) Queries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queries", reflect.TypeOf((*MockAPI)(nil).Queries))
+}
+
+// Statuses mocks base method.
+func (m *MockAPI) Statuses() openproject.StatusAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statuses")
+	ret0, _ := ret[0].(openproject.StatusAPI)
+	return ret0
+}
+
+// Statuses indicates an expected call of Statuses.
+func (mr *MockAPIMockRecorder) Statuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statuses", reflect.TypeOf((*MockAPI)(nil).Statuses))
+}
+
+// Users mocks base method.
+func (m *MockAPI) Users() openproject.UserAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users")
+	ret0, _ := ret[0].(openproject.UserAPI)
+	return ret0
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockAPIMockRecorder) Users() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockAPI)(nil).Users))
+}
+
+// WikiPages mocks base method.
+func (m *MockAPI) WikiPages() openproject.WikiPageAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WikiPages")
+	ret0, _ := ret[0].(openproject.WikiPageAPI)
+	return ret0
+}
+
+// WikiPages indicates an expected call of WikiPages.
+func (mr *MockAPIMockRecorder) WikiPages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WikiPages", reflect.TypeOf((*MockAPI)(nil).WikiPages))
+}
+
+// WorkPackages mocks base method.
+func (m *MockAPI) WorkPackages() openproject.WorkPackageAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkPackages")
+	ret0, _ := ret[0].(openproject.WorkPackageAPI)
+	return ret0
+}
+
+// WorkPackages indicates an expected call of WorkPackages.
+func (mr *MockAPIMockRecorder) WorkPackages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkPackages", reflect.TypeOf((*MockAPI)(nil).WorkPackages))
+}
+
+// MockAuthenticationAPI is a mock of AuthenticationAPI interface.
+type MockAuthenticationAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticationAPIMockRecorder
+}
+
+// MockAuthenticationAPIMockRecorder is the mock recorder for MockAuthenticationAPI.
+type MockAuthenticationAPIMockRecorder struct {
+	mock *MockAuthenticationAPI
+}
+
+// NewMockAuthenticationAPI creates a new mock instance.
+func NewMockAuthenticationAPI(ctrl *gomock.Controller) *MockAuthenticationAPI {
+	mock := &MockAuthenticationAPI{ctrl: ctrl}
+	mock.recorder = &MockAuthenticationAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticationAPI) EXPECT() *MockAuthenticationAPIMockRecorder {
+	return m.recorder
+}
+
+// AcquireSessionCookie mocks base method.
+func (m *MockAuthenticationAPI) AcquireSessionCookie(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireSessionCookie", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireSessionCookie indicates an expected call of AcquireSessionCookie.
+func (mr *MockAuthenticationAPIMockRecorder) AcquireSessionCookie(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireSessionCookie", reflect.TypeOf((*MockAuthenticationAPI)(nil).AcquireSessionCookie), arg0, arg1)
+}
+
+// AcquireSessionCookieWithContext mocks base method.
+func (m *MockAuthenticationAPI) AcquireSessionCookieWithContext(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireSessionCookieWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireSessionCookieWithContext indicates an expected call of AcquireSessionCookieWithContext.
+func (mr *MockAuthenticationAPIMockRecorder) AcquireSessionCookieWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireSessionCookieWithContext", reflect.TypeOf((*MockAuthenticationAPI)(nil).AcquireSessionCookieWithContext), arg0, arg1, arg2)
+}
+
+// Authenticated mocks base method.
+func (m *MockAuthenticationAPI) Authenticated() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticated")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Authenticated indicates an expected call of Authenticated.
+func (mr *MockAuthenticationAPIMockRecorder) Authenticated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticated", reflect.TypeOf((*MockAuthenticationAPI)(nil).Authenticated))
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockAuthenticationAPI) GetCurrentUser() (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser")
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockAuthenticationAPIMockRecorder) GetCurrentUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockAuthenticationAPI)(nil).GetCurrentUser))
+}
+
+// GetCurrentUserWithContext mocks base method.
+func (m *MockAuthenticationAPI) GetCurrentUserWithContext(arg0 context.Context) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUserWithContext", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCurrentUserWithContext indicates an expected call of GetCurrentUserWithContext.
+func (mr *MockAuthenticationAPIMockRecorder) GetCurrentUserWithContext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUserWithContext", reflect.TypeOf((*MockAuthenticationAPI)(nil).GetCurrentUserWithContext), arg0)
+}
+
+// Logout mocks base method.
+func (m *MockAuthenticationAPI) Logout() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthenticationAPIMockRecorder) Logout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthenticationAPI)(nil).Logout))
+}
+
+// LogoutWithContext mocks base method.
+func (m *MockAuthenticationAPI) LogoutWithContext(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutWithContext", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutWithContext indicates an expected call of LogoutWithContext.
+func (mr *MockAuthenticationAPIMockRecorder) LogoutWithContext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutWithContext", reflect.TypeOf((*MockAuthenticationAPI)(nil).LogoutWithContext), arg0)
+}
+
+// SetAPIKey mocks base method.
+func (m *MockAuthenticationAPI) SetAPIKey(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAPIKey", arg0)
+}
+
+// SetAPIKey indicates an expected call of SetAPIKey.
+func (mr *MockAuthenticationAPIMockRecorder) SetAPIKey(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIKey", reflect.TypeOf((*MockAuthenticationAPI)(nil).SetAPIKey), arg0)
+}
+
+// SetBasicAuth mocks base method.
+func (m *MockAuthenticationAPI) SetBasicAuth(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBasicAuth", arg0, arg1)
+}
+
+// SetBasicAuth indicates an expected call of SetBasicAuth.
+func (mr *MockAuthenticationAPIMockRecorder) SetBasicAuth(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBasicAuth", reflect.TypeOf((*MockAuthenticationAPI)(nil).SetBasicAuth), arg0, arg1)
+}
+
+// MockWorkPackageAPI is a mock of WorkPackageAPI interface.
+type MockWorkPackageAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkPackageAPIMockRecorder
+}
+
+// MockWorkPackageAPIMockRecorder is the mock recorder for MockWorkPackageAPI.
+type MockWorkPackageAPIMockRecorder struct {
+	mock *MockWorkPackageAPI
+}
+
+// NewMockWorkPackageAPI creates a new mock instance.
+func NewMockWorkPackageAPI(ctrl *gomock.Controller) *MockWorkPackageAPI {
+	mock := &MockWorkPackageAPI{ctrl: ctrl}
+	mock.recorder = &MockWorkPackageAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkPackageAPI) EXPECT() *MockWorkPackageAPIMockRecorder {
+	return m.recorder
+}
+
+// BulkCopy mocks base method.
+func (m *MockWorkPackageAPI) BulkCopy(arg0 []string, arg1 string, arg2 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCopy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCopy indicates an expected call of BulkCopy.
+func (mr *MockWorkPackageAPIMockRecorder) BulkCopy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCopy", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkCopy), arg0, arg1, arg2)
+}
+
+// BulkCopyWithContext mocks base method.
+func (m *MockWorkPackageAPI) BulkCopyWithContext(arg0 context.Context, arg1 []string, arg2 string, arg3 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCopyWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCopyWithContext indicates an expected call of BulkCopyWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) BulkCopyWithContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCopyWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkCopyWithContext), arg0, arg1, arg2, arg3)
+}
+
+// BulkCreate mocks base method.
+func (m *MockWorkPackageAPI) BulkCreate(arg0 []*openproject.WorkPackage, arg1 string, arg2 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockWorkPackageAPIMockRecorder) BulkCreate(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkCreate), arg0, arg1, arg2)
+}
+
+// BulkCreateWithContext mocks base method.
+func (m *MockWorkPackageAPI) BulkCreateWithContext(arg0 context.Context, arg1 []*openproject.WorkPackage, arg2 string, arg3 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateWithContext indicates an expected call of BulkCreateWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) BulkCreateWithContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkCreateWithContext), arg0, arg1, arg2, arg3)
+}
+
+// BulkDelete mocks base method.
+func (m *MockWorkPackageAPI) BulkDelete(arg0 []string, arg1 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockWorkPackageAPIMockRecorder) BulkDelete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkDelete), arg0, arg1)
+}
+
+// BulkDeleteWithContext mocks base method.
+func (m *MockWorkPackageAPI) BulkDeleteWithContext(arg0 context.Context, arg1 []string, arg2 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteWithContext indicates an expected call of BulkDeleteWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) BulkDeleteWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkDeleteWithContext), arg0, arg1, arg2)
+}
+
+// BulkMove mocks base method.
+func (m *MockWorkPackageAPI) BulkMove(arg0 []string, arg1 string, arg2 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkMove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkMove indicates an expected call of BulkMove.
+func (mr *MockWorkPackageAPIMockRecorder) BulkMove(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMove", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkMove), arg0, arg1, arg2)
+}
+
+// BulkMoveWithContext mocks base method.
+func (m *MockWorkPackageAPI) BulkMoveWithContext(arg0 context.Context, arg1 []string, arg2 string, arg3 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkMoveWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkMoveWithContext indicates an expected call of BulkMoveWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) BulkMoveWithContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMoveWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkMoveWithContext), arg0, arg1, arg2, arg3)
+}
+
+// BulkUpdate mocks base method.
+func (m *MockWorkPackageAPI) BulkUpdate(arg0 []openproject.BulkUpdateItem, arg1 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdate indicates an expected call of BulkUpdate.
+func (mr *MockWorkPackageAPIMockRecorder) BulkUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkUpdate), arg0, arg1)
+}
+
+// BulkUpdateWithContext mocks base method.
+func (m *MockWorkPackageAPI) BulkUpdateWithContext(arg0 context.Context, arg1 []openproject.BulkUpdateItem, arg2 *openproject.BulkOptions) (*openproject.BulkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.BulkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateWithContext indicates an expected call of BulkUpdateWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) BulkUpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).BulkUpdateWithContext), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockWorkPackageAPI) Create(arg0 *openproject.WorkPackage, arg1 string) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkPackageAPIMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkPackageAPI)(nil).Create), arg0, arg1)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockWorkPackageAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.WorkPackage, arg2 string) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) CreateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).CreateWithContext), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockWorkPackageAPI) Delete(arg0 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkPackageAPIMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkPackageAPI)(nil).Delete), arg0)
+}
+
+// DeleteWithContext mocks base method.
+func (m *MockWorkPackageAPI) DeleteWithContext(arg0 context.Context, arg1 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithContext indicates an expected call of DeleteWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) DeleteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).DeleteWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockWorkPackageAPI) Get(arg0 string) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWorkPackageAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWorkPackageAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockWorkPackageAPI) GetList(arg0 *openproject.FilterOptions) ([]openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].([]openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockWorkPackageAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockWorkPackageAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockWorkPackageAPI) GetListWithContext(arg0 context.Context, arg1 *openproject.FilterOptions) ([]openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].([]openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockWorkPackageAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockWorkPackageAPI) Update(arg0 string, arg1 *openproject.WorkPackage) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkPackageAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkPackageAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockWorkPackageAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.WorkPackage) (*openproject.WorkPackage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.WorkPackage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockWorkPackageAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockWorkPackageAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
+
+// MockProjectAPI is a mock of ProjectAPI interface.
+type MockProjectAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectAPIMockRecorder
+}
+
+// MockProjectAPIMockRecorder is the mock recorder for MockProjectAPI.
+type MockProjectAPIMockRecorder struct {
+	mock *MockProjectAPI
+}
+
+// NewMockProjectAPI creates a new mock instance.
+func NewMockProjectAPI(ctrl *gomock.Controller) *MockProjectAPI {
+	mock := &MockProjectAPI{ctrl: ctrl}
+	mock.recorder = &MockProjectAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectAPI) EXPECT() *MockProjectAPIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProjectAPI) Create(arg0 *openproject.Project) (*openproject.Project, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*openproject.Project)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProjectAPIMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProjectAPI)(nil).Create), arg0)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockProjectAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.Project) (*openproject.Project, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Project)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockProjectAPIMockRecorder) CreateWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockProjectAPI)(nil).CreateWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockProjectAPI) Get(arg0 string) (*openproject.Project, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Project)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProjectAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProjectAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockProjectAPI) GetList() (*openproject.SearchResultProject, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList")
+	ret0, _ := ret[0].(*openproject.SearchResultProject)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockProjectAPIMockRecorder) GetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockProjectAPI)(nil).GetList))
+}
+
+// GetListWithContext mocks base method.
+func (m *MockProjectAPI) GetListWithContext(arg0 context.Context) (*openproject.SearchResultProject, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultProject)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockProjectAPIMockRecorder) GetListWithContext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockProjectAPI)(nil).GetListWithContext), arg0)
+}
+
+// GetWithContext mocks base method.
+func (m *MockProjectAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Project, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Project)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockProjectAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockProjectAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// MockUserAPI is a mock of UserAPI interface.
+type MockUserAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAPIMockRecorder
+}
+
+// MockUserAPIMockRecorder is the mock recorder for MockUserAPI.
+type MockUserAPIMockRecorder struct {
+	mock *MockUserAPI
+}
+
+// NewMockUserAPI creates a new mock instance.
+func NewMockUserAPI(ctrl *gomock.Controller) *MockUserAPI {
+	mock := &MockUserAPI{ctrl: ctrl}
+	mock.recorder = &MockUserAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAPI) EXPECT() *MockUserAPIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserAPI) Create(arg0 *openproject.User) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserAPIMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserAPI)(nil).Create), arg0)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockUserAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.User) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockUserAPIMockRecorder) CreateWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockUserAPI)(nil).CreateWithContext), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockUserAPI) Delete(arg0 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserAPIMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserAPI)(nil).Delete), arg0)
+}
+
+// DeleteWithContext mocks base method.
+func (m *MockUserAPI) DeleteWithContext(arg0 context.Context, arg1 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithContext indicates an expected call of DeleteWithContext.
+func (mr *MockUserAPIMockRecorder) DeleteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithContext", reflect.TypeOf((*MockUserAPI)(nil).DeleteWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockUserAPI) Get(arg0 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockUserAPI) GetList(arg0 *openproject.FilterOptions) (*openproject.SearchResultUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockUserAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockUserAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockUserAPI) GetListWithContext(arg0 context.Context, arg1 *openproject.FilterOptions) (*openproject.SearchResultUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockUserAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockUserAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockUserAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockUserAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockUserAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// Invite mocks base method.
+func (m *MockUserAPI) Invite(arg0 *openproject.User) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockUserAPIMockRecorder) Invite(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockUserAPI)(nil).Invite), arg0)
+}
+
+// InviteWithContext mocks base method.
+func (m *MockUserAPI) InviteWithContext(arg0 context.Context, arg1 *openproject.User) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InviteWithContext indicates an expected call of InviteWithContext.
+func (mr *MockUserAPIMockRecorder) InviteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteWithContext", reflect.TypeOf((*MockUserAPI)(nil).InviteWithContext), arg0, arg1)
+}
+
+// Lock mocks base method.
+func (m *MockUserAPI) Lock(arg0 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockUserAPIMockRecorder) Lock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockUserAPI)(nil).Lock), arg0)
+}
+
+// LockWithContext mocks base method.
+func (m *MockUserAPI) LockWithContext(arg0 context.Context, arg1 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LockWithContext indicates an expected call of LockWithContext.
+func (mr *MockUserAPIMockRecorder) LockWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWithContext", reflect.TypeOf((*MockUserAPI)(nil).LockWithContext), arg0, arg1)
+}
+
+// Unlock mocks base method.
+func (m *MockUserAPI) Unlock(arg0 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", arg0)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockUserAPIMockRecorder) Unlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockUserAPI)(nil).Unlock), arg0)
+}
+
+// UnlockWithContext mocks base method.
+func (m *MockUserAPI) UnlockWithContext(arg0 context.Context, arg1 string) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UnlockWithContext indicates an expected call of UnlockWithContext.
+func (mr *MockUserAPIMockRecorder) UnlockWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockWithContext", reflect.TypeOf((*MockUserAPI)(nil).UnlockWithContext), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockUserAPI) Update(arg0 string, arg1 *openproject.UserUpdate) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockUserAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.UserUpdate) (*openproject.User, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.User)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockUserAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockUserAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
+
+// MockStatusAPI is a mock of StatusAPI interface.
+type MockStatusAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusAPIMockRecorder
+}
+
+// MockStatusAPIMockRecorder is the mock recorder for MockStatusAPI.
+type MockStatusAPIMockRecorder struct {
+	mock *MockStatusAPI
+}
+
+// NewMockStatusAPI creates a new mock instance.
+func NewMockStatusAPI(ctrl *gomock.Controller) *MockStatusAPI {
+	mock := &MockStatusAPI{ctrl: ctrl}
+	mock.recorder = &MockStatusAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusAPI) EXPECT() *MockStatusAPIMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStatusAPI) Get(arg0 string) (*openproject.Status, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Status)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStatusAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStatusAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockStatusAPI) GetList() (*openproject.SearchResultStatus, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList")
+	ret0, _ := ret[0].(*openproject.SearchResultStatus)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockStatusAPIMockRecorder) GetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockStatusAPI)(nil).GetList))
+}
+
+// GetListWithContext mocks base method.
+func (m *MockStatusAPI) GetListWithContext(arg0 context.Context) (*openproject.SearchResultStatus, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultStatus)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockStatusAPIMockRecorder) GetListWithContext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockStatusAPI)(nil).GetListWithContext), arg0)
+}
+
+// GetWithContext mocks base method.
+func (m *MockStatusAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Status, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Status)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockStatusAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockStatusAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// MockWikiPageAPI is a mock of WikiPageAPI interface.
+type MockWikiPageAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWikiPageAPIMockRecorder
+}
+
+// MockWikiPageAPIMockRecorder is the mock recorder for MockWikiPageAPI.
+type MockWikiPageAPIMockRecorder struct {
+	mock *MockWikiPageAPI
+}
+
+// NewMockWikiPageAPI creates a new mock instance.
+func NewMockWikiPageAPI(ctrl *gomock.Controller) *MockWikiPageAPI {
+	mock := &MockWikiPageAPI{ctrl: ctrl}
+	mock.recorder = &MockWikiPageAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWikiPageAPI) EXPECT() *MockWikiPageAPIMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockWikiPageAPI) AddAttachment(arg0, arg1 string, arg2 []byte) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockWikiPageAPIMockRecorder) AddAttachment(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockWikiPageAPI)(nil).AddAttachment), arg0, arg1, arg2)
+}
+
+// AddAttachmentWithContext mocks base method.
+func (m *MockWikiPageAPI) AddAttachmentWithContext(arg0 context.Context, arg1, arg2 string, arg3 []byte) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachmentWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddAttachmentWithContext indicates an expected call of AddAttachmentWithContext.
+func (mr *MockWikiPageAPIMockRecorder) AddAttachmentWithContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachmentWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).AddAttachmentWithContext), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockWikiPageAPI) Create(arg0 string, arg1 *openproject.WikiPage) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWikiPageAPIMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWikiPageAPI)(nil).Create), arg0, arg1)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockWikiPageAPI) CreateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.WikiPage) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockWikiPageAPIMockRecorder) CreateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).CreateWithContext), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockWikiPageAPI) Get(arg0 string) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWikiPageAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWikiPageAPI)(nil).Get), arg0)
+}
+
+// GetAttachments mocks base method.
+func (m *MockWikiPageAPI) GetAttachments(arg0 string) (*openproject.SearchResultAttachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultAttachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockWikiPageAPIMockRecorder) GetAttachments(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockWikiPageAPI)(nil).GetAttachments), arg0)
+}
+
+// GetAttachmentsWithContext mocks base method.
+func (m *MockWikiPageAPI) GetAttachmentsWithContext(arg0 context.Context, arg1 string) (*openproject.SearchResultAttachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultAttachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachmentsWithContext indicates an expected call of GetAttachmentsWithContext.
+func (mr *MockWikiPageAPIMockRecorder) GetAttachmentsWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).GetAttachmentsWithContext), arg0, arg1)
+}
+
+// GetList mocks base method.
+func (m *MockWikiPageAPI) GetList(arg0 string) (*openproject.SearchResultWikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultWikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockWikiPageAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockWikiPageAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockWikiPageAPI) GetListWithContext(arg0 context.Context, arg1 string) (*openproject.SearchResultWikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultWikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockWikiPageAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockWikiPageAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockWikiPageAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockWikiPageAPI) Update(arg0 string, arg1 *openproject.WikiPage) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWikiPageAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWikiPageAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockWikiPageAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.WikiPage) (*openproject.WikiPage, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.WikiPage)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockWikiPageAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockWikiPageAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
+
+// MockAttachmentAPI is a mock of AttachmentAPI interface.
+type MockAttachmentAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentAPIMockRecorder
+}
+
+// MockAttachmentAPIMockRecorder is the mock recorder for MockAttachmentAPI.
+type MockAttachmentAPIMockRecorder struct {
+	mock *MockAttachmentAPI
+}
+
+// NewMockAttachmentAPI creates a new mock instance.
+func NewMockAttachmentAPI(ctrl *gomock.Controller) *MockAttachmentAPI {
+	mock := &MockAttachmentAPI{ctrl: ctrl}
+	mock.recorder = &MockAttachmentAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentAPI) EXPECT() *MockAttachmentAPIMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockAttachmentAPI) Download(arg0 string) (*[]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", arg0)
+	ret0, _ := ret[0].(*[]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockAttachmentAPIMockRecorder) Download(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachmentAPI)(nil).Download), arg0)
+}
+
+// DownloadWithContext mocks base method.
+func (m *MockAttachmentAPI) DownloadWithContext(arg0 context.Context, arg1 string) (*[]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*[]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadWithContext indicates an expected call of DownloadWithContext.
+func (mr *MockAttachmentAPIMockRecorder) DownloadWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadWithContext", reflect.TypeOf((*MockAttachmentAPI)(nil).DownloadWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAttachmentAPI) Get(arg0 string) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAttachmentAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAttachmentAPI)(nil).Get), arg0)
+}
+
+// GetWithContext mocks base method.
+func (m *MockAttachmentAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockAttachmentAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockAttachmentAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// Upload mocks base method.
+func (m *MockAttachmentAPI) Upload(arg0, arg1 string, arg2 []byte) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentAPIMockRecorder) Upload(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachmentAPI)(nil).Upload), arg0, arg1, arg2)
+}
+
+// UploadWithContext mocks base method.
+func (m *MockAttachmentAPI) UploadWithContext(arg0 context.Context, arg1, arg2 string, arg3 []byte) (*openproject.Attachment, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*openproject.Attachment)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadWithContext indicates an expected call of UploadWithContext.
+func (mr *MockAttachmentAPIMockRecorder) UploadWithContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadWithContext", reflect.TypeOf((*MockAttachmentAPI)(nil).UploadWithContext), arg0, arg1, arg2, arg3)
+}
+
+// MockCategoryAPI is a mock of CategoryAPI interface.
+type MockCategoryAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryAPIMockRecorder
+}
+
+// MockCategoryAPIMockRecorder is the mock recorder for MockCategoryAPI.
+type MockCategoryAPIMockRecorder struct {
+	mock *MockCategoryAPI
+}
+
+// NewMockCategoryAPI creates a new mock instance.
+func NewMockCategoryAPI(ctrl *gomock.Controller) *MockCategoryAPI {
+	mock := &MockCategoryAPI{ctrl: ctrl}
+	mock.recorder = &MockCategoryAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryAPI) EXPECT() *MockCategoryAPIMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCategoryAPI) Get(arg0 string) (*openproject.Category, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Category)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCategoryAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCategoryAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockCategoryAPI) GetList(arg0 string) (*openproject.CategoryList, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.CategoryList)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockCategoryAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCategoryAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockCategoryAPI) GetListWithContext(arg0 context.Context, arg1 string) (*openproject.CategoryList, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.CategoryList)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockCategoryAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockCategoryAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockCategoryAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Category, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Category)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockCategoryAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockCategoryAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// MockQueryAPI is a mock of QueryAPI interface.
+type MockQueryAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryAPIMockRecorder
+}
+
+// MockQueryAPIMockRecorder is the mock recorder for MockQueryAPI.
+type MockQueryAPIMockRecorder struct {
+	mock *MockQueryAPI
+}
+
+// NewMockQueryAPI creates a new mock instance.
+func NewMockQueryAPI(ctrl *gomock.Controller) *MockQueryAPI {
+	mock := &MockQueryAPI{ctrl: ctrl}
+	mock.recorder = &MockQueryAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryAPI) EXPECT() *MockQueryAPIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQueryAPI) Create(arg0 *openproject.QueryPayload) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQueryAPIMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQueryAPI)(nil).Create), arg0)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockQueryAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.QueryPayload) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockQueryAPIMockRecorder) CreateWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockQueryAPI)(nil).CreateWithContext), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockQueryAPI) Delete(arg0 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockQueryAPIMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQueryAPI)(nil).Delete), arg0)
+}
+
+// DeleteWithContext mocks base method.
+func (m *MockQueryAPI) DeleteWithContext(arg0 context.Context, arg1 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithContext indicates an expected call of DeleteWithContext.
+func (mr *MockQueryAPIMockRecorder) DeleteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithContext", reflect.TypeOf((*MockQueryAPI)(nil).DeleteWithContext), arg0, arg1)
+}
+
+// Form mocks base method.
+func (m *MockQueryAPI) Form(arg0 *openproject.QueryPayload) (*openproject.QueryForm, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Form", arg0)
+	ret0, _ := ret[0].(*openproject.QueryForm)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Form indicates an expected call of Form.
+func (mr *MockQueryAPIMockRecorder) Form(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Form", reflect.TypeOf((*MockQueryAPI)(nil).Form), arg0)
+}
+
+// FormWithContext mocks base method.
+func (m *MockQueryAPI) FormWithContext(arg0 context.Context, arg1 *openproject.QueryPayload) (*openproject.QueryForm, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.QueryForm)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FormWithContext indicates an expected call of FormWithContext.
+func (mr *MockQueryAPIMockRecorder) FormWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormWithContext", reflect.TypeOf((*MockQueryAPI)(nil).FormWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockQueryAPI) Get(arg0 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockQueryAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQueryAPI)(nil).Get), arg0)
+}
+
+// GetDefault mocks base method.
+func (m *MockQueryAPI) GetDefault(arg0 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefault", arg0)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDefault indicates an expected call of GetDefault.
+func (mr *MockQueryAPIMockRecorder) GetDefault(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefault", reflect.TypeOf((*MockQueryAPI)(nil).GetDefault), arg0)
+}
+
+// GetDefaultWithContext mocks base method.
+func (m *MockQueryAPI) GetDefaultWithContext(arg0 context.Context, arg1 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDefaultWithContext indicates an expected call of GetDefaultWithContext.
+func (mr *MockQueryAPIMockRecorder) GetDefaultWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetDefaultWithContext), arg0, arg1)
+}
+
+// GetFilterInstanceSchema mocks base method.
+func (m *MockQueryAPI) GetFilterInstanceSchema(arg0 string) (*openproject.QueryFilterInstanceSchema, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilterInstanceSchema", arg0)
+	ret0, _ := ret[0].(*openproject.QueryFilterInstanceSchema)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFilterInstanceSchema indicates an expected call of GetFilterInstanceSchema.
+func (mr *MockQueryAPIMockRecorder) GetFilterInstanceSchema(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilterInstanceSchema", reflect.TypeOf((*MockQueryAPI)(nil).GetFilterInstanceSchema), arg0)
+}
+
+// GetFilterInstanceSchemaWithContext mocks base method.
+func (m *MockQueryAPI) GetFilterInstanceSchemaWithContext(arg0 context.Context, arg1 string) (*openproject.QueryFilterInstanceSchema, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilterInstanceSchemaWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.QueryFilterInstanceSchema)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFilterInstanceSchemaWithContext indicates an expected call of GetFilterInstanceSchemaWithContext.
+func (mr *MockQueryAPIMockRecorder) GetFilterInstanceSchemaWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilterInstanceSchemaWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetFilterInstanceSchemaWithContext), arg0, arg1)
+}
+
+// GetFilterInstanceSchemas mocks base method.
+func (m *MockQueryAPI) GetFilterInstanceSchemas(arg0 string) (*openproject.SearchResultQueryFilterInstanceSchema, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilterInstanceSchemas", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultQueryFilterInstanceSchema)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFilterInstanceSchemas indicates an expected call of GetFilterInstanceSchemas.
+func (mr *MockQueryAPIMockRecorder) GetFilterInstanceSchemas(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilterInstanceSchemas", reflect.TypeOf((*MockQueryAPI)(nil).GetFilterInstanceSchemas), arg0)
+}
+
+// GetFilterInstanceSchemasWithContext mocks base method.
+func (m *MockQueryAPI) GetFilterInstanceSchemasWithContext(arg0 context.Context, arg1 string) (*openproject.SearchResultQueryFilterInstanceSchema, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilterInstanceSchemasWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultQueryFilterInstanceSchema)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFilterInstanceSchemasWithContext indicates an expected call of GetFilterInstanceSchemasWithContext.
+func (mr *MockQueryAPIMockRecorder) GetFilterInstanceSchemasWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilterInstanceSchemasWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetFilterInstanceSchemasWithContext), arg0, arg1)
+}
+
+// GetList mocks base method.
+func (m *MockQueryAPI) GetList() (*openproject.SearchResultQuery, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList")
+	ret0, _ := ret[0].(*openproject.SearchResultQuery)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockQueryAPIMockRecorder) GetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockQueryAPI)(nil).GetList))
+}
+
+// GetListWithContext mocks base method.
+func (m *MockQueryAPI) GetListWithContext(arg0 context.Context) (*openproject.SearchResultQuery, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultQuery)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockQueryAPIMockRecorder) GetListWithContext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetListWithContext), arg0)
+}
+
+// GetResults mocks base method.
+func (m *MockQueryAPI) GetResults(arg0 string, arg1 *openproject.QueryResultsOptions) (*openproject.SearchResultWP, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResults", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultWP)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetResults indicates an expected call of GetResults.
+func (mr *MockQueryAPIMockRecorder) GetResults(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResults", reflect.TypeOf((*MockQueryAPI)(nil).GetResults), arg0, arg1)
+}
+
+// GetResultsWithContext mocks base method.
+func (m *MockQueryAPI) GetResultsWithContext(arg0 context.Context, arg1 string, arg2 *openproject.QueryResultsOptions) (*openproject.SearchResultWP, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultsWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.SearchResultWP)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetResultsWithContext indicates an expected call of GetResultsWithContext.
+func (mr *MockQueryAPIMockRecorder) GetResultsWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultsWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetResultsWithContext), arg0, arg1, arg2)
+}
+
+// GetWithContext mocks base method.
+func (m *MockQueryAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockQueryAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockQueryAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// Star mocks base method.
+func (m *MockQueryAPI) Star(arg0 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Star", arg0)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Star indicates an expected call of Star.
+func (mr *MockQueryAPIMockRecorder) Star(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Star", reflect.TypeOf((*MockQueryAPI)(nil).Star), arg0)
+}
+
+// StarWithContext mocks base method.
+func (m *MockQueryAPI) StarWithContext(arg0 context.Context, arg1 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StarWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StarWithContext indicates an expected call of StarWithContext.
+func (mr *MockQueryAPIMockRecorder) StarWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarWithContext", reflect.TypeOf((*MockQueryAPI)(nil).StarWithContext), arg0, arg1)
+}
+
+// Unstar mocks base method.
+func (m *MockQueryAPI) Unstar(arg0 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unstar", arg0)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Unstar indicates an expected call of Unstar.
+func (mr *MockQueryAPIMockRecorder) Unstar(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unstar", reflect.TypeOf((*MockQueryAPI)(nil).Unstar), arg0)
+}
+
+// UnstarWithContext mocks base method.
+func (m *MockQueryAPI) UnstarWithContext(arg0 context.Context, arg1 string) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnstarWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UnstarWithContext indicates an expected call of UnstarWithContext.
+func (mr *MockQueryAPIMockRecorder) UnstarWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarWithContext", reflect.TypeOf((*MockQueryAPI)(nil).UnstarWithContext), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockQueryAPI) Update(arg0 string, arg1 *openproject.QueryPayload) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQueryAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQueryAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockQueryAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.QueryPayload) (*openproject.Query, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.Query)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockQueryAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockQueryAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
+
+// MockGroupAPI is a mock of GroupAPI interface.
+type MockGroupAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupAPIMockRecorder
+}
+
+// MockGroupAPIMockRecorder is the mock recorder for MockGroupAPI.
+type MockGroupAPIMockRecorder struct {
+	mock *MockGroupAPI
+}
+
+// NewMockGroupAPI creates a new mock instance.
+func NewMockGroupAPI(ctrl *gomock.Controller) *MockGroupAPI {
+	mock := &MockGroupAPI{ctrl: ctrl}
+	mock.recorder = &MockGroupAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupAPI) EXPECT() *MockGroupAPIMockRecorder {
+	return m.recorder
+}
+
+// AddMembers mocks base method.
+func (m *MockGroupAPI) AddMembers(arg0 string, arg1 ...string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddMembers", varargs...)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockGroupAPIMockRecorder) AddMembers(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockGroupAPI)(nil).AddMembers), varargs...)
+}
+
+// AddMembersWithContext mocks base method.
+func (m *MockGroupAPI) AddMembersWithContext(arg0 context.Context, arg1 string, arg2 ...string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddMembersWithContext", varargs...)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddMembersWithContext indicates an expected call of AddMembersWithContext.
+func (mr *MockGroupAPIMockRecorder) AddMembersWithContext(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembersWithContext", reflect.TypeOf((*MockGroupAPI)(nil).AddMembersWithContext), varargs...)
+}
+
+// Create mocks base method.
+func (m *MockGroupAPI) Create(arg0 *openproject.Group) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupAPIMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupAPI)(nil).Create), arg0)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockGroupAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.Group) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockGroupAPIMockRecorder) CreateWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockGroupAPI)(nil).CreateWithContext), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockGroupAPI) Delete(arg0 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGroupAPIMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGroupAPI)(nil).Delete), arg0)
+}
+
+// DeleteWithContext mocks base method.
+func (m *MockGroupAPI) DeleteWithContext(arg0 context.Context, arg1 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithContext indicates an expected call of DeleteWithContext.
+func (mr *MockGroupAPIMockRecorder) DeleteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithContext", reflect.TypeOf((*MockGroupAPI)(nil).DeleteWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockGroupAPI) Get(arg0 string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGroupAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGroupAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockGroupAPI) GetList(arg0 *openproject.FilterOptions) (*openproject.SearchResultGroup, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultGroup)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockGroupAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockGroupAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockGroupAPI) GetListWithContext(arg0 context.Context, arg1 *openproject.FilterOptions) (*openproject.SearchResultGroup, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultGroup)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockGroupAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockGroupAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockGroupAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockGroupAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockGroupAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// RemoveMembers mocks base method.
+func (m *MockGroupAPI) RemoveMembers(arg0 string, arg1 ...string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMembers", varargs...)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoveMembers indicates an expected call of RemoveMembers.
+func (mr *MockGroupAPIMockRecorder) RemoveMembers(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMembers", reflect.TypeOf((*MockGroupAPI)(nil).RemoveMembers), varargs...)
+}
+
+// RemoveMembersWithContext mocks base method.
+func (m *MockGroupAPI) RemoveMembersWithContext(arg0 context.Context, arg1 string, arg2 ...string) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMembersWithContext", varargs...)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoveMembersWithContext indicates an expected call of RemoveMembersWithContext.
+func (mr *MockGroupAPIMockRecorder) RemoveMembersWithContext(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMembersWithContext", reflect.TypeOf((*MockGroupAPI)(nil).RemoveMembersWithContext), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockGroupAPI) Update(arg0 string, arg1 *openproject.Group) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGroupAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockGroupAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.Group) (*openproject.Group, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.Group)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockGroupAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockGroupAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
+
+// MockPrincipalAPI is a mock of PrincipalAPI interface.
+type MockPrincipalAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrincipalAPIMockRecorder
+}
+
+// MockPrincipalAPIMockRecorder is the mock recorder for MockPrincipalAPI.
+type MockPrincipalAPIMockRecorder struct {
+	mock *MockPrincipalAPI
+}
+
+// NewMockPrincipalAPI creates a new mock instance.
+func NewMockPrincipalAPI(ctrl *gomock.Controller) *MockPrincipalAPI {
+	mock := &MockPrincipalAPI{ctrl: ctrl}
+	mock.recorder = &MockPrincipalAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrincipalAPI) EXPECT() *MockPrincipalAPIMockRecorder {
+	return m.recorder
+}
+
+// GetList mocks base method.
+func (m *MockPrincipalAPI) GetList(arg0 *openproject.FilterOptions) (*openproject.SearchResultPrincipal, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultPrincipal)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockPrincipalAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockPrincipalAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockPrincipalAPI) GetListWithContext(arg0 context.Context, arg1 *openproject.FilterOptions) (*openproject.SearchResultPrincipal, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultPrincipal)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockPrincipalAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockPrincipalAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// Resolve mocks base method.
+func (m *MockPrincipalAPI) Resolve(arg0 string) (*openproject.Principal, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0)
+	ret0, _ := ret[0].(*openproject.Principal)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockPrincipalAPIMockRecorder) Resolve(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockPrincipalAPI)(nil).Resolve), arg0)
+}
+
+// ResolveWithContext mocks base method.
+func (m *MockPrincipalAPI) ResolveWithContext(arg0 context.Context, arg1 string) (*openproject.Principal, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Principal)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveWithContext indicates an expected call of ResolveWithContext.
+func (mr *MockPrincipalAPIMockRecorder) ResolveWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveWithContext", reflect.TypeOf((*MockPrincipalAPI)(nil).ResolveWithContext), arg0, arg1)
+}
+
+// MockPlaceholderUserAPI is a mock of PlaceholderUserAPI interface.
+type MockPlaceholderUserAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaceholderUserAPIMockRecorder
+}
+
+// MockPlaceholderUserAPIMockRecorder is the mock recorder for MockPlaceholderUserAPI.
+type MockPlaceholderUserAPIMockRecorder struct {
+	mock *MockPlaceholderUserAPI
+}
+
+// NewMockPlaceholderUserAPI creates a new mock instance.
+func NewMockPlaceholderUserAPI(ctrl *gomock.Controller) *MockPlaceholderUserAPI {
+	mock := &MockPlaceholderUserAPI{ctrl: ctrl}
+	mock.recorder = &MockPlaceholderUserAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaceholderUserAPI) EXPECT() *MockPlaceholderUserAPIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPlaceholderUserAPI) Create(arg0 *openproject.PlaceholderUser) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlaceholderUserAPIMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).Create), arg0)
+}
+
+// CreateWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) CreateWithContext(arg0 context.Context, arg1 *openproject.PlaceholderUser) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithContext indicates an expected call of CreateWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) CreateWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).CreateWithContext), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPlaceholderUserAPI) Delete(arg0 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlaceholderUserAPIMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).Delete), arg0)
+}
+
+// DeleteWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) DeleteWithContext(arg0 context.Context, arg1 string) (*openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithContext indicates an expected call of DeleteWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) DeleteWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).DeleteWithContext), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockPlaceholderUserAPI) Get(arg0 string) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPlaceholderUserAPIMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).Get), arg0)
+}
+
+// GetList mocks base method.
+func (m *MockPlaceholderUserAPI) GetList(arg0 *openproject.FilterOptions) (*openproject.SearchResultPlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0)
+	ret0, _ := ret[0].(*openproject.SearchResultPlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockPlaceholderUserAPIMockRecorder) GetList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).GetList), arg0)
+}
+
+// GetListWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) GetListWithContext(arg0 context.Context, arg1 *openproject.FilterOptions) (*openproject.SearchResultPlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.SearchResultPlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithContext indicates an expected call of GetListWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) GetListWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).GetListWithContext), arg0, arg1)
+}
+
+// GetWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) GetWithContext(arg0 context.Context, arg1 string) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithContext indicates an expected call of GetWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) GetWithContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).GetWithContext), arg0, arg1)
+}
+
+// ReassignWorkPackages mocks base method.
+func (m *MockPlaceholderUserAPI) ReassignWorkPackages(arg0, arg1 string) ([]openproject.WorkPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignWorkPackages", arg0, arg1)
+	ret0, _ := ret[0].([]openproject.WorkPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignWorkPackages indicates an expected call of ReassignWorkPackages.
+func (mr *MockPlaceholderUserAPIMockRecorder) ReassignWorkPackages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignWorkPackages", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).ReassignWorkPackages), arg0, arg1)
+}
+
+// ReassignWorkPackagesWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) ReassignWorkPackagesWithContext(arg0 context.Context, arg1, arg2 string) ([]openproject.WorkPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignWorkPackagesWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]openproject.WorkPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignWorkPackagesWithContext indicates an expected call of ReassignWorkPackagesWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) ReassignWorkPackagesWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignWorkPackagesWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).ReassignWorkPackagesWithContext), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockPlaceholderUserAPI) Update(arg0 string, arg1 *openproject.PlaceholderUser) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPlaceholderUserAPIMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).Update), arg0, arg1)
+}
+
+// UpdateWithContext mocks base method.
+func (m *MockPlaceholderUserAPI) UpdateWithContext(arg0 context.Context, arg1 string, arg2 *openproject.PlaceholderUser) (*openproject.PlaceholderUser, *openproject.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*openproject.PlaceholderUser)
+	ret1, _ := ret[1].(*openproject.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWithContext indicates an expected call of UpdateWithContext.
+func (mr *MockPlaceholderUserAPIMockRecorder) UpdateWithContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithContext", reflect.TypeOf((*MockPlaceholderUserAPI)(nil).UpdateWithContext), arg0, arg1, arg2)
+}
@@ -0,0 +1,38 @@
+package openprojectmock
+
+import (
+	"errors"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// subjectOf is the kind of consumer code the mocks are meant for
+func subjectOf(api openproject.API, id string) (string, error) {
+	wp, _, err := api.WorkPackages().Get(id)
+	if err != nil {
+		return "", err
+	}
+	return wp.Subject, nil
+}
+
+func TestMockAPI_WorkPackages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	workPackages := NewMockWorkPackageAPI(ctrl)
+	api := NewMockAPI(ctrl)
+	api.EXPECT().WorkPackages().Return(workPackages).Times(2)
+	workPackages.EXPECT().Get("36353").Return(&openproject.WorkPackage{Subject: "Stubbed"}, nil, nil)
+	workPackages.EXPECT().Get("1").Return(nil, nil, &openproject.Error{StatusCode: 404, Identifier: openproject.ErrNotFound})
+
+	subject, err := subjectOf(api, "36353")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if subject != "Stubbed" {
+		t.Errorf("Expected subject Stubbed, got %s", subject)
+	}
+	if _, err := subjectOf(api, "1"); !errors.Is(err, openproject.ErrNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,501 @@
+package openprojecttest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInteractionNotFound is returned when replaying a request the cassette has no interaction for.
+// Requests sent through an openproject client fail with an error matching it through errors.Is
+var ErrInteractionNotFound = errors.New("no interaction recorded for the request")
+
+// RecorderMode tells whether a Recorder replays or records interactions
+type RecorderMode int
+
+const (
+	// ModeReplay replays the interactions of an existing cassette and never sends requests. It is the default
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends every request and records the interactions, replacing the cassette when stopped
+	ModeRecord
+	// ModeRecordOnce replays the cassette if it exists, and records it otherwise
+	ModeRecordOnce
+)
+
+// MatchMode tells how replayed requests are matched with the recorded interactions
+type MatchMode int
+
+const (
+	// MatchStrict replays the interactions in the order they were recorded. Each request must have the method,
+	// the path, the query and the body of the next interaction. It is the default
+	MatchStrict MatchMode = iota
+	// MatchLenient replays the first unplayed interaction with the method, the path and the query of a request,
+	// whatever its body and its order. Once all of them are played, the last one is replayed again
+	MatchLenient
+)
+
+// cassetteVersion is the version of the cassette format
+const cassetteVersion = 1
+
+// redactedValue replaces redacted headers and fields
+const redactedValue = "REDACTED"
+
+// Credentials redacted from every cassette
+var (
+	cassetteRedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+	cassetteRedactFields  = []string{"password", "apikey", "api_key", "key", "token", "access_token", "refresh_token",
+		"client_secret", "code", "code_verifier", "authenticity_token"}
+)
+
+// emailPattern matches email addresses, percent-encoded ones included (i.e. within links).
+// A percent-encoded character before the address is matched apart, so it is not taken as part of it
+var emailPattern = regexp.MustCompile(`(%[0-9A-Fa-f]{2})?([A-Za-z0-9._+-]+)(@|%40)([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// Cassette holds the interactions recorded by a Recorder, stored as JSON
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it got
+type Interaction struct {
+	Request    CassetteRequest  `json:"request"`
+	Response   CassetteResponse `json:"response"`
+	RecordedAt time.Time        `json:"recordedAt"`
+}
+
+// CassetteRequest is a recorded request
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// Binary bodies are encoded in base64
+	Binary bool `json:"binary,omitempty"`
+}
+
+// CassetteResponse is a recorded response
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	// Binary bodies are encoded in base64
+	Binary bool `json:"binary,omitempty"`
+}
+
+// RecorderOptions configures a Recorder
+type RecorderOptions struct {
+	Mode  RecorderMode
+	Match MatchMode
+	// Transport sends the requests being recorded, http.DefaultTransport if nil
+	Transport http.RoundTripper
+	// RedactHeaders are redacted in addition to Authorization, Cookie, Set-Cookie and the like
+	RedactHeaders []string
+	// RedactFields are the JSON fields, form fields and query parameters redacted in addition to the ones
+	// carrying credentials, i.e. "password", "apikey" or "access_token"
+	RedactFields []string
+}
+
+// Recorder is an http.RoundTripper recording the interactions with a real instance into a cassette file,
+// to replay them later without network, i.e. in CI:
+//
+//	recorder, err := openprojecttest.NewRecorder("testdata/work-packages.json", &openprojecttest.RecorderOptions{
+//		Mode:      openprojecttest.ModeRecordOnce,
+//		Transport: &openproject.APIKeyTransport{APIKey: os.Getenv("OPENPROJECT_API_KEY")},
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//	client, _ := openproject.NewClient(recorder.Client(), "https://staging.openproject.example.com")
+//
+// Credentials and email addresses are redacted before being written. Email addresses are replaced by
+// a pseudonym derived from them, so requests about the same address still match when replayed.
+// Requests are matched by path and query, so replayed clients can use any base URL
+type Recorder struct {
+	path      string
+	options   RecorderOptions
+	recording bool
+	cassette  *Cassette
+	played    []bool
+	next      int
+	headers   map[string]bool
+	redactor  *cassetteRedactor
+	mu        sync.Mutex
+}
+
+// NewRecorder returns a recorder of the cassette at path. Replaying fails if the cassette does not exist
+func NewRecorder(path string, options *RecorderOptions) (*Recorder, error) {
+	r := &Recorder{path: path, cassette: &Cassette{Version: cassetteVersion}, headers: make(map[string]bool)}
+	if options != nil {
+		r.options = *options
+	}
+	for _, header := range append(append([]string(nil), cassetteRedactHeaders...), r.options.RedactHeaders...) {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	r.redactor = newCassetteRedactor(append(append([]string(nil), cassetteRedactFields...), r.options.RedactFields...))
+
+	raw, err := ioutil.ReadFile(path)
+	switch {
+	case r.options.Mode == ModeRecord || (r.options.Mode == ModeRecordOnce && os.IsNotExist(err)):
+		r.recording = true
+		return r, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading cassette %s failed: %s", path, err)
+	}
+	if err := json.Unmarshal(raw, r.cassette); err != nil {
+		return nil, fmt.Errorf("cassette %s is invalid: %s", path, err)
+	}
+	if r.cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf("cassette %s has version %d, version %d is supported", path, r.cassette.Version, cassetteVersion)
+	}
+	r.played = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Recording reports whether the recorder records interactions, rather than replaying them
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// RoundTrip records or replays a request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.recording {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// Client returns an *http.Client using the recorder as transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the cassette when recording. When replaying strictly, it fails if interactions were not played
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		if r.options.Match == MatchStrict && r.next < len(r.cassette.Interactions) {
+			return fmt.Errorf("cassette %s: %d of %d interactions were not played, the next one is %s",
+				r.path, len(r.cassette.Interactions)-r.next, len(r.cassette.Interactions), describe(&r.cassette.Interactions[r.next].Request))
+		}
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(raw, '\n'), 0644)
+}
+
+// record sends a request and adds the redacted interaction to the cassette
+func (r *Recorder) record(req *http.Request, recorded *CassetteRequest) (*http.Response, error) {
+	transport := r.options.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	interaction := &Interaction{Request: *recorded, RecordedAt: time.Now().UTC()}
+	interaction.Response = CassetteResponse{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     r.redactHeader(resp.Header),
+	}
+	interaction.Response.Body, interaction.Response.Binary = r.encodeBody(body, resp.Header.Get("Content-Type"))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the recorded response of a request
+func (r *Recorder) replay(req *http.Request, recorded *CassetteRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interaction, err := r.match(recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	body := []byte(interaction.Response.Body)
+	if interaction.Response.Binary {
+		if body, err = base64.StdEncoding.DecodeString(interaction.Response.Body); err != nil {
+			return nil, fmt.Errorf("cassette %s: invalid binary body: %s", r.path, err)
+		}
+	}
+	header := http.Header{}
+	for key, values := range interaction.Response.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	return &http.Response{
+		StatusCode:    interaction.Response.StatusCode,
+		Status:        interaction.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the interaction to replay for a request
+func (r *Recorder) match(recorded *CassetteRequest) (*Interaction, error) {
+	interactions := r.cassette.Interactions
+	if r.options.Match == MatchStrict {
+		if r.next >= len(interactions) {
+			return nil, fmt.Errorf("cassette %s: %w: %s, all %d interactions were played",
+				r.path, ErrInteractionNotFound, describe(recorded), len(interactions))
+		}
+		interaction := interactions[r.next]
+		if reason := mismatch(&interaction.Request, recorded, true); reason != "" {
+			return nil, fmt.Errorf("cassette %s: %w: %s does not match interaction %d %s: %s",
+				r.path, ErrInteractionNotFound, describe(recorded), r.next+1, describe(&interaction.Request), reason)
+		}
+		r.played[r.next] = true
+		r.next++
+		return interaction, nil
+	}
+
+	last := -1
+	for i, interaction := range interactions {
+		if mismatch(&interaction.Request, recorded, false) != "" {
+			continue
+		}
+		if !r.played[i] {
+			r.played[i] = true
+			return interaction, nil
+		}
+		last = i
+	}
+	if last == -1 {
+		return nil, fmt.Errorf("cassette %s: %w: %s", r.path, ErrInteractionNotFound, describe(recorded))
+	}
+	return interactions[last], nil
+}
+
+// recordRequest returns the redacted representation of a request, leaving its body readable
+func (r *Recorder) recordRequest(req *http.Request) (*CassetteRequest, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := &CassetteRequest{
+		Method: req.Method,
+		URL:    r.redactor.redactURL(req.URL),
+		Header: r.redactHeader(req.Header),
+	}
+	recorded.Body, recorded.Binary = r.encodeBody(body, req.Header.Get("Content-Type"))
+	if boundary := multipartBoundary(req.Header.Get("Content-Type")); boundary != "" {
+		// Boundaries are random, they would prevent multipart requests from matching
+		contentType := strings.Replace(req.Header.Get("Content-Type"), boundary, "BOUNDARY", 1)
+		recorded.Header.Set("Content-Type", contentType)
+		recorded.Body = strings.Replace(recorded.Body, boundary, "BOUNDARY", -1)
+	}
+	return recorded, nil
+}
+
+// redactHeader returns a copy of headers with credentials redacted
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, values := range header {
+		for _, value := range values {
+			if r.headers[http.CanonicalHeaderKey(key)] {
+				value = redactedValue
+			} else {
+				value = redactEmails(value)
+			}
+			redacted.Add(key, value)
+		}
+	}
+	return redacted
+}
+
+// encodeBody redacts a body, or encodes it in base64 if binary
+func (r *Recorder) encodeBody(body []byte, contentType string) (string, bool) {
+	if len(body) == 0 {
+		return "", false
+	}
+	if !utf8.Valid(body) {
+		return base64.StdEncoding.EncodeToString(body), true
+	}
+	return string(r.redactor.redactBody(body, contentType)), false
+}
+
+// mismatch returns why a request does not match a recorded one, empty if it does
+func mismatch(recorded, req *CassetteRequest, compareBody bool) string {
+	if recorded.Method != req.Method {
+		return fmt.Sprintf("method %s expected", recorded.Method)
+	}
+	recordedURL, errA := url.Parse(recorded.URL)
+	reqURL, errB := url.Parse(req.URL)
+	if errA != nil || errB != nil {
+		return "invalid URL"
+	}
+	if recordedURL.Path != reqURL.Path {
+		return fmt.Sprintf("path %s expected", recordedURL.Path)
+	}
+	if !reflect.DeepEqual(recordedURL.Query(), reqURL.Query()) {
+		return fmt.Sprintf("query %q expected, %q given", recordedURL.RawQuery, reqURL.RawQuery)
+	}
+	if compareBody && !equalBodies(recorded, req) {
+		return fmt.Sprintf("body %q expected, %q given", abbreviate(recorded.Body), abbreviate(req.Body))
+	}
+	return ""
+}
+
+// equalBodies compares the bodies of requests, JSON ones regardless of formatting and attribute order
+func equalBodies(a, b *CassetteRequest) bool {
+	if a.Body == b.Body {
+		return true
+	}
+	var jsonA, jsonB interface{}
+	if json.Unmarshal([]byte(a.Body), &jsonA) != nil || json.Unmarshal([]byte(b.Body), &jsonB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(jsonA, jsonB)
+}
+
+// describe returns a short description of a request, i.e. "GET /api/v3/projects"
+func describe(req *CassetteRequest) string {
+	if u, err := url.Parse(req.URL); err == nil {
+		return req.Method + " " + u.RequestURI()
+	}
+	return req.Method + " " + req.URL
+}
+
+// abbreviate shortens bodies within error messages
+func abbreviate(body string) string {
+	if len(body) > 200 {
+		return body[:200] + "..."
+	}
+	return body
+}
+
+// multipartBoundary returns the boundary of a multipart content type, empty otherwise
+func multipartBoundary(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return ""
+	}
+	return params["boundary"]
+}
+
+// redactEmails replaces email addresses by a pseudonym derived from them, i.e. "user-1a2b3c4d@example.com"
+func redactEmails(s string) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := emailPattern.FindStringSubmatch(match)
+		prefix, at, domain := parts[1], parts[3], strings.ToLower(parts[4])
+		if domain == "example.com" {
+			return match
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(parts[2]) + "@" + domain))
+		return prefix + "user-" + hex.EncodeToString(sum[:4]) + at + "example.com"
+	})
+}
+
+// cassetteRedactor redacts credentials and email addresses from URLs and bodies
+type cassetteRedactor struct {
+	fields map[string]bool
+	json   *regexp.Regexp
+	form   *regexp.Regexp
+}
+
+// newCassetteRedactor returns a redactor of the given fields, case insensitive
+func newCassetteRedactor(fields []string) *cassetteRedactor {
+	r := &cassetteRedactor{fields: make(map[string]bool)}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = true
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	names := strings.Join(quoted, "|")
+	r.json = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	r.form = regexp.MustCompile(`(?i)(^|&)((?:` + names + `)=)[^&]*`)
+	return r
+}
+
+// redactURL returns a URL with the redacted query parameters and email addresses
+func (r *cassetteRedactor) redactURL(u *url.URL) string {
+	u2 := *u
+	u2.User = nil
+	if u.RawQuery != "" {
+		u2.RawQuery = r.redactValues(u.Query()).Encode()
+	}
+	return u2.String()
+}
+
+// redactValues redacts the fields and email addresses of query or form values
+func (r *cassetteRedactor) redactValues(values url.Values) url.Values {
+	for key, vals := range values {
+		for i, value := range vals {
+			if r.fields[strings.ToLower(key)] {
+				vals[i] = redactedValue
+			} else {
+				vals[i] = redactEmails(value)
+			}
+		}
+	}
+	return values
+}
+
+// redactBody redacts the fields and email addresses of a body. Fields are redacted from JSON and form bodies only
+func (r *cassetteRedactor) redactBody(body []byte, contentType string) []byte {
+	trimmed := bytes.TrimSpace(body)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['):
+		body = r.json.ReplaceAll(body, []byte(`${1}"`+redactedValue+`"`))
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return []byte(r.redactValues(values).Encode())
+		}
+		body = r.form.ReplaceAll(body, []byte(`${1}${2}`+redactedValue))
+	}
+	return []byte(redactEmails(string(body)))
+}
@@ -0,0 +1,172 @@
+package openprojecttest
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// recordCassette records a few interactions with a fake server into a cassette and returns its path
+func recordCassette(t *testing.T) string {
+	server := NewServer()
+	defer server.Close()
+	server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+
+	path := filepath.Join(t.TempDir(), "cassettes", "users.json")
+	recorder, err := NewRecorder(path, &RecorderOptions{Mode: ModeRecordOnce})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !recorder.Recording() {
+		t.Fatal("Expected to record a missing cassette")
+	}
+	transport := &openproject.APIKeyTransport{APIKey: "s3cr3t-key", Transport: recorder}
+	client, _ := openproject.NewClient(transport.Client(), server.URL)
+
+	if _, _, err := client.User.Create(&openproject.User{Login: "jdoe", FirstName: "John", LastName: "Doe",
+		Email: "john.doe@corp.io", Password: "p4ssw0rd"}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.User.GetList(&openproject.FilterOptions{
+		Fields: []openproject.OptionsFields{{Field: "email", Operator: openproject.Equal, Value: "john.doe@corp.io"}},
+	}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.Project.Get("demo"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return path
+}
+
+func TestRecorder_Record(t *testing.T) {
+	path := recordCassette(t)
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	cassette := string(raw)
+	for _, secret := range []string{"s3cr3t-key", "p4ssw0rd", "john.doe@corp.io", "john.doe%40corp.io", "YXBpa2V5"} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("Expected %q to be redacted from the cassette:\n%s", secret, cassette)
+		}
+	}
+	if !strings.Contains(cassette, `"Authorization": [`) || !strings.Contains(cassette, redactedValue) {
+		t.Errorf("Expected the authorization to be redacted, got:\n%s", cassette)
+	}
+}
+
+func TestRecorder_ReplayStrict(t *testing.T) {
+	path := recordCassette(t)
+
+	recorder, err := NewRecorder(path, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	// No server is listening there, every response comes from the cassette
+	client, _ := openproject.NewClient(recorder.Client(), "http://openproject.invalid")
+
+	user, _, err := client.User.Create(&openproject.User{Login: "jdoe", FirstName: "John", LastName: "Doe",
+		Email: "john.doe@corp.io", Password: "other password"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if user.Login != "jdoe" || user.Email == "john.doe@corp.io" {
+		t.Errorf("Unexpected user replayed: %+v", user)
+	}
+
+	// Out of order
+	_, _, err = client.Project.Get("demo")
+	if !errors.Is(err, ErrInteractionNotFound) || !strings.Contains(err.Error(), "GET /api/v3/users?filters=") {
+		t.Errorf("Expected the request not to match the next interaction, got %v", err)
+	}
+
+	if _, _, err := client.User.GetList(&openproject.FilterOptions{
+		Fields: []openproject.OptionsFields{{Field: "email", Operator: openproject.Equal, Value: "john.doe@corp.io"}},
+	}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if err := recorder.Stop(); err == nil || !strings.Contains(err.Error(), "1 of 3 interactions were not played") {
+		t.Errorf("Expected an unplayed interaction, got %v", err)
+	}
+	if _, _, err := client.Project.Get("demo"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestRecorder_ReplayLenient(t *testing.T) {
+	path := recordCassette(t)
+
+	recorder, err := NewRecorder(path, &RecorderOptions{Match: MatchLenient})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	client, _ := openproject.NewClient(recorder.Client(), "http://openproject.invalid")
+
+	for i := 0; i < 2; i++ {
+		project, _, err := client.Project.Get("demo")
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if project.Identifier != "demo" {
+			t.Errorf("Unexpected project replayed: %+v", project)
+		}
+	}
+	if _, _, err := client.User.Create(&openproject.User{Login: "someone-else"}); err != nil {
+		t.Errorf("Expected the body to be ignored, got %v", err)
+	}
+
+	_, _, err = client.Status.Get("1")
+	var opErr *openproject.Error
+	if !errors.Is(err, ErrInteractionNotFound) || !errors.As(err, &opErr) || opErr.StatusCode != 0 {
+		t.Errorf("Expected a missing interaction, got %v", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), &RecorderOptions{Mode: ModeReplay})
+	if err == nil || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("Expected the cassette to be missing, got %v", err)
+	}
+}
+
+func TestRecorder_Binary(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddAttachment(0, "image.png", []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0x01})
+
+	path := filepath.Join(t.TempDir(), "binary.json")
+	recorder, _ := NewRecorder(path, &RecorderOptions{Mode: ModeRecord})
+	client, _ := openproject.NewClient(recorder.Client(), server.URL)
+	recorded, err := client.Attachment.Download("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	replayer, _ := NewRecorder(path, nil)
+	client, _ = openproject.NewClient(&http.Client{Transport: replayer}, "http://openproject.invalid")
+	replayed, err := client.Attachment.Download("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if string(*replayed) != string(*recorded) {
+		t.Errorf("Expected %v, got %v", *recorded, *replayed)
+	}
+}
@@ -0,0 +1,47 @@
+package openprojecttest
+
+import (
+	"fmt"
+	"net/http"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// writeError writes a HAL error, i.e. {"_type": "Error", "errorIdentifier": "...", "message": "..."}
+func writeError(w http.ResponseWriter, status int, id openproject.ErrorIdentifier, message string) {
+	writeJSON(w, status, &openproject.Error{Type: "Error", Identifier: id, Message: message})
+}
+
+// writeNotFound writes the error of a missing resource or endpoint
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, openproject.ErrNotFound, "The requested resource could not be found.")
+}
+
+// writeMethodNotAllowed writes the error of a method not supported by an endpoint
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, errNotAllowed, fmt.Sprintf("The %s method is not allowed for this resource.", r.Method))
+}
+
+// writeViolations writes the error of a resource violating constraints. Several violations are
+// rendered as MultipleErrors embedding a PropertyConstraintViolation each
+func writeViolations(w http.ResponseWriter, violations []violation) {
+	errs := make([]*openproject.Error, 0, len(violations))
+	for _, v := range violations {
+		errs = append(errs, &openproject.Error{
+			Type:       "Error",
+			Identifier: openproject.ErrPropertyConstraintViolation,
+			Message:    v.message,
+			Embedded:   openproject.ErrorEmbedded{Details: &openproject.ErrorDetails{Attribute: v.attribute}},
+		})
+	}
+	if len(errs) == 1 {
+		writeJSON(w, http.StatusUnprocessableEntity, errs[0])
+		return
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, &openproject.Error{
+		Type:       "Error",
+		Identifier: openproject.ErrMultipleErrors,
+		Message:    "Multiple field constraints have been violated.",
+		Embedded:   openproject.ErrorEmbedded{Errors: errs},
+	})
+}
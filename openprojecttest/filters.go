@@ -0,0 +1,285 @@
+package openprojecttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Paging defaults of OpenProject
+const (
+	defaultPageSize = 20
+	maxPageSize     = 1000
+)
+
+// filter is a single filter of the "filters" parameter, i.e. {"status": {"operator": "o", "values": null}}
+type filter struct {
+	field    string
+	operator string
+	values   []string
+}
+
+// sortCriterion is a single criterion of the "sortBy" parameter, i.e. ["updatedAt", "desc"]
+type sortCriterion struct {
+	field      string
+	descending bool
+}
+
+// operatorValues is the number of values taken by the supported operators, -1 for one or more
+var operatorValues = map[string]int{
+	"=": -1, "!": -1, "<>": -1, "~": 1, "!~": 1, "**": 1,
+	">": 1, "<": 1, ">=": 1, "<=": 1,
+	"*": 0, "!*": 0, "o": 0, "c": 0,
+	"=d": 1, "<>d": 2, "t": 0, "t-": 1, ">t-": 1, "<t-": 1,
+}
+
+// parseFilters parses the "filters" parameter of a request to a collection
+func parseFilters(raw string, name string) ([]filter, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var parsed []map[string]struct {
+		Operator string   `json:"operator"`
+		Values   []string `json:"values"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, fmt.Errorf("filters could not be parsed: %s", err)
+	}
+
+	filters := make([]filter, 0, len(parsed))
+	for _, item := range parsed {
+		for field, f := range item {
+			if !kinds[name].fields[field] {
+				return nil, fmt.Errorf("filter %s does not exist", field)
+			}
+			count, ok := operatorValues[f.Operator]
+			if !ok || ((f.Operator == "o" || f.Operator == "c") && (field != "status" || name != workPackages)) {
+				return nil, fmt.Errorf("operator %q is not allowed for filter %s", f.Operator, field)
+			}
+			if (count == -1 && len(f.Values) == 0) || (count > 0 && len(f.Values) != count) {
+				return nil, fmt.Errorf("filter %s has invalid values", field)
+			}
+			values := make([]string, len(f.Values))
+			for i, value := range f.Values {
+				values[i] = normalizeValue(value)
+			}
+			filters = append(filters, filter{field: field, operator: f.Operator, values: values})
+		}
+	}
+	return filters, nil
+}
+
+// parseSortBy parses the "sortBy" parameter of a request to a collection. Resources are sorted by ID by default
+func parseSortBy(raw string, name string) ([]sortCriterion, error) {
+	criteria := make([]sortCriterion, 0)
+	if raw != "" {
+		var parsed [][]string
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+			return nil, fmt.Errorf("sort criteria could not be parsed: %s", err)
+		}
+		for _, criterion := range parsed {
+			if len(criterion) != 2 || !kinds[name].fields[criterion[0]] || (criterion[1] != "asc" && criterion[1] != "desc") {
+				return nil, fmt.Errorf("sorting by %v is not supported", criterion)
+			}
+			criteria = append(criteria, sortCriterion{field: criterion[0], descending: criterion[1] == "desc"})
+		}
+	}
+	return append(criteria, sortCriterion{field: "id"}), nil
+}
+
+// parsePage parses the "offset" (page number from 1) and "pageSize" parameters of a request to a collection
+func parsePage(query url.Values) (int, int, error) {
+	page, pageSize := 1, defaultPageSize
+	if raw := query.Get("offset"); raw != "" {
+		var err error
+		if page, err = strconv.Atoi(raw); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("offset must be a positive integer, %q given", raw)
+		}
+	}
+	if raw := query.Get("pageSize"); raw != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(raw); err != nil || pageSize < 0 {
+			return 0, 0, fmt.Errorf("page size must be a non-negative integer, %q given", raw)
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize, nil
+}
+
+// normalizeValue converts filter values to the representation of attributes, i.e. booleans to "t" and "f"
+func normalizeValue(value string) string {
+	switch value {
+	case "true":
+		return "t"
+	case "false":
+		return "f"
+	}
+	return value
+}
+
+// attribute returns the value of an attribute of a resource as string. Links give the ID they point to
+// and formattable texts their raw text
+func attribute(res resource, field string) (string, bool) {
+	if value, ok := res[field]; ok {
+		if text, ok := value.(map[string]interface{}); ok {
+			value = text["raw"]
+		}
+		s := stringValue(value)
+		return s, s != ""
+	}
+	href := linkHref(res, field)
+	if href == "" {
+		return "", false
+	}
+	return href[strings.LastIndex(href, "/")+1:], true
+}
+
+// match reports whether a resource matches every filter
+func (s *Server) match(res resource, filters []filter) bool {
+	for _, f := range filters {
+		if !s.matchFilter(res, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFilter reports whether a resource matches a filter
+func (s *Server) matchFilter(res resource, f filter) bool {
+	value, present := attribute(res, f.field)
+	switch f.operator {
+	case "=":
+		return present && containsFold(f.values, value)
+	case "!", "<>":
+		return !present || !containsFold(f.values, value)
+	case "~", "**":
+		return present && strings.Contains(strings.ToLower(value), strings.ToLower(f.values[0]))
+	case "!~":
+		return !present || !strings.Contains(strings.ToLower(value), strings.ToLower(f.values[0]))
+	case ">":
+		return present && compare(value, f.values[0]) > 0
+	case ">=":
+		return present && compare(value, f.values[0]) >= 0
+	case "<":
+		return present && compare(value, f.values[0]) < 0
+	case "<=":
+		return present && compare(value, f.values[0]) <= 0
+	case "*":
+		return present
+	case "!*":
+		return !present
+	case "o", "c":
+		status, _ := s.resolve(linkHref(res, "status"))
+		closed := status != nil && status["isClosed"] == true
+		return closed == (f.operator == "c")
+	}
+	return present && s.matchDate(value, f)
+}
+
+// matchDate reports whether a date or a time matches a date filter
+func (s *Server) matchDate(value string, f filter) bool {
+	t, ok := parseTime(value)
+	if !ok {
+		return false
+	}
+	today := startOfDay(s.now())
+	daysAgo := func() (time.Time, bool) {
+		days, err := strconv.Atoi(f.values[0])
+		return today.AddDate(0, 0, -days), err == nil
+	}
+	switch f.operator {
+	case "=d":
+		day, ok := parseTime(f.values[0])
+		return ok && startOfDay(t).Equal(startOfDay(day))
+	case "<>d":
+		if from, ok := parseTime(f.values[0]); ok && t.Before(from) {
+			return false
+		}
+		if to, ok := parseTime(f.values[1]); ok {
+			if len(f.values[1]) == len("2006-01-02") {
+				// A date includes the whole day
+				to = to.AddDate(0, 0, 1)
+				return t.Before(to)
+			}
+			return !t.After(to)
+		}
+		return true
+	case "t":
+		return startOfDay(t).Equal(today)
+	case "t-":
+		day, ok := daysAgo()
+		return ok && startOfDay(t).Equal(day)
+	case ">t-":
+		day, ok := daysAgo()
+		return ok && !t.Before(day)
+	case "<t-":
+		day, ok := daysAgo()
+		return ok && t.Before(day)
+	}
+	return false
+}
+
+// less reports whether a resource comes before another according to sort criteria
+func less(a, b resource, criteria []sortCriterion) bool {
+	for _, criterion := range criteria {
+		valueA, _ := attribute(a, criterion.field)
+		valueB, _ := attribute(b, criterion.field)
+		c := compare(valueA, valueB)
+		if c == 0 {
+			continue
+		}
+		if criterion.descending {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+// compare compares values numerically if both are numbers, as case insensitive strings otherwise.
+// Dates and times are rendered so that they compare as strings
+func compare(a, b string) int {
+	numberA, errA := strconv.ParseFloat(a, 64)
+	numberB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// containsFold reports whether values contain a value, case insensitive
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTime parses a time or a date, UTC if no zone is given
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// startOfDay returns the beginning of the UTC day of a time
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
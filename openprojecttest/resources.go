@@ -0,0 +1,446 @@
+package openprojecttest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// Error identifiers of OpenProject not provided by the openproject package
+const (
+	errInvalidQuery       openproject.ErrorIdentifier = "urn:openproject-org:api:v3:errors:InvalidQuery"
+	errInvalidRequestBody openproject.ErrorIdentifier = "urn:openproject-org:api:v3:errors:InvalidRequestBody"
+	errNotAllowed         openproject.ErrorIdentifier = "urn:openproject-org:api:v3:errors:NotAllowed"
+)
+
+// timeLayout is the layout of the times rendered by the server, the one parsed by openproject.Time
+const timeLayout = "2006-01-02T15:04:05Z"
+
+// identifierPattern matches valid project identifiers
+var identifierPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// identifierSeparators are the characters of project names replaced when deriving identifiers
+var identifierSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// violation is a property constraint violated by a resource
+type violation struct {
+	attribute string
+	message   string
+}
+
+// kind describes the behavior of a collection
+type kind struct {
+	halType string
+	// listable collections can be listed at top level, i.e. api/v3/projects
+	listable  bool
+	creatable bool
+	updatable bool
+	deletable bool
+	// fields are the attributes and links resources can be filtered and sorted by
+	fields map[string]bool
+	// defaults completes a resource before it is validated, on creation and on update
+	defaults func(s *Server, res resource)
+	// validate returns the violated constraints of a resource, id is 0 for a new resource
+	validate func(s *Server, res resource, id int) []violation
+	// decorate completes the rendering of a resource, i.e. with action links
+	decorate func(s *Server, rendered resource)
+}
+
+// kinds are the collections served by the fake server
+var kinds map[string]*kind
+
+func init() {
+	kinds = map[string]*kind{
+		projects: {
+			halType:   "Project",
+			listable:  true,
+			creatable: true,
+			updatable: true,
+			deletable: true,
+			fields:    fieldSet("id", "name", "identifier", "active", "public", "status", "createdAt", "updatedAt"),
+			defaults:  projectDefaults,
+			validate:  validateProject,
+			decorate:  decorateProject,
+		},
+		workPackages: {
+			halType:   "WorkPackage",
+			listable:  true,
+			creatable: true,
+			updatable: true,
+			deletable: true,
+			fields: fieldSet("id", "subject", "description", "status", "project", "type", "priority", "assignee",
+				"responsible", "startDate", "dueDate", "createdAt", "updatedAt"),
+			defaults: workPackageDefaults,
+			validate: validateWorkPackage,
+			decorate: decorateWorkPackage,
+		},
+		users: {
+			halType:   "User",
+			listable:  true,
+			creatable: true,
+			updatable: true,
+			deletable: true,
+			fields:    fieldSet("id", "login", "name", "firstName", "lastName", "email", "status", "admin", "createdAt", "updatedAt"),
+			defaults:  userDefaults,
+			validate:  validateUser,
+			decorate:  decorateUser,
+		},
+		statuses: {
+			halType:  "Status",
+			listable: true,
+			fields:   fieldSet("id", "name", "isClosed", "isDefault", "isReadOnly", "position"),
+			defaults: func(*Server, resource) {},
+			validate: func(*Server, resource, int) []violation { return nil },
+			decorate: func(*Server, resource) {},
+		},
+		attachments: {
+			halType:   "Attachment",
+			creatable: true,
+			deletable: true,
+			fields:    fieldSet("id", "fileName", "fileSize", "contentType", "createdAt"),
+			defaults:  func(*Server, resource) {},
+			validate:  func(*Server, resource, int) []violation { return nil },
+			decorate:  decorateAttachment,
+		},
+	}
+}
+
+// projectDefaults derives the identifier from the name and makes projects active unless told otherwise
+func projectDefaults(s *Server, res resource) {
+	if stringValue(res["identifier"]) == "" && stringValue(res["name"]) != "" {
+		identifier := strings.Trim(identifierSeparators.ReplaceAllString(strings.ToLower(stringValue(res["name"])), "-"), "-")
+		if identifier != "" && (identifier[0] < 'a' || identifier[0] > 'z') {
+			identifier = "p-" + identifier
+		}
+		res["identifier"] = identifier
+	}
+	if _, ok := res["active"]; !ok {
+		res["active"] = true
+	}
+	if _, ok := res["public"]; !ok {
+		res["public"] = false
+	}
+}
+
+// validateProject checks the name and the identifier of a project
+func validateProject(s *Server, res resource, id int) []violation {
+	var violations []violation
+	if strings.TrimSpace(stringValue(res["name"])) == "" {
+		violations = append(violations, violation{"name", "Name can't be blank."})
+	}
+	identifier := stringValue(res["identifier"])
+	switch {
+	case identifier == "":
+		violations = append(violations, violation{"identifier", "Identifier can't be blank."})
+	case !identifierPattern.MatchString(identifier):
+		violations = append(violations, violation{"identifier", "Identifier is invalid."})
+	default:
+		for otherID, other := range s.stores[projects].items {
+			if otherID != id && other["identifier"] == identifier {
+				violations = append(violations, violation{"identifier", "Identifier has already been taken."})
+			}
+		}
+	}
+	return violations
+}
+
+// decorateProject adds the links to the work-packages of a project
+func decorateProject(s *Server, rendered resource) {
+	links(rendered)["workPackages"] = map[string]interface{}{
+		"href": fmt.Sprintf("%s/%s", selfHref(projects, rendered["id"].(int)), workPackages),
+	}
+	renderFormattable(rendered, "description")
+}
+
+// workPackageDefaults sets the lock version and the default status of a work-package
+func workPackageDefaults(s *Server, res resource) {
+	delete(res, "Custom")
+	res["lockVersion"] = intValue(res["lockVersion"])
+	if linkHref(res, "status") == "" {
+		for _, status := range s.stores[statuses].items {
+			if status["isDefault"] == true {
+				links(res)["status"] = link(statuses, status)
+				break
+			}
+		}
+	}
+}
+
+// validateWorkPackage checks the subject, the links and the dates of a work-package
+func validateWorkPackage(s *Server, res resource, id int) []violation {
+	var violations []violation
+	if strings.TrimSpace(stringValue(res["subject"])) == "" {
+		violations = append(violations, violation{"subject", "Subject can't be blank."})
+	}
+	if _, ok := s.resolve(linkHref(res, "project")); !ok {
+		violations = append(violations, violation{"project", "Project can't be blank."})
+	}
+	for _, rel := range []string{"status", "assignee", "responsible"} {
+		if href := linkHref(res, rel); href != "" {
+			if _, ok := s.resolve(href); !ok {
+				violations = append(violations, violation{rel, fmt.Sprintf("%s is invalid.", strings.ToUpper(rel[:1])+rel[1:])})
+			}
+		}
+	}
+	startDate, dueDate := stringValue(res["startDate"]), stringValue(res["dueDate"])
+	if startDate != "" && dueDate != "" && dueDate < startDate {
+		violations = append(violations, violation{"dueDate", "Finish date must be greater than or equal to start date."})
+	}
+	return violations
+}
+
+// decorateWorkPackage titles the links of a work-package and adds the links to its attachments
+func decorateWorkPackage(s *Server, rendered resource) {
+	l := links(rendered)
+	for _, rel := range []string{"project", "status", "assignee", "responsible"} {
+		if target, ok := s.resolve(linkHref(rendered, rel)); ok {
+			l[rel] = map[string]interface{}{"href": linkHref(rendered, rel), "title": title(target)}
+		}
+	}
+	attachmentsHref := fmt.Sprintf("%s/%s", selfHref(workPackages, rendered["id"].(int)), attachments)
+	l["attachments"] = map[string]interface{}{"href": attachmentsHref}
+	l["addAttachment"] = map[string]interface{}{"href": attachmentsHref, "method": "post"}
+	renderFormattable(rendered, "description")
+}
+
+// userDefaults sets the status, the login of invited users and the name of a user. Passwords are never kept
+func userDefaults(s *Server, res resource) {
+	delete(res, "password")
+	if stringValue(res["status"]) == "" {
+		res["status"] = openproject.UserStatusActive
+	}
+	if stringValue(res["login"]) == "" && res["status"] == openproject.UserStatusInvited {
+		res["login"] = res["email"]
+	}
+	res["name"] = strings.TrimSpace(stringValue(res["firstName"]) + " " + stringValue(res["lastName"]))
+}
+
+// validateUser checks the mandatory attributes, the uniqueness of login and email and the status of a user
+func validateUser(s *Server, res resource, id int) []violation {
+	var violations []violation
+	status := stringValue(res["status"])
+	required := []violation{{"login", "Username can't be blank."}, {"email", "Email can't be blank."}}
+	if status != openproject.UserStatusInvited {
+		required = append(required, violation{"firstName", "First name can't be blank."}, violation{"lastName", "Last name can't be blank."})
+	}
+	for _, v := range required {
+		if strings.TrimSpace(stringValue(res[v.attribute])) == "" {
+			violations = append(violations, v)
+		}
+	}
+	for otherID, other := range s.stores[users].items {
+		if otherID == id {
+			continue
+		}
+		if login := stringValue(res["login"]); login != "" && strings.EqualFold(stringValue(other["login"]), login) {
+			violations = append(violations, violation{"login", "Username has already been taken."})
+		}
+		if email := stringValue(res["email"]); email != "" && strings.EqualFold(stringValue(other["email"]), email) {
+			violations = append(violations, violation{"email", "Email has already been taken."})
+		}
+	}
+	switch status {
+	case openproject.UserStatusActive, openproject.UserStatusRegistered, openproject.UserStatusLocked, openproject.UserStatusInvited:
+	default:
+		violations = append(violations, violation{"status", "Status is not set to one of the allowed values."})
+	}
+	return violations
+}
+
+// decorateUser adds the action links of a user
+func decorateUser(s *Server, rendered resource) {
+	l := links(rendered)
+	self := selfHref(users, rendered["id"].(int))
+	l["updateImmediately"] = map[string]interface{}{"href": self, "method": "patch"}
+	l["delete"] = map[string]interface{}{"href": self, "method": "delete"}
+	if rendered["status"] == openproject.UserStatusLocked {
+		l["unlock"] = map[string]interface{}{"href": self + "/lock", "method": "delete"}
+	} else {
+		l["lock"] = map[string]interface{}{"href": self + "/lock", "method": "post"}
+	}
+}
+
+// decorateAttachment adds the download links of an attachment
+func decorateAttachment(s *Server, rendered resource) {
+	l := links(rendered)
+	content := map[string]interface{}{"href": selfHref(attachments, rendered["id"].(int)) + "/content"}
+	l["downloadLocation"] = content
+	l["staticDownloadLocation"] = content
+	if container, ok := s.resolve(linkHref(rendered, "container")); ok {
+		l["container"] = map[string]interface{}{"href": linkHref(rendered, "container"), "title": title(container)}
+	}
+	renderFormattable(rendered, "description")
+}
+
+// resolve returns the resource a link points to, i.e. "/api/v3/statuses/1"
+func (s *Server) resolve(href string) (resource, bool) {
+	segments := strings.Split(strings.TrimPrefix(href, apiPrefix), "/")
+	if href == "" || len(segments) != 2 {
+		return nil, false
+	}
+	if _, ok := kinds[segments[0]]; !ok {
+		return nil, false
+	}
+	return s.find(segments[0], segments[1])
+}
+
+// fieldSet returns a set of fields
+func fieldSet(fields ...string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return set
+}
+
+// selfHref returns the path of a resource, i.e. "/api/v3/projects/1"
+func selfHref(name string, id int) string {
+	return fmt.Sprintf("%s%s/%d", apiPrefix, name, id)
+}
+
+// link returns a HAL link to a resource
+func link(name string, res resource) map[string]interface{} {
+	l := map[string]interface{}{"href": selfHref(name, intValue(res["id"]))}
+	if t := title(res); t != "" {
+		l["title"] = t
+	}
+	return l
+}
+
+// title returns the title of the links to a resource
+func title(res resource) string {
+	for _, field := range []string{"name", "subject", "fileName"} {
+		if t := stringValue(res[field]); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// links returns the links of a resource, adding them if missing
+func links(res resource) map[string]interface{} {
+	l, ok := res["_links"].(map[string]interface{})
+	if !ok {
+		l = make(map[string]interface{})
+		res["_links"] = l
+	}
+	return l
+}
+
+// linkHref returns the target of a link of a resource, empty if not set
+func linkHref(res resource, rel string) string {
+	l, _ := res["_links"].(map[string]interface{})
+	target, _ := l[rel].(map[string]interface{})
+	return stringValue(target["href"])
+}
+
+// formattable returns a formattable text, as descriptions are rendered
+func formattable(raw string) map[string]interface{} {
+	return map[string]interface{}{"format": "markdown", "raw": raw, "html": renderHTML(raw)}
+}
+
+// renderFormattable renders the HTML of a formattable attribute
+func renderFormattable(rendered resource, field string) {
+	switch value := rendered[field].(type) {
+	case map[string]interface{}:
+		rendered[field] = formattable(stringValue(value["raw"]))
+	case string:
+		rendered[field] = formattable(value)
+	}
+}
+
+// renderHTML renders a raw text as HTML paragraph
+func renderHTML(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	return "<p>" + html.EscapeString(raw) + "</p>"
+}
+
+// stringValue returns the string representation of an attribute, empty for null
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "t"
+		}
+		return "f"
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// intValue returns the integer value of a numeric attribute, 0 otherwise
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// formatTime renders a time as the API does
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// md5Hex returns the digest of an attachment
+func md5Hex(content []byte) string {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// copyResource returns a deep copy of a resource, keeping the type of its values
+func copyResource(res resource) resource {
+	return resource(copyValue(map[string]interface{}(res)).(map[string]interface{}))
+}
+
+// copyValue returns a deep copy of a JSON value
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case resource:
+		return copyValue(map[string]interface{}(v))
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			c[key] = copyValue(item)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = copyValue(item)
+		}
+		return c
+	}
+	return value
+}
+
+// decode converts a value into another through its JSON representation
+func decode(from interface{}, to interface{}) {
+	raw, err := json.Marshal(from)
+	if err != nil {
+		panic(fmt.Sprintf("openprojecttest: %s", err))
+	}
+	if err := json.Unmarshal(raw, to); err != nil {
+		panic(fmt.Sprintf("openprojecttest: %s", err))
+	}
+}
@@ -0,0 +1,675 @@
+// Package openprojecttest provides a fake OpenProject server to test code using the openproject client
+// without a real instance. The server keeps its state in memory: projects, work-packages, users, statuses
+// and attachments can be created, updated, filtered, paginated and deleted through the API v3 and are
+// rendered as HAL resources, work-packages can be validated through their forms, failing requests get
+// the HAL errors of OpenProject:
+//
+//	server := openprojecttest.NewServer()
+//	defer server.Close()
+//	project := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+//	server.AddStatus(&openproject.Status{Name: "New", IsDefault: true})
+//
+//	client := server.Client()
+//	wp, _, err := client.WorkPackage.Create(&openproject.WorkPackage{Subject: "Test"}, project.Identifier)
+//
+// The server accepts every request, whatever its credentials.
+package openprojecttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+// apiPrefix is the path of the API v3 within the server
+const apiPrefix = "/api/v3/"
+
+// halContentType is the content type of every API response
+const halContentType = "application/hal+json; charset=utf-8"
+
+// Collections of the API v3 served by the fake server
+const (
+	projects     = "projects"
+	workPackages = "work_packages"
+	users        = "users"
+	statuses     = "statuses"
+	attachments  = "attachments"
+)
+
+// resource is a HAL resource as rendered by the server, i.e. {"_type": "Project", "id": 1, "_links": {...}}
+type resource map[string]interface{}
+
+// store holds the resources of a collection
+type store struct {
+	nextID int
+	items  map[int]resource
+}
+
+// Server is a fake OpenProject instance serving the API v3 over HTTP. It is safe for concurrent use
+type Server struct {
+	// URL is the base URL of the server, i.e. "http://127.0.0.1:51234"
+	URL string
+
+	server        *httptest.Server
+	mu            sync.Mutex
+	stores        map[string]*store
+	contents      map[int][]byte
+	currentUserID int
+	now           func() time.Time
+}
+
+// NewServer starts a fake OpenProject server without any resource. Close it once done
+func NewServer() *Server {
+	s := &Server{
+		stores:   make(map[string]*store),
+		contents: make(map[int][]byte),
+		now:      time.Now,
+	}
+	for name := range kinds {
+		s.stores[name] = &store{nextID: 1, items: make(map[int]resource)}
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an OpenProject client connected to the server
+func (s *Server) Client() *openproject.Client {
+	client, err := openproject.NewClient(s.server.Client(), s.URL)
+	if err != nil {
+		panic(fmt.Sprintf("openprojecttest: %s", err))
+	}
+	return client
+}
+
+// SetClock sets the function giving the time of the server, used for createdAt and updatedAt
+// and for relative date filters. The time is the current one by default
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetCurrentUser sets the user returned by api/v3/users/me. Without current user, that endpoint
+// answers as for an anonymous user
+func (s *Server) SetCurrentUser(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentUserID = userID
+}
+
+// AddProject adds a project and returns it as rendered by the API. The identifier is derived from the name if empty
+func (s *Server) AddProject(project *openproject.Project) *openproject.Project {
+	result := new(openproject.Project)
+	s.add(projects, project, nil, result)
+	return result
+}
+
+// AddWorkPackage adds a work-package to a project, given by ID or identifier, and returns it as rendered by the API.
+// The status is the default one if the work-package has no status link
+func (s *Server) AddWorkPackage(projectID string, workPackage *openproject.WorkPackage) *openproject.WorkPackage {
+	result := new(openproject.WorkPackage)
+	s.add(workPackages, workPackage, func(res resource) {
+		project, ok := s.find(projects, projectID)
+		if !ok {
+			panic(fmt.Sprintf("openprojecttest: project %s not found", projectID))
+		}
+		links(res)["project"] = link(projects, project)
+	}, result)
+	return result
+}
+
+// AddUser adds a user and returns it as rendered by the API
+func (s *Server) AddUser(user *openproject.User) *openproject.User {
+	result := new(openproject.User)
+	s.add(users, user, nil, result)
+	return result
+}
+
+// AddStatus adds a work-package status and returns it as rendered by the API
+func (s *Server) AddStatus(status *openproject.Status) *openproject.Status {
+	result := new(openproject.Status)
+	s.add(statuses, status, nil, result)
+	return result
+}
+
+// AddAttachment adds a file as attachment of a work-package and returns it as rendered by the API.
+// A workPackageID of 0 adds a containerless attachment
+func (s *Server) AddAttachment(workPackageID int, fileName string, content []byte) *openproject.Attachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var container resource
+	if workPackageID != 0 {
+		var ok bool
+		if container, ok = s.stores[workPackages].items[workPackageID]; !ok {
+			panic(fmt.Sprintf("openprojecttest: work-package %d not found", workPackageID))
+		}
+	}
+	res := s.newAttachment(container, fileName, "", content)
+
+	result := new(openproject.Attachment)
+	decode(s.render(attachments, res), result)
+	return result
+}
+
+// add stores a resource given as model, without validation, and decodes its rendering into result
+func (s *Server) add(name string, model interface{}, prepare func(resource), result interface{}) {
+	res := make(resource)
+	decode(model, &res)
+	delete(res, "id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prepare != nil {
+		prepare(res)
+	}
+	kinds[name].defaults(s, res)
+	res = s.insert(name, res)
+	decode(s.render(name, res), result)
+}
+
+// insert assigns an ID to a new resource and stores it
+func (s *Server) insert(name string, res resource) resource {
+	st := s.stores[name]
+	res["id"] = st.nextID
+	st.items[st.nextID] = res
+	st.nextID++
+
+	now := formatTime(s.now())
+	if name != statuses {
+		res["createdAt"] = now
+	}
+	if name != statuses && name != attachments {
+		res["updatedAt"] = now
+	}
+	return res
+}
+
+// find returns a resource by ID. Projects can also be found by identifier
+func (s *Server) find(name string, id string) (resource, bool) {
+	if number, err := strconv.Atoi(id); err == nil {
+		res, ok := s.stores[name].items[number]
+		return res, ok
+	}
+	if name == projects {
+		for _, res := range s.stores[name].items {
+			if res["identifier"] == id {
+				return res, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// serveHTTP routes the requests of the API v3
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeNotFound(w)
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+	name := segments[0]
+	if _, ok := kinds[name]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch {
+	case len(segments) == 1:
+		s.serveCollection(w, r, name, nil)
+	case len(segments) == 2 && name == attachments && segments[1] == "prepare":
+		// Direct uploads are not supported, so clients fall back to multipart uploads
+		writeNotFound(w)
+	case len(segments) == 2 && name == users && segments[1] == "me":
+		s.serveCurrentUser(w, r)
+	case len(segments) == 2:
+		s.serveResource(w, r, name, segments[1])
+	default:
+		parent, ok := s.find(name, segments[1])
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		switch {
+		case len(segments) == 3 && name == projects && segments[2] == workPackages:
+			s.serveCollection(w, r, workPackages, parent)
+		case len(segments) == 4 && name == projects && segments[2] == workPackages && segments[3] == "form":
+			s.serveForm(w, r, parent, nil)
+		case len(segments) == 3 && name == workPackages && segments[2] == "form":
+			s.serveForm(w, r, nil, parent)
+		case len(segments) == 3 && name == workPackages && segments[2] == attachments:
+			s.serveCollection(w, r, attachments, parent)
+		case len(segments) == 4 && name == workPackages && segments[2] == attachments && segments[3] == "prepare":
+			writeNotFound(w)
+		case len(segments) == 3 && name == attachments && segments[2] == "content":
+			s.serveContent(w, r, parent)
+		case len(segments) == 3 && name == users && segments[2] == "lock":
+			s.serveLock(w, r, parent)
+		default:
+			writeNotFound(w)
+		}
+	}
+}
+
+// serveCollection lists (GET) or creates (POST) the resources of a collection, within a parent resource if not nil
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, name string, parent resource) {
+	k := kinds[name]
+	switch r.Method {
+	case "GET":
+		if parent == nil && !k.listable {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		s.list(w, r, name, parent)
+	case "POST":
+		if !k.creatable {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		if name == attachments {
+			s.upload(w, r, parent)
+			return
+		}
+		res, ok := readResource(w, r)
+		if !ok {
+			return
+		}
+		delete(res, "id")
+		if parent != nil {
+			links(res)["project"] = link(projects, parent)
+		}
+		k.defaults(s, res)
+		if errs := k.validate(s, res, 0); len(errs) > 0 {
+			writeViolations(w, errs)
+			return
+		}
+		res = s.insert(name, res)
+		writeJSON(w, http.StatusCreated, s.render(name, res))
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// serveResource gets (GET), updates (PATCH) or deletes (DELETE) a single resource
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, name string, id string) {
+	res, ok := s.find(name, id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	k := kinds[name]
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, s.render(name, res))
+	case "PATCH":
+		if !k.updatable {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		s.update(w, r, name, res)
+	case "DELETE":
+		if !k.deletable {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		s.delete(name, res)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// update applies a PATCH to a resource. Work-packages must be given the lockVersion they are based on
+func (s *Server) update(w http.ResponseWriter, r *http.Request, name string, res resource) {
+	changes, ok := readResource(w, r)
+	if !ok {
+		return
+	}
+	if name == workPackages {
+		lockVersion, given := changes["lockVersion"].(float64)
+		if !given || int(lockVersion) != res["lockVersion"] {
+			writeError(w, http.StatusConflict, openproject.ErrUpdateConflict,
+				"Your changes could not be saved, because the resource was changed meanwhile. Please reload and try again.")
+			return
+		}
+	}
+
+	updated := merge(res, changes)
+	id := res["id"].(int)
+	kinds[name].defaults(s, updated)
+	if errs := kinds[name].validate(s, updated, id); len(errs) > 0 {
+		writeViolations(w, errs)
+		return
+	}
+
+	updated["updatedAt"] = formatTime(s.now())
+	if name == workPackages {
+		updated["lockVersion"] = res["lockVersion"].(int) + 1
+	}
+	s.stores[name].items[id] = updated
+	writeJSON(w, http.StatusOK, s.render(name, updated))
+}
+
+// merge returns a copy of a resource with the writable attributes and links of changes applied
+func merge(res resource, changes resource) resource {
+	merged := copyResource(res)
+	for field, value := range changes {
+		switch field {
+		case "id", "_type", "createdAt", "updatedAt", "lockVersion", "_embedded":
+			// Read-only attributes
+		case "_links":
+			if changedLinks, ok := value.(map[string]interface{}); ok {
+				for rel, l := range changedLinks {
+					if rel != "self" {
+						links(merged)[rel] = l
+					}
+				}
+			}
+		default:
+			merged[field] = value
+		}
+	}
+	return merged
+}
+
+// serveForm validates a work-package without saving it, as the forms of OpenProject do: the create form of a
+// project when current is nil, the update form of current otherwise. Violations are rendered as
+// validationErrors of the form, along with the payload completed by the defaults
+func (s *Server) serveForm(w http.ResponseWriter, r *http.Request, project resource, current resource) {
+	if r.Method != "POST" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	changes, ok := readResource(w, r)
+	if !ok {
+		return
+	}
+	var payload resource
+	id := 0
+	if current != nil {
+		if lockVersion, given := changes["lockVersion"].(float64); given && int(lockVersion) != current["lockVersion"] {
+			writeError(w, http.StatusConflict, openproject.ErrUpdateConflict,
+				"Your changes could not be saved, because the resource was changed meanwhile. Please reload and try again.")
+			return
+		}
+		payload, id = merge(current, changes), current["id"].(int)
+	} else {
+		payload = changes
+		delete(payload, "id")
+		links(payload)["project"] = link(projects, project)
+	}
+	kinds[workPackages].defaults(s, payload)
+
+	validationErrors := make(map[string]interface{})
+	for _, v := range kinds[workPackages].validate(s, payload, id) {
+		validationErrors[v.attribute] = &openproject.Error{
+			Type:       "Error",
+			Identifier: openproject.ErrPropertyConstraintViolation,
+			Message:    v.message,
+			Embedded:   openproject.ErrorEmbedded{Details: &openproject.ErrorDetails{Attribute: v.attribute}},
+		}
+	}
+	delete(payload, "id")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"_type": "Form",
+		"_embedded": map[string]interface{}{
+			"payload":          payload,
+			"validationErrors": validationErrors,
+		},
+	})
+}
+
+// delete removes a resource along with the resources it contains
+func (s *Server) delete(name string, res resource) {
+	id := res["id"].(int)
+	delete(s.stores[name].items, id)
+	href := selfHref(name, id)
+	switch name {
+	case projects:
+		for _, wp := range s.stores[workPackages].items {
+			if linkHref(wp, "project") == href {
+				s.delete(workPackages, wp)
+			}
+		}
+	case workPackages:
+		for _, attachment := range s.stores[attachments].items {
+			if linkHref(attachment, "container") == href {
+				s.delete(attachments, attachment)
+			}
+		}
+	case attachments:
+		delete(s.contents, id)
+	}
+}
+
+// list renders a page of the resources matching the filters of the request, sorted as requested
+func (s *Server) list(w http.ResponseWriter, r *http.Request, name string, parent resource) {
+	query := r.URL.Query()
+	filters, err := parseFilters(query.Get("filters"), name)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidQuery, err.Error())
+		return
+	}
+	sortBy, err := parseSortBy(query.Get("sortBy"), name)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidQuery, err.Error())
+		return
+	}
+	page, pageSize, err := parsePage(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidQuery, err.Error())
+		return
+	}
+
+	parentLink, parentHref := "", ""
+	if parent != nil {
+		parentLink, parentHref = "project", selfHref(projects, parent["id"].(int))
+		if name == attachments {
+			parentLink, parentHref = "container", selfHref(workPackages, parent["id"].(int))
+		}
+	}
+	matches := make([]resource, 0)
+	for _, res := range s.stores[name].items {
+		if parentLink != "" && linkHref(res, parentLink) != parentHref {
+			continue
+		}
+		if s.match(res, filters) {
+			matches = append(matches, res)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return less(matches[i], matches[j], sortBy)
+	})
+
+	elements := make([]resource, 0)
+	start := (page - 1) * pageSize
+	for i := start; i < len(matches) && i < start+pageSize; i++ {
+		elements = append(elements, s.render(name, matches[i]))
+	}
+
+	self := *r.URL
+	pageLink := func(page int) map[string]interface{} {
+		values := self.Query()
+		values.Set("offset", strconv.Itoa(page))
+		values.Set("pageSize", strconv.Itoa(pageSize))
+		u := self
+		u.RawQuery = values.Encode()
+		return map[string]interface{}{"href": u.RequestURI()}
+	}
+	collectionLinks := map[string]interface{}{"self": map[string]interface{}{"href": self.RequestURI()}}
+	if start+pageSize < len(matches) {
+		collectionLinks["nextByOffset"] = pageLink(page + 1)
+	}
+	if page > 1 {
+		collectionLinks["previousByOffset"] = pageLink(page - 1)
+	}
+
+	writeJSON(w, http.StatusOK, resource{
+		"_type":     "Collection",
+		"total":     len(matches),
+		"count":     len(elements),
+		"pageSize":  pageSize,
+		"offset":    page,
+		"_embedded": map[string]interface{}{"elements": elements},
+		"_links":    collectionLinks,
+	})
+}
+
+// serveCurrentUser renders the current user
+func (s *Server) serveCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.stores[users].items[s.currentUserID]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, openproject.ErrUnauthenticated, "You need to be authenticated to access this resource.")
+		return
+	}
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.render(users, user))
+}
+
+// serveLock locks (POST) or unlocks (DELETE) a user
+func (s *Server) serveLock(w http.ResponseWriter, r *http.Request, user resource) {
+	switch r.Method {
+	case "POST":
+		user["status"] = openproject.UserStatusLocked
+	case "DELETE":
+		user["status"] = openproject.UserStatusActive
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	user["updatedAt"] = formatTime(s.now())
+	writeJSON(w, http.StatusOK, s.render(users, user))
+}
+
+// serveContent sends the file of an attachment
+func (s *Server) serveContent(w http.ResponseWriter, r *http.Request, attachment resource) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", fmt.Sprint(attachment["contentType"]))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment["fileName"]))
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.contents[attachment["id"].(int)])
+}
+
+// upload creates an attachment out of a multipart request with "metadata" and "file" parts
+func (s *Server) upload(w http.ResponseWriter, r *http.Request, container resource) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, "The request could not be parsed as multipart form: "+err.Error())
+		return
+	}
+	metadata := new(openproject.AttachmentMetadata)
+	if raw := r.FormValue("metadata"); raw == "" {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, "The metadata part is missing.")
+		return
+	} else if err := json.Unmarshal([]byte(raw), metadata); err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, "The metadata part could not be parsed as JSON.")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, "The file part is missing.")
+		return
+	}
+	defer file.Close()
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, err.Error())
+		return
+	}
+
+	fileName := metadata.FileName
+	if fileName == "" {
+		fileName = header.Filename
+	}
+	if fileName == "" {
+		writeViolations(w, []violation{{"fileName", "File name can't be blank."}})
+		return
+	}
+	contentType := metadata.ContentType
+	if contentType == "" {
+		contentType = header.Header.Get("Content-Type")
+	}
+	res := s.newAttachment(container, fileName, contentType, content)
+	if metadata.Description != nil {
+		res["description"] = formattable(metadata.Description.Raw)
+	}
+	writeJSON(w, http.StatusOK, s.render(attachments, res))
+}
+
+// newAttachment stores a file as attachment of a container, nil for a containerless attachment
+func (s *Server) newAttachment(container resource, fileName string, contentType string, content []byte) resource {
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(content)
+	}
+	res := resource{
+		"fileName":    fileName,
+		"fileSize":    len(content),
+		"contentType": contentType,
+		"digest":      map[string]interface{}{"algorithm": "md5", "hash": md5Hex(content)},
+		"_links":      map[string]interface{}{},
+	}
+	if container != nil {
+		links(res)["container"] = link(workPackages, container)
+	}
+	res = s.insert(attachments, res)
+	s.contents[res["id"].(int)] = append([]byte(nil), content...)
+	return res
+}
+
+// render returns the HAL representation of a resource
+func (s *Server) render(name string, res resource) resource {
+	rendered := copyResource(res)
+	rendered["_type"] = kinds[name].halType
+	l := links(rendered)
+	l["self"] = link(name, res)
+	kinds[name].decorate(s, rendered)
+	return rendered
+}
+
+// readResource decodes the JSON object of a request body, writing the error if it can't be decoded
+func readResource(w http.ResponseWriter, r *http.Request) (resource, bool) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, err.Error())
+		return nil, false
+	}
+	res := make(resource)
+	if len(bytes.TrimSpace(body)) == 0 {
+		return res, true
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		writeError(w, http.StatusBadRequest, errInvalidRequestBody, "The request body was not a single JSON object.")
+		return nil, false
+	}
+	return res, true
+}
+
+// writeJSON writes a HAL response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", halContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,341 @@
+package openprojecttest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+)
+
+func TestServer_Projects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	created, _, err := client.Project.Create(&openproject.Project{Name: "Demo project"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if created.ID != 1 || created.Identifier != "demo-project" || !created.Active || created.Type != "Project" || created.CreatedAt == nil {
+		t.Errorf("Unexpected project created: %+v", created)
+	}
+
+	project, _, err := client.Project.Get("demo-project")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if project.ID != created.ID {
+		t.Errorf("Expected project %d, got %d", created.ID, project.ID)
+	}
+
+	_, _, err = client.Project.Create(&openproject.Project{Name: "Demo project"})
+	var opErr *openproject.Error
+	if !errors.As(err, &opErr) || opErr.StatusCode != http.StatusUnprocessableEntity || opErr.Attribute() != "identifier" {
+		t.Errorf("Expected the identifier to be taken, got %v", err)
+	}
+
+	_, _, err = client.Project.Create(&openproject.Project{Identifier: "1nvalid"})
+	if !errors.Is(err, openproject.ErrMultipleErrors) || !errors.Is(err, openproject.ErrPropertyConstraintViolation) {
+		t.Fatalf("Expected multiple violations, got %v", err)
+	}
+	errors.As(err, &opErr)
+	if attributes := opErr.Attributes(); len(attributes) != 2 || attributes[0] != "name" || attributes[1] != "identifier" {
+		t.Errorf("Expected violations of name and identifier, got %v", attributes)
+	}
+
+	list, _, err := client.Project.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if list.Total != 1 || list.Count != 1 || len(list.Embedded.Elements) != 1 {
+		t.Errorf("Expected a single project, got %+v", list)
+	}
+
+	_, _, err = client.Project.Get("missing")
+	if !errors.Is(err, openproject.ErrNotFound) {
+		t.Errorf("Expected not found, got %v", err)
+	}
+}
+
+func TestServer_WorkPackages(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	project := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	open := server.AddStatus(&openproject.Status{Name: "New", IsDefault: true})
+	closed := server.AddStatus(&openproject.Status{Name: "Closed", IsClosed: true})
+
+	wp, _, err := client.WorkPackage.Create(&openproject.WorkPackage{Subject: "First"}, project.Identifier)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if wp.ID != 1 || wp.LockVersion != 0 || wp.Links.Status.Href != fmt.Sprintf("/api/v3/statuses/%d", open.ID) ||
+		wp.Links.Status.Title != "New" || wp.Links.Project.Title != "Demo" {
+		t.Errorf("Unexpected work-package created: %+v %+v", wp, wp.Links)
+	}
+
+	_, _, err = client.WorkPackage.Create(&openproject.WorkPackage{}, project.Identifier)
+	var opErr *openproject.Error
+	if !errors.As(err, &opErr) || !errors.Is(err, openproject.ErrPropertyConstraintViolation) || opErr.Attribute() != "subject" ||
+		opErr.Message != "Subject can't be blank." {
+		t.Errorf("Expected the subject to be missing, got %v", err)
+	}
+
+	updated, _, err := client.WorkPackage.Update("1", &openproject.WorkPackage{
+		Subject:     "First, updated",
+		LockVersion: wp.LockVersion,
+		Links:       &openproject.WPLinks{Status: openproject.WPLinksField{Href: fmt.Sprintf("/api/v3/statuses/%d", closed.ID)}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if updated.Subject != "First, updated" || updated.LockVersion != 1 || updated.Links.Status.Title != "Closed" {
+		t.Errorf("Unexpected work-package updated: %+v %+v", updated, updated.Links)
+	}
+
+	_, _, err = client.WorkPackage.Update("1", &openproject.WorkPackage{Subject: "Stale", LockVersion: wp.LockVersion})
+	if !errors.Is(err, openproject.ErrUpdateConflict) {
+		t.Errorf("Expected an update conflict, got %v", err)
+	}
+
+	server.AddWorkPackage("demo", &openproject.WorkPackage{Subject: "Second"})
+	list, _, err := client.WorkPackage.GetList(&openproject.FilterOptions{
+		Fields: []openproject.OptionsFields{{Field: "status", Operator: openproject.Open}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(list) != 1 || list[0].Subject != "Second" {
+		t.Errorf("Expected the open work-package only, got %+v", list)
+	}
+
+	if _, err := client.WorkPackage.Delete("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.WorkPackage.Get("1"); !errors.Is(err, openproject.ErrNotFound) {
+		t.Errorf("Expected the work-package to be deleted, got %v", err)
+	}
+}
+
+func TestServer_WorkPackageForms(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	demo := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	archive := server.AddProject(&openproject.Project{Identifier: "archive", Name: "Archive"})
+	wp := server.AddWorkPackage(demo.Identifier, &openproject.WorkPackage{Subject: "First"})
+	id := fmt.Sprint(wp.ID)
+
+	report, err := client.WorkPackage.BulkMove([]string{id, "99"}, fmt.Sprint(archive.ID), &openproject.BulkOptions{DryRun: true})
+	if !errors.Is(err, openproject.ErrNotFound) {
+		t.Errorf("Expected the missing work package to fail, got %v", err)
+	}
+	if moved := report.Results[0].WorkPackage; moved == nil || moved.Links.Project.Href != "/api/v3/projects/2" {
+		t.Errorf("Expected the work package validated in the archive, got %+v", report.Results[0])
+	}
+	if current, _, _ := client.WorkPackage.Get(id); current.LockVersion != 0 || current.Links.Project.Title != "Demo" {
+		t.Errorf("Expected a dry run to leave the work package unchanged, got %+v", current)
+	}
+
+	_, err = client.WorkPackage.BulkUpdate([]openproject.BulkUpdateItem{{ID: id, Changes: &openproject.WorkPackage{Subject: " "}}}, &openproject.BulkOptions{DryRun: true})
+	var opErr *openproject.Error
+	if !errors.As(err, &opErr) || opErr.Attribute() != "subject" {
+		t.Errorf("Expected the blank subject to be rejected by the form, got %v", err)
+	}
+
+	report, err = client.WorkPackage.BulkCopy([]string{id}, archive.Identifier, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if copied := report.Results[0].WorkPackage; copied.ID == wp.ID || copied.Subject != "First" || copied.Links.Project.Title != "Archive" {
+		t.Errorf("Unexpected copy %+v", copied)
+	}
+}
+
+func TestServer_FiltersAndPages(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+	server.SetClock(func() time.Time { return now })
+	server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	for i := 1; i <= 25; i++ {
+		if i == 21 {
+			now = now.AddDate(0, 0, 3)
+		}
+		server.AddWorkPackage("demo", &openproject.WorkPackage{Subject: fmt.Sprintf("Task %02d", i)})
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		total    int
+		count    int
+		first    string
+		next     bool
+		previous bool
+	}{
+		{"first page", "", 25, 20, "Task 01", true, false},
+		{"last page", "offset=2&pageSize=20", 25, 5, "Task 21", false, true},
+		{"sorted", `sortBy=[["subject","desc"]]&pageSize=2`, 25, 2, "Task 25", true, false},
+		{"like", `filters=[{"subject":{"operator":"~","values":["task%201"]}}]`, 10, 10, "Task 10", false, false},
+		{"ids", `filters=[{"id":{"operator":"=","values":["3","5"]}}]`, 2, 2, "Task 03", false, false},
+		{"updated since", `filters=[{"updatedAt":{"operator":"<>d","values":["2021-03-12T00:00:00Z",""]}}]`, 5, 5, "Task 21", false, false},
+		{"updated on", `filters=[{"updatedAt":{"operator":"=d","values":["2021-03-10"]}}]`, 20, 20, "Task 01", false, false},
+		{"updated today", `filters=[{"updatedAt":{"operator":"t","values":[]}}]`, 5, 5, "Task 21", false, false},
+		{"empty page", "pageSize=0", 25, 0, "", true, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := client.NewRequest("GET", "api/v3/projects/demo/work_packages?"+tc.query, nil)
+			result := new(struct {
+				openproject.SearchResultWP
+				Links map[string]*openproject.OPGenericLink `json:"_links"`
+			})
+			if _, err := client.Do(req, result); err != nil {
+				t.Fatalf("Error given: %s", err)
+			}
+			if result.Total != tc.total || result.Count != tc.count || len(result.Embedded.Elements) != tc.count {
+				t.Errorf("Expected %d of %d work-packages, got %d of %d", tc.count, tc.total, result.Count, result.Total)
+			}
+			if tc.count > 0 && len(result.Embedded.Elements) > 0 && result.Embedded.Elements[0].Subject != tc.first {
+				t.Errorf("Expected %s first, got %s", tc.first, result.Embedded.Elements[0].Subject)
+			}
+			if (result.Links["nextByOffset"] != nil) != tc.next || (result.Links["previousByOffset"] != nil) != tc.previous {
+				t.Errorf("Unexpected page links %v", result.Links)
+			}
+		})
+	}
+
+	req, _ := client.NewRequest("GET", `api/v3/work_packages?filters=[{"unknown":{"operator":"=","values":["1"]}}]`, nil)
+	_, err := client.Do(req, nil)
+	var opErr *openproject.Error
+	if !errors.As(err, &opErr) || opErr.StatusCode != http.StatusBadRequest || opErr.Identifier != errInvalidQuery {
+		t.Errorf("Expected an invalid query, got %v", err)
+	}
+}
+
+func TestServer_Users(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	if _, _, err := client.Authentication.GetCurrentUser(); !errors.Is(err, openproject.ErrUnauthenticated) {
+		t.Errorf("Expected an anonymous user, got %v", err)
+	}
+
+	user, _, err := client.User.Create(&openproject.User{Login: "jdoe", FirstName: "John", LastName: "Doe", Email: "jdoe@example.com", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if user.Name != "John Doe" || user.Status != openproject.UserStatusActive || user.Password != "" || user.Links.Lock == nil {
+		t.Errorf("Unexpected user created: %+v", user)
+	}
+	invited, _, err := client.User.Invite(&openproject.User{Email: "new@example.com"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if invited.Login != "new@example.com" || invited.Status != openproject.UserStatusInvited {
+		t.Errorf("Unexpected user invited: %+v", invited)
+	}
+	if _, _, err := client.User.Create(&openproject.User{Login: "JDoe", FirstName: "J", LastName: "D", Email: "other@example.com"}); !errors.Is(err, openproject.ErrPropertyConstraintViolation) {
+		t.Errorf("Expected the login to be taken, got %v", err)
+	}
+
+	locked, _, err := client.User.Lock(fmt.Sprint(user.ID))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if locked.Status != openproject.UserStatusLocked || locked.Links.Unlock == nil {
+		t.Errorf("Unexpected user locked: %+v", locked)
+	}
+
+	list, _, err := client.User.GetList(&openproject.FilterOptions{
+		Fields: []openproject.OptionsFields{{Field: "status", Operator: openproject.Equal, Value: openproject.UserStatusLocked}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if list.Total != 1 || list.Embedded.Elements[0].Login != "jdoe" {
+		t.Errorf("Expected the locked user only, got %+v", list)
+	}
+
+	server.SetCurrentUser(user.ID)
+	current, _, err := client.Authentication.GetCurrentUser()
+	if err != nil || current.ID != user.ID {
+		t.Errorf("Expected the current user %d, got %+v %v", user.ID, current, err)
+	}
+}
+
+func TestServer_Attachments(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	wp := server.AddWorkPackage("demo", &openproject.WorkPackage{Subject: "With files"})
+	server.AddAttachment(wp.ID, "seeded.txt", []byte("seeded"))
+
+	content := []byte("hello world")
+	attachment, _, err := client.Attachment.Upload(fmt.Sprintf("api/v3/work_packages/%d/attachments", wp.ID), "hello.txt", content)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if attachment.FileName != "hello.txt" || attachment.FileSize != len(content) || attachment.Digest.Algorithm != "md5" ||
+		attachment.Links.Container.Title != "With files" {
+		t.Errorf("Unexpected attachment uploaded: %+v", attachment)
+	}
+
+	downloaded, err := client.Attachment.Download(fmt.Sprint(attachment.ID))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !bytes.Equal(*downloaded, content) {
+		t.Errorf("Expected %q, got %q", content, *downloaded)
+	}
+
+	list := new(openproject.SearchResultAttachment)
+	req, _ := client.NewRequest("GET", fmt.Sprintf("api/v3/work_packages/%d/attachments", wp.ID), nil)
+	if _, err := client.Do(req, list); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if list.Total != 2 {
+		t.Errorf("Expected 2 attachments, got %d", list.Total)
+	}
+
+	if _, err := client.WorkPackage.Delete(fmt.Sprint(wp.ID)); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.Attachment.Get(fmt.Sprint(attachment.ID)); !errors.Is(err, openproject.ErrNotFound) {
+		t.Errorf("Expected the attachment to be deleted along with its container, got %v", err)
+	}
+}
+
+func TestServer_Statuses(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.AddStatus(&openproject.Status{Name: "New", IsDefault: true})
+	list, _, err := client.Status.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if list.Total != 1 || list.Embedded.Elements[0].Name != "New" {
+		t.Errorf("Unexpected statuses: %+v", list)
+	}
+
+	req, _ := client.NewRequest("DELETE", "api/v3/statuses/1", nil)
+	_, err = client.Do(req, nil)
+	var opErr *openproject.Error
+	if !errors.As(err, &opErr) || opErr.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected statuses to be read-only, got %v", err)
+	}
+}
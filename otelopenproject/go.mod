@@ -0,0 +1,24 @@
+module github.com/manuelbcd/go-openproject/otelopenproject
+
+go 1.20
+
+require (
+	github.com/manuelbcd/go-openproject v0.0.0
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/metric v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/sdk/metric v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.0.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/trivago/tgo v1.0.7 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+)
+
+replace github.com/manuelbcd/go-openproject => ../
@@ -0,0 +1,191 @@
+// Package otelopenproject instruments the OpenProject client with OpenTelemetry.
+// Every API call gets a client span named after the service method (i.e. "WorkPackageService.GetList"),
+// carrying the HTTP attributes, the resource ID and the result count, and the trace is propagated to
+// OpenProject within the request headers. Latency and errors are recorded per endpoint:
+//
+//	instrumentation, err := otelopenproject.New()
+//	if err != nil {
+//		return err
+//	}
+//	client.SetInstrumentation(instrumentation)
+package otelopenproject
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer and of the meter
+const instrumentationName = "github.com/manuelbcd/go-openproject/otelopenproject"
+
+// Attributes of the spans and of the metrics, besides the HTTP ones
+const (
+	// OperationKey is the service method of the call, i.e. "WorkPackageService.GetList"
+	OperationKey = attribute.Key("openproject.operation")
+	// EndpointKey is the path of the call with IDs replaced, i.e. "api/v3/work_packages/{id}"
+	EndpointKey = attribute.Key("openproject.endpoint")
+	// ResourceIDKey is the ID of the resource of the call
+	ResourceIDKey = attribute.Key("openproject.resource.id")
+	// ResultCountKey is the number of elements returned by a collection
+	ResultCountKey = attribute.Key("openproject.result.count")
+	// ResultTotalKey is the number of elements of a collection among every page
+	ResultTotalKey = attribute.Key("openproject.result.total")
+)
+
+// config holds the options of the instrumentation
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global one by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global one by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators injecting the trace into requests, the global ones by default
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// instrumentation implements openproject.Instrumentation
+type instrumentation struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+}
+
+// New returns an instrumentation to be set with Client.SetInstrumentation
+func New(options ...Option) (openproject.Instrumentation, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram("openproject.client.duration",
+		metric.WithDescription("Duration of the OpenProject API calls, retries included"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	errors, err := meter.Int64Counter("openproject.client.errors",
+		metric.WithDescription("Number of failed OpenProject API calls"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumentation{
+		tracer:      c.tracerProvider.Tracer(instrumentationName),
+		propagators: c.propagators,
+		duration:    duration,
+		errors:      errors,
+	}, nil
+}
+
+// StartCall starts the span of a call and injects it into the request
+func (i *instrumentation) StartCall(ctx context.Context, call *openproject.CallInfo) (context.Context, func(result *openproject.CallResult)) {
+	req := call.Request
+	name := call.Operation
+	if name == "" {
+		name = "OpenProject " + req.Method
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.full", req.URL.String()),
+		attribute.String("server.address", req.URL.Hostname()),
+		EndpointKey.String(call.Endpoint),
+	}
+	if port := serverPort(req); port > 0 {
+		attributes = append(attributes, attribute.Int("server.port", port))
+	}
+	if call.Operation != "" {
+		attributes = append(attributes, OperationKey.String(call.Operation))
+	}
+	if call.ResourceID != "" {
+		attributes = append(attributes, ResourceIDKey.String(call.ResourceID))
+	}
+
+	ctx, span := i.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	// The request is shared with the caller, so its headers are copied before adding the trace ones
+	call.Request = req.Clone(ctx)
+	i.propagators.Inject(ctx, propagation.HeaderCarrier(call.Request.Header))
+
+	return ctx, func(result *openproject.CallResult) {
+		metricAttributes := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			EndpointKey.String(call.Endpoint),
+			OperationKey.String(call.Operation),
+		}
+		if result.StatusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", result.StatusCode))
+			metricAttributes = append(metricAttributes, attribute.Int("http.response.status_code", result.StatusCode))
+		}
+		if result.Count >= 0 {
+			span.SetAttributes(ResultCountKey.Int(result.Count), ResultTotalKey.Int(result.Total))
+		}
+
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+			errorType := "request"
+			if result.StatusCode != 0 {
+				errorType = strconv.Itoa(result.StatusCode)
+			}
+			i.errors.Add(ctx, 1, metric.WithAttributes(append(metricAttributes, attribute.String("error.type", errorType))...))
+		}
+		i.duration.Record(ctx, result.Duration.Seconds(), metric.WithAttributes(metricAttributes...))
+		span.End()
+	}
+}
+
+// serverPort returns the port of the server of a request
+func serverPort(req *http.Request) int {
+	if port := req.URL.Port(); port != "" {
+		p, _ := strconv.Atoi(port)
+		return p
+	}
+	if _, port, err := net.SplitHostPort(req.Host); err == nil {
+		p, _ := strconv.Atoi(port)
+		return p
+	}
+	switch req.URL.Scheme {
+	case "https":
+		return 443
+	case "http":
+		return 80
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package otelopenproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	traceparents := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("Traceparent"))
+		switch r.URL.Path {
+		case "/api/v3/work_packages":
+			fmt.Fprint(w, `{"_type":"Collection","total":42,"count":2,"pageSize":2,"offset":1,"_embedded":{"elements":[{"id":1},{"id":2}]}}`)
+		case "/api/v3/statuses/9":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+		}
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	instrumentation, err := New(
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+		WithPropagators(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := openproject.NewClient(nil, server.URL)
+	client.SetInstrumentation(instrumentation)
+
+	// The span of the caller is the parent of the call span
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "dashboard")
+	if _, _, err := client.WorkPackage.GetListWithContext(ctx, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	parent.End()
+	if _, _, err := client.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := client.Status.Get("9"); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 4 {
+		t.Fatalf("Expected 4 spans, %d given", len(ended))
+	}
+	list, get, failed := ended[0], ended[2], ended[3]
+	if list.Name() != "WorkPackageService.GetList" || get.Name() != "StatusService.Get" {
+		t.Errorf("Unexpected span names %q and %q", list.Name(), get.Name())
+	}
+	if list.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected call span child of the caller span")
+	}
+	if traceparents[0] == "" || traceparents[0][36:52] != list.SpanContext().SpanID().String() {
+		t.Errorf("Expected trace propagated to the server, %q given", traceparents[0])
+	}
+	expectAttribute(t, list.Attributes(), ResultCountKey, attribute.IntValue(2))
+	expectAttribute(t, list.Attributes(), ResultTotalKey, attribute.IntValue(42))
+	expectAttribute(t, list.Attributes(), "http.response.status_code", attribute.IntValue(200))
+	expectAttribute(t, get.Attributes(), ResourceIDKey, attribute.StringValue("1"))
+	expectAttribute(t, get.Attributes(), EndpointKey, attribute.StringValue("api/v3/statuses/{id}"))
+	expectAttribute(t, get.Attributes(), "http.request.method", attribute.StringValue("GET"))
+	if failed.Status().Code != codes.Error || len(failed.Events()) != 1 {
+		t.Errorf("Expected failed span with error recorded, %+v given", failed.Status())
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	durations, errorCount := 0, int64(0)
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					durations += int(point.Count)
+				}
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					errorCount += point.Value
+					if endpoint, _ := point.Attributes.Value(EndpointKey); endpoint.AsString() != "api/v3/statuses/{id}" {
+						t.Errorf("Unexpected error endpoint %v", endpoint)
+					}
+				}
+			}
+		}
+	}
+	if durations != 3 || errorCount != 1 {
+		t.Errorf("Expected 3 durations and 1 error recorded, %d and %d given", durations, errorCount)
+	}
+}
+
+// expectAttribute checks the value of an attribute
+func expectAttribute(t *testing.T, attributes []attribute.KeyValue, key attribute.Key, value attribute.Value) {
+	t.Helper()
+	for _, kv := range attributes {
+		if kv.Key == key {
+			if kv.Value != value {
+				t.Errorf("Expected %s = %v, %v given", key, value.Emit(), kv.Value.Emit())
+			}
+			return
+		}
+	}
+	t.Errorf("Expected attribute %s", key)
+}
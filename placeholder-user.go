@@ -0,0 +1,186 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// PlaceholderUserService handles placeholder users for the OpenProject instance / API.
+// Placeholder users stand for people not hired (or not known) yet, i.e. "Backend dev #2",
+// and can be members of projects and assignees of work packages.
+type PlaceholderUserService struct {
+	client *Client
+}
+
+// SearchResultPlaceholderUser represent a list of placeholder users
+type SearchResultPlaceholderUser struct {
+	Embedded placeholderUserElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int                     `json:"total" structs:"total"`
+	Count    int                     `json:"count" structs:"count"`
+	PageSize int                     `json:"pageSize" structs:"pageSize"`
+	Offset   int                     `json:"offset" structs:"offset"`
+}
+
+// placeholderUserElements represent elements within SearchResultPlaceholderUser
+type placeholderUserElements struct {
+	Elements []PlaceholderUser `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// PlaceholderUser is the object representing OpenProject placeholder users.
+type PlaceholderUser struct {
+	Type      string                `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int                   `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string                `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt *Time                 `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time                 `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Links     *PlaceholderUserLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// PlaceholderUserLinks are PlaceholderUser Links
+type PlaceholderUserLinks struct {
+	Self              *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	ShowUser          *OPGenericLink `json:"showUser,omitempty" structs:"showUser,omitempty"`
+	UpdateImmediately *OPGenericLink `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Delete            *OPGenericLink `json:"delete,omitempty" structs:"delete,omitempty"`
+}
+
+// GetWithContext returns a single placeholder user for the given ID.
+func (s *PlaceholderUserService) GetWithContext(ctx context.Context, placeholderID string) (*PlaceholderUser, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/placeholder_users/%s", placeholderID)
+	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*PlaceholderUser), Resp, err
+}
+
+// Get wraps GetWithContext using the background context.
+func (s *PlaceholderUserService) Get(placeholderID string) (*PlaceholderUser, *Response, error) {
+	return s.GetWithContext(context.Background(), placeholderID)
+}
+
+// GetListWithContext will retrieve a list of placeholder users using filters
+func (s *PlaceholderUserService) GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultPlaceholderUser, *Response, error) {
+	u := url.URL{
+		Path: "api/v3/placeholder_users",
+	}
+
+	objList, resp, err := GetListWithContext(ctx, s, u.String(), options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return objList.(*SearchResultPlaceholderUser), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *PlaceholderUserService) GetList(options *FilterOptions) (*SearchResultPlaceholderUser, *Response, error) {
+	return s.GetListWithContext(context.Background(), options)
+}
+
+// CreateWithContext creates a placeholder user. Only its name is required.
+func (s *PlaceholderUserService) CreateWithContext(ctx context.Context, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error) {
+	apiEndpoint := "api/v3/placeholder_users"
+	Obj, Resp, err := CreateWithContext(ctx, s, apiEndpoint, placeholder)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*PlaceholderUser), Resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *PlaceholderUserService) Create(placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error) {
+	return s.CreateWithContext(context.Background(), placeholder)
+}
+
+// UpdateWithContext updates a placeholder user (PATCH)
+func (s *PlaceholderUserService) UpdateWithContext(ctx context.Context, placeholderID string, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/placeholder_users/%s", placeholderID)
+	Obj, Resp, err := UpdateWithContext(ctx, s, apiEndpoint, placeholder)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*PlaceholderUser), Resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *PlaceholderUserService) Update(placeholderID string, placeholder *PlaceholderUser) (*PlaceholderUser, *Response, error) {
+	return s.UpdateWithContext(context.Background(), placeholderID, placeholder)
+}
+
+// DeleteWithContext will delete a single placeholder user.
+func (s *PlaceholderUserService) DeleteWithContext(ctx context.Context, placeholderID string) (*Response, error) {
+	apiEndPoint := fmt.Sprintf("api/v3/placeholder_users/%s", placeholderID)
+	resp, err := DeleteWithContext(ctx, s, apiEndPoint)
+	return resp, err
+}
+
+// Delete wraps DeleteWithContext using the background context.
+func (s *PlaceholderUserService) Delete(placeholderID string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), placeholderID)
+}
+
+// ReassignWorkPackagesWithContext moves every work package assigned to (or accountable by) a placeholder user
+// to a real user, i.e. once the position has been filled. It returns the updated work packages.
+// On failure the work packages updated so far are returned along with the error, so the call can be retried.
+func (s *PlaceholderUserService) ReassignWorkPackagesWithContext(ctx context.Context, placeholderID string, userID string) ([]WorkPackage, error) {
+	userLink := WPLinksField{Href: fmt.Sprintf("/api/v3/users/%s", userID)}
+	updated := make([]WorkPackage, 0)
+
+	for _, field := range []string{"assignee", "responsible"} {
+		opts := &FilterOptions{
+			Fields: []OptionsFields{
+				{Field: field, Operator: Equal, Value: placeholderID},
+			},
+		}
+
+		// Updated work packages stop matching the filter, so keep asking for the first page until it is empty.
+		// A page holding only work packages updated already means the updates don't take effect, so stop there
+		done := make(map[int]bool)
+		for {
+			objList, _, err := GetListWithContext(ctx, s.client.WorkPackage, "api/v3/work_packages", opts)
+			if err != nil {
+				return updated, err
+			}
+			elements := objList.(*SearchResultWP).Embedded.Elements
+			if len(elements) == 0 {
+				break
+			}
+			progress := false
+			for _, wp := range elements {
+				progress = progress || !done[wp.ID]
+			}
+			if !progress {
+				return updated, errors.Errorf("work packages still have the placeholder %s as %s after reassigning them", placeholderID, field)
+			}
+
+			for _, wp := range elements {
+				change := &WorkPackage{
+					LockVersion: wp.LockVersion,
+					Links:       &WPLinks{},
+				}
+				if field == "assignee" {
+					change.Links.Assignee = userLink
+				} else {
+					change.Links.Responsible = userLink
+				}
+
+				result, _, err := s.client.WorkPackage.UpdateWithContext(ctx, fmt.Sprintf("%d", wp.ID), change)
+				if err != nil {
+					return updated, errors.Wrapf(err, "reassigning work package %d", wp.ID)
+				}
+				done[wp.ID] = true
+				updated = append(updated, *result)
+			}
+		}
+	}
+
+	return updated, nil
+}
+
+// ReassignWorkPackages wraps ReassignWorkPackagesWithContext using the background context.
+func (s *PlaceholderUserService) ReassignWorkPackages(placeholderID string, userID string) ([]WorkPackage, error) {
+	return s.ReassignWorkPackagesWithContext(context.Background(), placeholderID, userID)
+}
@@ -0,0 +1,229 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPlaceholderUserService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/placeholder_users"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-placeholder-users-no-filters.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	placeholders, resp, err := testClient.Placeholder.GetList(nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if placeholders == nil || resp.Total != 2 {
+		t.Error("Expected 2 placeholder users within the list")
+		return
+	}
+	if placeholders.Embedded.Elements[1].Name != "UX designer" {
+		t.Errorf("Unexpected placeholder user name %s", placeholders.Embedded.Elements[1].Name)
+	}
+}
+
+func TestPlaceholderUserService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/placeholder_users/12"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-placeholder-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	placeholder, _, err := testClient.Placeholder.Get("12")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if placeholder == nil || placeholder.Name != "Backend dev #2" {
+		t.Errorf("Unexpected placeholder user %+v", placeholder)
+	}
+}
+
+func TestPlaceholderUserService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/placeholder_users"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-placeholder-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		body := new(PlaceholderUser)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Error(err.Error())
+		}
+		if body.Name != "Backend dev #2" {
+			t.Errorf("Unexpected placeholder user name sent %s", body.Name)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	placeholder, _, err := testClient.Placeholder.Create(&PlaceholderUser{Name: "Backend dev #2"})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if placeholder == nil || placeholder.ID != 12 {
+		t.Errorf("Unexpected placeholder user %+v", placeholder)
+	}
+}
+
+func TestPlaceholderUserService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/placeholder_users/12"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-placeholder-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	placeholder, _, err := testClient.Placeholder.Update("12", &PlaceholderUser{Name: "Backend dev #2"})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if placeholder == nil {
+		t.Error("Expected placeholder user. Placeholder user is nil")
+	}
+}
+
+func TestPlaceholderUserService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/placeholder_users/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testRequestURL(t, r, "/api/v3/placeholder_users/12")
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	resp, err := testClient.Placeholder.Delete("12")
+	if resp.StatusCode != 202 {
+		t.Error("Placeholder user not deleted.")
+	}
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestPlaceholderUserService_ReassignWorkPackages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Work packages 1 and 2 are assigned to the placeholder, 2 is also accountable by it
+	assignee := map[int]string{1: "/api/v3/placeholder_users/12", 2: "/api/v3/placeholder_users/12"}
+	responsible := map[int]string{2: "/api/v3/placeholder_users/12"}
+	lockVersions := map[int]int{1: 0, 2: 3}
+
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		filters := make([]map[string]struct {
+			Operator string   `json:"operator"`
+			Values   []string `json:"values"`
+		}, 0)
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters); err != nil {
+			t.Error(err.Error())
+		}
+		links := assignee
+		if _, ok := filters[0]["responsible"]; ok {
+			links = responsible
+		}
+		elements := make([]string, 0)
+		for id, href := range links {
+			if href == "/api/v3/placeholder_users/12" {
+				elements = append(elements, fmt.Sprintf(`{"_type":"WorkPackage","id":%d,"lockVersion":%d}`, id, lockVersions[id]))
+			}
+		}
+		fmt.Fprintf(w, `{"_type":"WorkPackageCollection","total":%d,"count":%d,"_embedded":{"elements":[%s]}}`,
+			len(elements), len(elements), strings.Join(elements, ","))
+	})
+	for id := range assignee {
+		id := id
+		testMux.HandleFunc(fmt.Sprintf("/api/v3/work_packages/%d", id), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PATCH")
+			body := make(map[string]interface{})
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err.Error())
+			}
+			if body["lockVersion"] != float64(lockVersions[id]) {
+				t.Errorf("Expected lockVersion %d for work package %d, got %v", lockVersions[id], id, body["lockVersion"])
+			}
+			links := body["_links"].(map[string]interface{})
+			if link, ok := links["assignee"]; ok {
+				assignee[id] = link.(map[string]interface{})["href"].(string)
+			}
+			if link, ok := links["responsible"]; ok {
+				responsible[id] = link.(map[string]interface{})["href"].(string)
+			}
+			lockVersions[id]++
+			fmt.Fprintf(w, `{"_type":"WorkPackage","id":%d,"lockVersion":%d}`, id, lockVersions[id])
+		})
+	}
+
+	updated, err := testClient.Placeholder.ReassignWorkPackages("12", "4")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if len(updated) != 3 {
+		t.Errorf("Expected 3 updates, got %d", len(updated))
+	}
+	if assignee[1] != "/api/v3/users/4" || assignee[2] != "/api/v3/users/4" || responsible[2] != "/api/v3/users/4" {
+		t.Errorf("Work packages not reassigned: assignee %v, responsible %v", assignee, responsible)
+	}
+}
+
+func TestPlaceholderUserService_ReassignWorkPackages_NoProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The update is accepted but the work package keeps matching the filter
+	testMux.HandleFunc("/api/v3/work_packages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"_type":"WorkPackageCollection","total":1,"count":1,"_embedded":{"elements":[{"_type":"WorkPackage","id":1,"lockVersion":0}]}}`)
+	})
+	patches := 0
+	testMux.HandleFunc("/api/v3/work_packages/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		patches++
+		fmt.Fprint(w, `{"_type":"WorkPackage","id":1,"lockVersion":1}`)
+	})
+
+	updated, err := testClient.Placeholder.ReassignWorkPackages("12", "4")
+	if err == nil {
+		t.Error("Expected an error")
+	}
+	if len(updated) != 1 || patches != 1 {
+		t.Errorf("Expected a single update, got %d updates and %d requests", len(updated), patches)
+	}
+}
@@ -0,0 +1,156 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PrincipalService handles principals (users, groups and placeholder users) for the OpenProject instance / API.
+type PrincipalService struct {
+	client *Client
+}
+
+// Constants to represent the "_type" discriminator of OpenProject principals
+const (
+	// PrincipalTypeUser is the type of principals being users
+	PrincipalTypeUser = "User"
+	// PrincipalTypeGroup is the type of principals being groups
+	PrincipalTypeGroup = "Group"
+	// PrincipalTypePlaceholderUser is the type of principals being placeholder users
+	PrincipalTypePlaceholderUser = "PlaceholderUser"
+)
+
+// SearchResultPrincipal represent a list of Principals
+type SearchResultPrincipal struct {
+	Embedded principalElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int               `json:"total" structs:"total"`
+	Count    int               `json:"count" structs:"count"`
+	PageSize int               `json:"pageSize" structs:"pageSize"`
+	Offset   int               `json:"offset" structs:"offset"`
+}
+
+// principalElements represent elements within SearchResultPrincipal
+type principalElements struct {
+	Elements []Principal `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// Principal is anything work packages can be assigned to: users, groups or placeholder users.
+// Common fields are always populated, and depending on Type the specific object is
+// decoded into User, Group or PlaceholderUser.
+type Principal struct {
+	Type      string `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int    `json:"id,omitempty" structs:"id,omitempty"`
+	Name      string `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt *Time  `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time  `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+
+	User            *User            `json:"-" structs:"-"`
+	Group           *Group           `json:"-" structs:"-"`
+	PlaceholderUser *PlaceholderUser `json:"-" structs:"-"`
+}
+
+// principalFields wraps the fields shared by every principal type
+type principalFields struct {
+	Type      string `json:"_type,omitempty"`
+	ID        int    `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	CreatedAt *Time  `json:"createdAt,omitempty"`
+	UpdatedAt *Time  `json:"updatedAt,omitempty"`
+}
+
+// UnmarshalJSON decodes a principal using its "_type" as discriminator
+func (p *Principal) UnmarshalJSON(b []byte) error {
+	fields := new(principalFields)
+	if err := json.Unmarshal(b, fields); err != nil {
+		return err
+	}
+	*p = Principal{
+		Type:      fields.Type,
+		ID:        fields.ID,
+		Name:      fields.Name,
+		CreatedAt: fields.CreatedAt,
+		UpdatedAt: fields.UpdatedAt,
+	}
+
+	switch p.Type {
+	case PrincipalTypeUser:
+		p.User = new(User)
+		return json.Unmarshal(b, p.User)
+	case PrincipalTypeGroup:
+		p.Group = new(Group)
+		return json.Unmarshal(b, p.Group)
+	case PrincipalTypePlaceholderUser:
+		p.PlaceholderUser = new(PlaceholderUser)
+		return json.Unmarshal(b, p.PlaceholderUser)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the specific object of the principal if any, or its common fields otherwise
+func (p Principal) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.User != nil:
+		return json.Marshal(p.User)
+	case p.Group != nil:
+		return json.Marshal(p.Group)
+	case p.PlaceholderUser != nil:
+		return json.Marshal(p.PlaceholderUser)
+	}
+	return json.Marshal(principalFields{
+		Type:      p.Type,
+		ID:        p.ID,
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	})
+}
+
+// GetListWithContext will retrieve a list of principals using filters (i.e. "type", "member", "status")
+func (s *PrincipalService) GetListWithContext(ctx context.Context, options *FilterOptions) (*SearchResultPrincipal, *Response, error) {
+	u := url.URL{
+		Path: "api/v3/principals",
+	}
+
+	objList, resp, err := GetListWithContext(ctx, s, u.String(), options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return objList.(*SearchResultPrincipal), resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *PrincipalService) GetList(options *FilterOptions) (*SearchResultPrincipal, *Response, error) {
+	return s.GetListWithContext(context.Background(), options)
+}
+
+// ResolveWithContext retrieves the principal a link points to, i.e. the assignee of a work package.
+// The link may target a user, a group or a placeholder user.
+func (s *PrincipalService) ResolveWithContext(ctx context.Context, href string) (*Principal, *Response, error) {
+	if !isPrincipalHref(href) {
+		return nil, nil, fmt.Errorf("%q does not point to a principal", href)
+	}
+	Obj, Resp, err := GetWithContext(ctx, s, href)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Principal), Resp, err
+}
+
+// Resolve wraps ResolveWithContext using the background context.
+func (s *PrincipalService) Resolve(href string) (*Principal, *Response, error) {
+	return s.ResolveWithContext(context.Background(), href)
+}
+
+// isPrincipalHref reports whether an href points to one of the principal endpoints
+func isPrincipalHref(href string) bool {
+	path := strings.TrimLeft(href, "/")
+	for _, prefix := range []string{"api/v3/users/", "api/v3/groups/", "api/v3/placeholder_users/"} {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package openproject
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestPrincipalService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/principals"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-principals-no-filters.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	principals, resp, err := testClient.Principal.GetList(nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if principals == nil || resp.Total != 3 {
+		t.Error("Expected 3 principals within the list")
+		return
+	}
+
+	elements := principals.Embedded.Elements
+	if elements[0].User == nil || elements[0].User.LastName != "Boira" {
+		t.Errorf("Expected first principal decoded as user, got %+v", elements[0])
+	}
+	if elements[1].Group == nil || len(elements[1].Group.Links.Members) != 1 {
+		t.Errorf("Expected second principal decoded as group, got %+v", elements[1])
+	}
+	if elements[2].PlaceholderUser == nil || elements[2].PlaceholderUser.Name != "Backend dev #2" {
+		t.Errorf("Expected third principal to be a placeholder user, got %+v", elements[2])
+	}
+}
+
+func TestPrincipalService_Resolve(t *testing.T) {
+	setup()
+	defer teardown()
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-group.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/groups/9", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/groups/9")
+		fmt.Fprint(w, string(raw))
+	})
+
+	assignee, _, err := testClient.Principal.Resolve("/api/v3/groups/9")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if assignee == nil || assignee.Group == nil || assignee.Group.Name != "Backend team" {
+		t.Errorf("Expected assignee resolved as group, got %+v", assignee)
+	}
+
+	if _, _, err := testClient.Principal.Resolve("/api/v3/statuses/1"); err == nil {
+		t.Error("Expected an error resolving a link which is not a principal")
+	}
+}
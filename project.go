@@ -2,9 +2,7 @@ package openproject
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 )
 
 // ProjectService handles projects for the OpenProject instance / API.
@@ -47,6 +45,9 @@ type ProjDescription OPGenericDescription
 func (s *ProjectService) GetWithContext(ctx context.Context, projectID string) (*Project, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/projects/%s", projectID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*Project), Resp, err
 }
 
@@ -65,33 +66,21 @@ func (s *ProjectService) GetList() (*SearchResultProject, *Response, error) {
 func (s *ProjectService) GetListWithContext(ctx context.Context) (*SearchResultProject, *Response, error) {
 	apiEndpoint := "api/v3/projects"
 	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*SearchResultProject), Resp, err
 }
 
 // CreateWithContext creates a project from a JSON representation.
 func (s *ProjectService) CreateWithContext(ctx context.Context, project *Project) (*Project, *Response, error) {
 	apiEndpoint := "api/v3/projects"
-	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, project)
-	if err != nil {
-		return nil, nil, err
-	}
-	resp, err := s.client.Do(req, nil)
+	Obj, Resp, err := CreateWithContext(ctx, s, apiEndpoint, project)
 	if err != nil {
 		// incase of error return the resp for further inspection
-		return nil, resp, err
+		return nil, Resp, err
 	}
-
-	projResponse := new(Project)
-	defer resp.Body.Close()
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, resp, fmt.Errorf("could not read the returned data")
-	}
-	err = json.Unmarshal(data, projResponse)
-	if err != nil {
-		return nil, resp, fmt.Errorf("could not unmarshall the data into struct")
-	}
-	return projResponse, resp, nil
+	return Obj.(*Project), Resp, err
 }
 
 // Create wraps CreateWithContext using the background context.
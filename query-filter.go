@@ -0,0 +1,289 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Constants to represent the paths of query filters and operators
+const (
+	queryFilterPath   = "/api/v3/queries/filters/"
+	queryOperatorPath = "/api/v3/queries/operators/"
+)
+
+// queryFilterResources maps the filters whose values are resources to the path of those resources.
+// Values of any other filter are plain values (i.e. texts, dates or numbers of days)
+var queryFilterResources = map[string]string{
+	"assignee":       "/api/v3/users/",
+	"assignedToRole": "/api/v3/roles/",
+	"author":         "/api/v3/users/",
+	"category":       "/api/v3/categories/",
+	"memberOfGroup":  "/api/v3/groups/",
+	"priority":       "/api/v3/priorities/",
+	"project":        "/api/v3/projects/",
+	"responsible":    "/api/v3/users/",
+	"status":         "/api/v3/statuses/",
+	"type":           "/api/v3/types/",
+	"version":        "/api/v3/versions/",
+	"watcher":        "/api/v3/users/",
+}
+
+// SearchResultQueryFilterInstanceSchema represent a list of query filter instance schemas
+type SearchResultQueryFilterInstanceSchema struct {
+	Embedded queryFilterInstanceSchemaElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int                               `json:"total" structs:"total"`
+	Count    int                               `json:"count" structs:"count"`
+}
+
+// queryFilterInstanceSchemaElements represent elements within SearchResultQueryFilterInstanceSchema
+type queryFilterInstanceSchemaElements struct {
+	Elements []QueryFilterInstanceSchema `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// QueryFilterInstanceSchema describes how a filter can be used within a query:
+// the operators it accepts and, for each operator, the values it expects
+type QueryFilterInstanceSchema struct {
+	Type         string                          `json:"_type,omitempty" structs:"_type,omitempty"`
+	Name         *QueryFilterSchemaField         `json:"name,omitempty" structs:"name,omitempty"`
+	Filter       *QueryFilterSchemaField         `json:"filter,omitempty" structs:"filter,omitempty"`
+	Dependencies []QueryFilterSchemaDependency   `json:"_dependencies,omitempty" structs:"_dependencies,omitempty"`
+	Links        *QueryFilterInstanceSchemaLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryFilterInstanceSchemaLinks are QueryFilterInstanceSchema Links
+type QueryFilterInstanceSchemaLinks struct {
+	Self   *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Filter *OPGenericLink `json:"filter,omitempty" structs:"filter,omitempty"`
+}
+
+// QueryFilterSchemaDependency lists the fields depending on another one, i.e. the values depending on the operator.
+// Dependencies is indexed by the href of the operator
+type QueryFilterSchemaDependency struct {
+	Type         string                                       `json:"_type,omitempty" structs:"_type,omitempty"`
+	On           string                                       `json:"on,omitempty" structs:"on,omitempty"`
+	Dependencies map[string]map[string]QueryFilterSchemaField `json:"dependencies,omitempty" structs:"dependencies,omitempty"`
+}
+
+// QueryFilterSchemaField describes a field of a query filter, i.e. its values type "[]Status" or "[1]Integer"
+type QueryFilterSchemaField struct {
+	Type       string                       `json:"type,omitempty" structs:"type,omitempty"`
+	Name       string                       `json:"name,omitempty" structs:"name,omitempty"`
+	Required   bool                         `json:"required,omitempty" structs:"required,omitempty"`
+	HasDefault bool                         `json:"hasDefault,omitempty" structs:"hasDefault,omitempty"`
+	Writable   bool                         `json:"writable,omitempty" structs:"writable,omitempty"`
+	Visibility string                       `json:"visibility,omitempty" structs:"visibility,omitempty"`
+	Links      *QueryFilterSchemaFieldLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryFilterSchemaFieldLinks are QueryFilterSchemaField Links
+// AllowedValues points to the resources the field accepts, i.e. "/api/v3/statuses"
+type QueryFilterSchemaFieldLinks struct {
+	AllowedValues QueryFilterAllowedValues `json:"allowedValues,omitempty" structs:"allowedValues,omitempty"`
+}
+
+// QueryFilterAllowedValues is a list of links to allowed values.
+// OpenProject renders it either as a single link to a collection or as a list of links
+type QueryFilterAllowedValues []OPGenericLink
+
+// UnmarshalJSON decodes allowed values given either as a single link or as a list of links
+func (a *QueryFilterAllowedValues) UnmarshalJSON(b []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "[") {
+		var links []OPGenericLink
+		if err := json.Unmarshal(b, &links); err != nil {
+			return err
+		}
+		*a = links
+		return nil
+	}
+
+	link := OPGenericLink{}
+	if err := json.Unmarshal(b, &link); err != nil {
+		return err
+	}
+	*a = QueryFilterAllowedValues{link}
+	return nil
+}
+
+// FilterName returns the name of the filter described by the schema, i.e. "status"
+func (s *QueryFilterInstanceSchema) FilterName() string {
+	if s.Links == nil || s.Links.Filter == nil {
+		return ""
+	}
+	return hrefID(s.Links.Filter.Href)
+}
+
+// Operators returns the operators the filter accepts
+func (s *QueryFilterInstanceSchema) Operators() []SearchOperator {
+	operators := make([]SearchOperator, 0)
+	for _, dependency := range s.Dependencies {
+		if dependency.On != "operator" {
+			continue
+		}
+		for href := range dependency.Dependencies {
+			if operator, ok := parseOperator(hrefID(href)); ok {
+				operators = append(operators, operator)
+			}
+		}
+	}
+	sort.Slice(operators, func(i, j int) bool { return operators[i] < operators[j] })
+	return operators
+}
+
+// ValuesSchema returns the schema of the values expected by the filter when used with operator.
+// ok is false if the filter does not accept the operator, and values is nil if the operator takes no values
+func (s *QueryFilterInstanceSchema) ValuesSchema(operator SearchOperator) (values *QueryFilterSchemaField, ok bool) {
+	symbol := interpretOperator(operator)
+	for _, dependency := range s.Dependencies {
+		if dependency.On != "operator" {
+			continue
+		}
+		for href, fields := range dependency.Dependencies {
+			if hrefID(href) != symbol {
+				continue
+			}
+			if field, found := fields["values"]; found {
+				return &field, true
+			}
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// GetFilterInstanceSchemasWithContext retrieves the schemas of every filter available within queries.
+// If projectID is given, filters available only within that project (i.e. its custom fields) are included
+func (s *QueryService) GetFilterInstanceSchemasWithContext(ctx context.Context, projectID string) (*SearchResultQueryFilterInstanceSchema, *Response, error) {
+	apiEndpoint := "api/v3/queries/filter_instance_schemas"
+	if projectID != "" {
+		apiEndpoint = fmt.Sprintf("api/v3/projects/%s/queries/filter_instance_schemas", projectID)
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schemas := new(SearchResultQueryFilterInstanceSchema)
+	resp, err := s.client.Do(req, schemas)
+	if err != nil {
+		return nil, resp, err
+	}
+	return schemas, resp, nil
+}
+
+// GetFilterInstanceSchemas wraps GetFilterInstanceSchemasWithContext using the background context.
+func (s *QueryService) GetFilterInstanceSchemas(projectID string) (*SearchResultQueryFilterInstanceSchema, *Response, error) {
+	return s.GetFilterInstanceSchemasWithContext(context.Background(), projectID)
+}
+
+// GetFilterInstanceSchemaWithContext retrieves the schema of a single filter by its name, i.e. "status"
+func (s *QueryService) GetFilterInstanceSchemaWithContext(ctx context.Context, filterName string) (*QueryFilterInstanceSchema, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/filter_instance_schemas/%s", filterName)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema := new(QueryFilterInstanceSchema)
+	resp, err := s.client.Do(req, schema)
+	if err != nil {
+		return nil, resp, err
+	}
+	return schema, resp, nil
+}
+
+// GetFilterInstanceSchema wraps GetFilterInstanceSchemaWithContext using the background context.
+func (s *QueryService) GetFilterInstanceSchema(filterName string) (*QueryFilterInstanceSchema, *Response, error) {
+	return s.GetFilterInstanceSchemaWithContext(context.Background(), filterName)
+}
+
+// FilterOptions converts the filters and sort criteria of a saved query into FilterOptions,
+// so the query can be reproduced (and tweaked) with GetList of work packages.
+// Values pointing to resources are converted into their IDs, i.e. "/api/v3/statuses/7" into "7"
+func (q *Query) FilterOptions() (*FilterOptions, error) {
+	options := &FilterOptions{Fields: make([]OptionsFields, 0, len(q.Filters))}
+	for _, filter := range q.Filters {
+		if filter.Links == nil || filter.Links.Filter == nil || filter.Links.Filter.Href == "" {
+			return nil, fmt.Errorf("filter %q has no filter link", filter.Name)
+		}
+		field := hrefID(filter.Links.Filter.Href)
+
+		operator := Equal
+		if filter.Links.Operator != nil {
+			symbol := hrefID(filter.Links.Operator.Href)
+			op, ok := parseOperator(symbol)
+			if !ok {
+				return nil, fmt.Errorf("filter %q uses unknown operator %q", field, symbol)
+			}
+			operator = op
+		}
+
+		values := make([]string, 0)
+		values = append(values, filter.Values...)
+		for _, link := range filter.Links.Values {
+			if link.Href != "" {
+				values = append(values, hrefID(link.Href))
+			}
+		}
+
+		options.Fields = append(options.Fields, OptionsFields{
+			Field:    field,
+			Operator: operator,
+			Values:   values,
+		})
+	}
+
+	// Sort criteria are links like "/api/v3/queries/sort_bys/updatedAt-desc"
+	if q.Links != nil {
+		for _, link := range q.Links.SortBy {
+			criteria := hrefID(link.Href)
+			i := strings.LastIndex(criteria, "-")
+			if i <= 0 {
+				return nil, fmt.Errorf("unexpected sort criteria %q", link.Href)
+			}
+			options.SortBy = append(options.SortBy, SortOption{Field: criteria[:i], Descending: criteria[i+1:] == "desc"})
+		}
+	}
+	return options, nil
+}
+
+// QueryFilters converts FilterOptions into filters to be saved within a query.
+// Values of filters pointing to resources (i.e. status, assignee or type) are rendered as links,
+// "me" included, and values of any other filter as plain values
+func (fops *FilterOptions) QueryFilters() []QueryFilter {
+	filters := make([]QueryFilter, 0, len(fops.Fields))
+	for _, field := range fops.Fields {
+		filter := QueryFilter{
+			Links: &QueryFilterLinks{
+				Filter:   &OPGenericLink{Href: queryFilterPath + field.Field},
+				Operator: &OPGenericLink{Href: queryOperatorPath + url.PathEscape(interpretOperator(field.Operator))},
+				Values:   make([]OPGenericLink, 0),
+			},
+		}
+
+		values := field.filterValues()
+		if resourcePath, ok := queryFilterResources[field.Field]; ok {
+			for _, value := range values {
+				filter.Links.Values = append(filter.Links.Values, OPGenericLink{Href: resourcePath + value})
+			}
+		} else {
+			filter.Values = values
+		}
+
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// hrefID returns the last (unescaped) segment of an href, i.e. "7" for "/api/v3/statuses/7"
+func hrefID(href string) string {
+	id := path.Base(href)
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		return unescaped
+	}
+	return id
+}
@@ -0,0 +1,123 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestQueryService_GetFilterInstanceSchema(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/queries/filter_instance_schemas/status"
+	raw, err := ioutil.ReadFile("./mocks/get/get-query-filter-instance-schema.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	schema, _, err := testClient.Query.GetFilterInstanceSchema("status")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+		return
+	}
+	if schema.FilterName() != "status" {
+		t.Errorf("Expected filter status, %q given", schema.FilterName())
+	}
+	if ops := schema.Operators(); !reflect.DeepEqual(ops, []SearchOperator{Equal, Not, Open, Closed, All}) {
+		t.Errorf("Unexpected operators %v", ops)
+	}
+	if values, ok := schema.ValuesSchema(Equal); !ok || values == nil || values.Type != "[]Status" ||
+		len(values.Links.AllowedValues) != 1 || values.Links.AllowedValues[0].Href != "/api/v3/statuses" {
+		t.Errorf("Unexpected values schema for '=': %+v", values)
+	}
+	if values, ok := schema.ValuesSchema(Open); !ok || values != nil {
+		t.Errorf("Operator 'o' should be accepted without values, %+v given", values)
+	}
+	if _, ok := schema.ValuesSchema(Like); ok {
+		t.Error("Operator '~' should not be accepted")
+	}
+	if len(schema.Filter.Links.AllowedValues) != 1 {
+		t.Error("Expected allowed values given as list of links")
+	}
+}
+
+func TestQueryService_GetFilterInstanceSchemas(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query-filter-instance-schema.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/projects/demo-project/queries/filter_instance_schemas", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"_type":"Collection","total":1,"count":1,"_embedded":{"elements":[%s]}}`, raw)
+	})
+
+	schemas, _, err := testClient.Query.GetFilterInstanceSchemas("demo-project")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+		return
+	}
+	if schemas.Total != 1 || len(schemas.Embedded.Elements) != 1 {
+		t.Errorf("Expected 1 schema, %d given", len(schemas.Embedded.Elements))
+	}
+}
+
+func TestQuery_FilterOptions(t *testing.T) {
+	raw := `[
+		{"_type":"StatusQueryFilter","name":"Status","_links":{"filter":{"href":"/api/v3/queries/filters/status"},"operator":{"href":"/api/v3/queries/operators/="},"values":[{"href":"/api/v3/statuses/1"},{"href":"/api/v3/statuses/7"}]}},
+		{"_type":"AssigneeQueryFilter","name":"Assignee","_links":{"filter":{"href":"/api/v3/queries/filters/assignee"},"operator":{"href":"/api/v3/queries/operators/="},"values":[{"href":"/api/v3/users/me"}]}},
+		{"_type":"UpdatedAtQueryFilter","name":"Updated on","values":["7"],"_links":{"filter":{"href":"/api/v3/queries/filters/updatedAt"},"operator":{"href":"/api/v3/queries/operators/%3Et-"},"values":[]}}
+	]`
+	query := new(Query)
+	if err := json.Unmarshal([]byte(raw), &query.Filters); err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := query.FilterOptions()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	expected := []OptionsFields{
+		{Field: "status", Operator: Equal, Values: []string{"1", "7"}},
+		{Field: "assignee", Operator: Equal, Values: []string{"me"}},
+		{Field: "updatedAt", Operator: LessThanDaysAgo, Values: []string{"7"}},
+	}
+	if !reflect.DeepEqual(options.Fields, expected) {
+		t.Errorf("Unexpected options %+v", options.Fields)
+	}
+
+	// Back to query filters
+	filters := options.QueryFilters()
+	if len(filters) != 3 {
+		t.Fatalf("Expected 3 filters, %d given", len(filters))
+	}
+	if filters[0].Links.Values[1].Href != "/api/v3/statuses/7" || filters[1].Links.Values[0].Href != "/api/v3/users/me" {
+		t.Errorf("Unexpected resource values %+v %+v", filters[0].Links.Values, filters[1].Links.Values)
+	}
+	if filters[2].Links.Operator.Href != "/api/v3/queries/operators/%3Et-" || !reflect.DeepEqual(filters[2].Values, []string{"7"}) {
+		t.Errorf("Unexpected updatedAt filter %+v", filters[2])
+	}
+
+	// Sort criteria are kept
+	query.Links = &QueryLinks{SortBy: []OPGenericLink{{Href: "/api/v3/queries/sort_bys/updatedAt-desc"}}}
+	if options, err = query.FilterOptions(); err != nil {
+		t.Errorf("Error given: %s", err)
+	} else if !reflect.DeepEqual(options.SortBy, []SortOption{{Field: "updatedAt", Descending: true}}) {
+		t.Errorf("Unexpected sort criteria %+v", options.SortBy)
+	}
+
+	// Unknown operators are reported
+	query.Filters[0].Links.Operator.Href = "/api/v3/queries/operators/unknown"
+	if _, err := query.FilterOptions(); err == nil {
+		t.Error("Expected error for unknown operator")
+	}
+}
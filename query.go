@@ -5,51 +5,141 @@ import (
 	"fmt"
 )
 
-// QueryService handles statuses from the OpenProject instance / API.
+// QueryService handles queries from the OpenProject instance / API.
 type QueryService struct {
 	client *Client
 }
 
-// SearchResultQuery represent a list of Projects
+// SearchResultQuery represent a list of Queries
 type SearchResultQuery struct {
-	Embedded statusElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
-	Total    int            `json:"total" structs:"total"`
-	Count    int            `json:"count" structs:"count"`
-	PageSize int            `json:"pageSize" structs:"pageSize"`
-	Offset   int            `json:"offset" structs:"offset"`
+	Embedded QueryElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int           `json:"total" structs:"total"`
+	Count    int           `json:"count" structs:"count"`
+	PageSize int           `json:"pageSize" structs:"pageSize"`
+	Offset   int           `json:"offset" structs:"offset"`
 }
 
 // QueryElements array of elements within a query
 type QueryElements struct {
-	Elements []Status `json:"elements,omitempty" structs:"elements,omitempty"`
+	Elements []Query `json:"elements,omitempty" structs:"elements,omitempty"`
 }
 
 // Query is the object representing OpenProject queries.
-// TODO: Complete fields (i.e. timelineVisible, showHierarchies, timelineZoomLevel, showHierarchies, etc...
+// Columns, sort criteria, grouping and project of the query are set through its Links
 type Query struct {
-	Type      string        `json:"_type,omitempty" structs:"_type,omitempty"`
-	Starred   bool          `json:"starred,omitempty" structs:"starred,omitempty"`
-	ID        int           `json:"id,omitempty" structs:"id,omitempty"`
-	Name      string        `json:"name,omitempty" structs:"name,omitempty"`
-	CreatedAt *Time         `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
-	UpdatedAt *Time         `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
-	Filters   []QueryFilter `json:"filters,omitempty" structs:"filters,omitempty"`
-	Sums      bool          `json:"sums,omitempty" structs:"sums,omitempty"`
-	Public    bool          `json:"public,omitempty" structs:"public,omitempty"`
-	Hidden    bool          `json:"hidden,omitempty" structs:"hidden,omitempty"`
+	Type              string            `json:"_type,omitempty" structs:"_type,omitempty"`
+	Starred           bool              `json:"starred,omitempty" structs:"starred,omitempty"`
+	ID                int               `json:"id,omitempty" structs:"id,omitempty"`
+	Name              string            `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt         *Time             `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt         *Time             `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Filters           []QueryFilter     `json:"filters,omitempty" structs:"filters,omitempty"`
+	Sums              bool              `json:"sums,omitempty" structs:"sums,omitempty"`
+	Public            bool              `json:"public,omitempty" structs:"public,omitempty"`
+	Hidden            bool              `json:"hidden,omitempty" structs:"hidden,omitempty"`
+	TimelineVisible   bool              `json:"timelineVisible,omitempty" structs:"timelineVisible,omitempty"`
+	ShowHierarchies   bool              `json:"showHierarchies,omitempty" structs:"showHierarchies,omitempty"`
+	TimelineZoomLevel string            `json:"timelineZoomLevel,omitempty" structs:"timelineZoomLevel,omitempty"`
+	TimelineLabels    map[string]string `json:"timelineLabels,omitempty" structs:"timelineLabels,omitempty"`
+	HighlightingMode  string            `json:"highlightingMode,omitempty" structs:"highlightingMode,omitempty"`
+	Embedded          *QueryEmbedded    `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links             *QueryLinks       `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryEmbedded wraps embedded fields of Query
+// Results holds the work packages matching the query
+type QueryEmbedded struct {
+	Results *SearchResultWP `json:"results,omitempty" structs:"results,omitempty"`
+}
+
+// QueryLinks are Query Links
+// Columns are links like "/api/v3/queries/columns/subject", SortBy like "/api/v3/queries/sort_bys/id-asc"
+// and GroupBy like "/api/v3/queries/group_bys/status"
+type QueryLinks struct {
+	Self              *OPGenericLink  `json:"self,omitempty" structs:"self,omitempty"`
+	Project           *OPGenericLink  `json:"project,omitempty" structs:"project,omitempty"`
+	User              *OPGenericLink  `json:"user,omitempty" structs:"user,omitempty"`
+	Results           *OPGenericLink  `json:"results,omitempty" structs:"results,omitempty"`
+	Star              *OPGenericLink  `json:"star,omitempty" structs:"star,omitempty"`
+	Unstar            *OPGenericLink  `json:"unstar,omitempty" structs:"unstar,omitempty"`
+	Update            *OPGenericLink  `json:"update,omitempty" structs:"update,omitempty"`
+	UpdateImmediately *OPGenericLink  `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Delete            *OPGenericLink  `json:"delete,omitempty" structs:"delete,omitempty"`
+	Columns           []OPGenericLink `json:"columns,omitempty" structs:"columns,omitempty"`
+	SortBy            []OPGenericLink `json:"sortBy,omitempty" structs:"sortBy,omitempty"`
+	GroupBy           *OPGenericLink  `json:"groupBy,omitempty" structs:"groupBy,omitempty"`
+}
+
+// QueryPayload holds the writable attributes of a query sent by QueryService.Create, Update and Form
+// Empty fields are left unchanged, flags are pointers so that they can be switched off as well as on
+type QueryPayload struct {
+	Name              string             `json:"name,omitempty" structs:"name,omitempty"`
+	Filters           []QueryFilter      `json:"filters,omitempty" structs:"filters,omitempty"`
+	Starred           *bool              `json:"starred,omitempty" structs:"starred,omitempty"`
+	Sums              *bool              `json:"sums,omitempty" structs:"sums,omitempty"`
+	Public            *bool              `json:"public,omitempty" structs:"public,omitempty"`
+	Hidden            *bool              `json:"hidden,omitempty" structs:"hidden,omitempty"`
+	TimelineVisible   *bool              `json:"timelineVisible,omitempty" structs:"timelineVisible,omitempty"`
+	ShowHierarchies   *bool              `json:"showHierarchies,omitempty" structs:"showHierarchies,omitempty"`
+	TimelineZoomLevel string             `json:"timelineZoomLevel,omitempty" structs:"timelineZoomLevel,omitempty"`
+	TimelineLabels    map[string]string  `json:"timelineLabels,omitempty" structs:"timelineLabels,omitempty"`
+	HighlightingMode  string             `json:"highlightingMode,omitempty" structs:"highlightingMode,omitempty"`
+	Links             *QueryPayloadLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryPayloadLinks are the writable QueryPayload Links, see QueryLinks
+type QueryPayloadLinks struct {
+	Project *OPGenericLink  `json:"project,omitempty" structs:"project,omitempty"`
+	Columns []OPGenericLink `json:"columns,omitempty" structs:"columns,omitempty"`
+	SortBy  []OPGenericLink `json:"sortBy,omitempty" structs:"sortBy,omitempty"`
+	GroupBy *OPGenericLink  `json:"groupBy,omitempty" structs:"groupBy,omitempty"`
 }
 
 // QueryFilter filters within a query
-// TODO:Complete fields ( i.e. _links)
+// Values holds plain values (i.e. texts, dates or numbers of days) whereas values pointing to
+// resources (i.e. statuses or users) are links within Links.Values
 type QueryFilter struct {
-	Type string `json:"_type,omitempty" structs:"_type,omitempty"`
-	Name string `json:"name,omitempty" structs:"name,omitempty"`
+	Type   string            `json:"_type,omitempty" structs:"_type,omitempty"`
+	Name   string            `json:"name,omitempty" structs:"name,omitempty"`
+	Values []string          `json:"values,omitempty" structs:"values,omitempty"`
+	Links  *QueryFilterLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// QueryFilterLinks are QueryFilter Links
+// Filter is a link like "/api/v3/queries/filters/status" and Operator like "/api/v3/queries/operators/o"
+type QueryFilterLinks struct {
+	Schema   *OPGenericLink  `json:"schema,omitempty" structs:"schema,omitempty"`
+	Filter   *OPGenericLink  `json:"filter,omitempty" structs:"filter,omitempty"`
+	Operator *OPGenericLink  `json:"operator,omitempty" structs:"operator,omitempty"`
+	Values   []OPGenericLink `json:"values,omitempty" structs:"values,omitempty"`
+}
+
+// QueryForm represents Query form
+// The form validates a query without saving it, and returns the resulting payload and validation errors
+type QueryForm struct {
+	Type     string            `json:"_type,omitempty" structs:"_type,omitempty"`
+	Embedded QueryFormEmbedded `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+}
+
+// QueryFormEmbedded represents the 'embedded' struct nested in 'form'
+type QueryFormEmbedded struct {
+	Payload          *Query                 `json:"payload,omitempty" structs:"payload,omitempty"`
+	ValidationErrors map[string]interface{} `json:"validationErrors,omitempty" structs:"validationErrors,omitempty"`
+}
+
+// QueryResultsOptions specifies the page of results to retrieve when executing a query
+type QueryResultsOptions struct {
+	Offset   int `url:"offset,omitempty"`
+	PageSize int `url:"pageSize,omitempty"`
 }
 
 // GetWithContext gets query info from OpenProject using its query ID
 func (s *QueryService) GetWithContext(ctx context.Context, queryID string) (*Query, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/queries/%s", queryID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*Query), Resp, err
 }
 
@@ -63,14 +153,141 @@ func (s *QueryService) GetList() (*SearchResultQuery, *Response, error) {
 	return s.GetListWithContext(context.Background())
 }
 
-// GetListWithContext Retrieve status list with context
+// GetListWithContext Retrieve query list with context
 // TODO: Implement search parameters-options
 func (s *QueryService) GetListWithContext(ctx context.Context) (*SearchResultQuery, *Response, error) {
 	apiEndpoint := "api/v3/queries"
 	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*SearchResultQuery), Resp, err
 }
 
+// CreateWithContext creates a query with its filters, columns, sort criteria, grouping and timeline settings
+func (s *QueryService) CreateWithContext(ctx context.Context, query *QueryPayload) (*Query, *Response, error) {
+	apiEndpoint := "api/v3/queries"
+	Obj, Resp, err := CreateWithContext(ctx, s, apiEndpoint, query)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Query), Resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *QueryService) Create(query *QueryPayload) (*Query, *Response, error) {
+	return s.CreateWithContext(context.Background(), query)
+}
+
+// UpdateWithContext updates a query (PATCH). Only non-empty fields are sent
+func (s *QueryService) UpdateWithContext(ctx context.Context, queryID string, query *QueryPayload) (*Query, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s", queryID)
+	Obj, Resp, err := UpdateWithContext(ctx, s, apiEndpoint, query)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Query), Resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *QueryService) Update(queryID string, query *QueryPayload) (*Query, *Response, error) {
+	return s.UpdateWithContext(context.Background(), queryID, query)
+}
+
+// StarWithContext stars a query, so it is shown as favorite in the sidebar
+func (s *QueryService) StarWithContext(ctx context.Context, queryID string) (*Query, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s/star", queryID)
+	Obj, Resp, err := UpdateWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Query), Resp, err
+}
+
+// Star wraps StarWithContext using the background context.
+func (s *QueryService) Star(queryID string) (*Query, *Response, error) {
+	return s.StarWithContext(context.Background(), queryID)
+}
+
+// UnstarWithContext removes the star of a query
+func (s *QueryService) UnstarWithContext(ctx context.Context, queryID string) (*Query, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/queries/%s/unstar", queryID)
+	Obj, Resp, err := UpdateWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Query), Resp, err
+}
+
+// Unstar wraps UnstarWithContext using the background context.
+func (s *QueryService) Unstar(queryID string) (*Query, *Response, error) {
+	return s.UnstarWithContext(context.Background(), queryID)
+}
+
+// FormWithContext validates a query through the query form without saving it
+func (s *QueryService) FormWithContext(ctx context.Context, query *QueryPayload) (*QueryForm, *Response, error) {
+	apiEndpoint := "api/v3/queries/form"
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	form := new(QueryForm)
+	resp, err := s.client.Do(req, form)
+	if err != nil {
+		return nil, resp, err
+	}
+	return form, resp, nil
+}
+
+// Form wraps FormWithContext using the background context.
+func (s *QueryService) Form(query *QueryPayload) (*QueryForm, *Response, error) {
+	return s.FormWithContext(context.Background(), query)
+}
+
+// GetDefaultWithContext gets the default query of a project, or the global one if projectID is empty
+func (s *QueryService) GetDefaultWithContext(ctx context.Context, projectID string) (*Query, *Response, error) {
+	apiEndpoint := "api/v3/queries/default"
+	if projectID != "" {
+		apiEndpoint = fmt.Sprintf("api/v3/projects/%s/queries/default", projectID)
+	}
+	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*Query), Resp, err
+}
+
+// GetDefault wraps GetDefaultWithContext using the background context.
+func (s *QueryService) GetDefault(projectID string) (*Query, *Response, error) {
+	return s.GetDefaultWithContext(context.Background(), projectID)
+}
+
+// GetResultsWithContext executes a saved query and returns the page of matching work packages.
+// Filters, sort criteria and grouping are the ones saved within the query
+func (s *QueryService) GetResultsWithContext(ctx context.Context, queryID string, options *QueryResultsOptions) (*SearchResultWP, *Response, error) {
+	apiEndpoint, err := addOptions(fmt.Sprintf("api/v3/queries/%s", queryID), options)
+	if err != nil {
+		return nil, nil, err
+	}
+	Obj, resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, resp, err
+	}
+	query := Obj.(*Query)
+	if query.Embedded == nil || query.Embedded.Results == nil {
+		return nil, resp, fmt.Errorf("query %s returned no results", queryID)
+	}
+
+	resp.populatePageValues(query.Embedded.Results)
+	return query.Embedded.Results, resp, nil
+}
+
+// GetResults wraps GetResultsWithContext using the background context.
+func (s *QueryService) GetResults(queryID string, options *QueryResultsOptions) (*SearchResultWP, *Response, error) {
+	return s.GetResultsWithContext(context.Background(), queryID, options)
+}
+
 // DeleteWithContext will delete a single query object
 func (s *QueryService) DeleteWithContext(ctx context.Context, queryID string) (*Response, error) {
 	apiEndPoint := fmt.Sprintf("api/v3/queries/%s", queryID)
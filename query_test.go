@@ -1,9 +1,11 @@
 package openproject
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -80,3 +82,180 @@ func TestQueryService_Delete(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	}
 }
+
+func TestQueryService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/queries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/api/v3/queries")
+
+		body := new(Query)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Error(err.Error())
+		}
+		if body.Name != "Project plan" || len(body.Links.Columns) != 2 || body.Links.GroupBy.Href != "/api/v3/queries/group_bys/status" {
+			t.Errorf("Unexpected query sent %+v", body)
+		}
+		if len(body.Filters) != 1 || body.Filters[0].Links.Operator.Href != "/api/v3/queries/operators/o" {
+			t.Errorf("Unexpected query filters sent %+v", body.Filters)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	timelineVisible := true
+	q := &QueryPayload{
+		Name:            "Project plan",
+		TimelineVisible: &timelineVisible,
+		Filters: []QueryFilter{
+			{
+				Links: &QueryFilterLinks{
+					Filter:   &OPGenericLink{Href: "/api/v3/queries/filters/status"},
+					Operator: &OPGenericLink{Href: "/api/v3/queries/operators/o"},
+				},
+			},
+		},
+		Links: &QueryPayloadLinks{
+			Project: &OPGenericLink{Href: "/api/v3/projects/1"},
+			Columns: []OPGenericLink{
+				{Href: "/api/v3/queries/columns/id"},
+				{Href: "/api/v3/queries/columns/subject"},
+			},
+			SortBy:  []OPGenericLink{{Href: "/api/v3/queries/sort_bys/id-asc"}},
+			GroupBy: &OPGenericLink{Href: "/api/v3/queries/group_bys/status"},
+		},
+	}
+	query, _, err := testClient.Query.Create(q)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if query == nil || query.ID != 1 {
+		t.Errorf("Unexpected query %+v", query)
+	}
+}
+
+func TestQueryService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/queries/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, "/api/v3/queries/1")
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		want := map[string]interface{}{"name": "Project plan", "public": false}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("Request body: %v, want %v", body, want)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	public := false
+	query, _, err := testClient.Query.Update("1", &QueryPayload{Name: "Project plan", Public: &public})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if query == nil {
+		t.Error("Expected query. Query is nil")
+	}
+}
+
+func TestQueryService_StarUnstar(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	for _, action := range []string{"star", "unstar"} {
+		testMux.HandleFunc("/api/v3/queries/1/"+action, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PATCH")
+			fmt.Fprint(w, string(raw))
+		})
+	}
+
+	if query, _, err := testClient.Query.Star("1"); err != nil || query == nil {
+		t.Errorf("Error given starring the query: %v", err)
+	}
+	if query, _, err := testClient.Query.Unstar("1"); err != nil || query == nil {
+		t.Errorf("Error given unstarring the query: %v", err)
+	}
+}
+
+func TestQueryService_Form(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/queries/form", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/api/v3/queries/form")
+		fmt.Fprint(w, `{"_type":"Form","_embedded":{"payload":{"name":""},"validationErrors":{"name":{"_type":"Error","message":"Name can't be blank."}}}}`)
+	})
+
+	form, _, err := testClient.Query.Form(&QueryPayload{})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if form == nil || form.Embedded.ValidationErrors["name"] == nil {
+		t.Errorf("Expected validation error for name, got %+v", form)
+	}
+}
+
+func TestQueryService_GetDefault(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/projects/demo-project/queries/default", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(raw))
+	})
+	testMux.HandleFunc("/api/v3/queries/default", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(raw))
+	})
+
+	if query, _, err := testClient.Query.GetDefault("demo-project"); err != nil || query == nil {
+		t.Errorf("Error given getting project default query: %v", err)
+	}
+	if query, _, err := testClient.Query.GetDefault(""); err != nil || query == nil {
+		t.Errorf("Error given getting global default query: %v", err)
+	}
+}
+
+func TestQueryService_GetResults(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-query.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/queries/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/api/v3/queries/1?offset=1&pageSize=20")
+		fmt.Fprint(w, string(raw))
+	})
+
+	results, resp, err := testClient.Query.GetResults("1", &QueryResultsOptions{Offset: 1, PageSize: 20})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if results == nil || len(results.Embedded.Elements) != 20 {
+		t.Error("Expected 20 work packages as query results")
+		return
+	}
+	if resp.Total != 21 {
+		t.Errorf("Total should populate with 21, %v given", resp.Total)
+	}
+}
@@ -0,0 +1,211 @@
+package openproject
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit limits the requests sent by the Client, see Client.SetRateLimit.
+// Requests beyond the limits wait until they can be sent, or until their context is done
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests. 0 means no rate limit
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once before being limited to RequestsPerSecond.
+	// It defaults to 1
+	Burst int
+	// MaxInFlight is the number of requests in progress at the same time. A request is in progress until
+	// the body of its response is closed or read to the end. 0 means no limit
+	MaxInFlight int
+}
+
+// rateLimitRule is a RateLimit applying to some requests only, see Client.SetRateLimitFor
+type rateLimitRule struct {
+	method  string
+	pattern string
+	limiter *limiter
+}
+
+// rateLimits are the limits of a Client
+type rateLimits struct {
+	global *limiter
+	rules  []rateLimitRule
+}
+
+// limiter is a token bucket along with a semaphore of requests in flight
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	inFlight chan struct{}
+}
+
+// SetRateLimit limits every request sent by the client. A nil limit removes the limits, which is the default.
+// It must not be called while requests are being sent
+func (c *Client) SetRateLimit(limit *RateLimit) {
+	c.limits.global = newLimiter(limit)
+}
+
+// SetRateLimitFor adds a tighter limit to the requests matching method (any if empty) and pattern,
+// i.e. SetRateLimitFor("POST", "api/v3/*/*/attachments", ...) limits attachment uploads.
+// pattern follows path.Match and is matched against the path of the request relative to the base URL.
+// Matching requests are limited by the first matching rule as well as by the limit of SetRateLimit.
+// It must not be called while requests are being sent
+func (c *Client) SetRateLimitFor(method string, pattern string, limit *RateLimit) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return err
+	}
+	c.limits.rules = append(c.limits.rules, rateLimitRule{
+		method:  strings.ToUpper(method),
+		pattern: strings.TrimLeft(pattern, "/"),
+		limiter: newLimiter(limit),
+	})
+	return nil
+}
+
+// acquireLimits waits until a request is allowed by the limits of the client.
+// release must be called once the response is done with, it can be called more than once
+func (c *Client) acquireLimits(req *http.Request) (release func(), err error) {
+	limiters := make([]*limiter, 0, 2)
+	if rule := c.limits.match(req, c.baseURL.Path); rule != nil && rule.limiter != nil {
+		limiters = append(limiters, rule.limiter)
+	}
+	if c.limits.global != nil {
+		limiters = append(limiters, c.limits.global)
+	}
+
+	releases := make([]func(), 0, len(limiters))
+	release = func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, l := range limiters {
+		r, err := l.acquire(req.Context())
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
+
+// releaseOnClose is a response body releasing the limits of its request once it is closed or read to the end
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// match returns the first rule matching a request
+func (l *rateLimits) match(req *http.Request, basePath string) *rateLimitRule {
+	if len(l.rules) == 0 {
+		return nil
+	}
+	reqPath := strings.TrimLeft(strings.TrimPrefix(req.URL.Path, basePath), "/")
+	for i, rule := range l.rules {
+		if rule.method != "" && rule.method != req.Method {
+			continue
+		}
+		if matched, _ := path.Match(rule.pattern, reqPath); matched || rule.pattern == "" {
+			return &l.rules[i]
+		}
+	}
+	return nil
+}
+
+// newLimiter returns the limiter of a RateLimit, or nil if it does not limit anything
+func newLimiter(limit *RateLimit) *limiter {
+	if limit == nil || (limit.RequestsPerSecond <= 0 && limit.MaxInFlight <= 0) {
+		return nil
+	}
+	l := &limiter{rate: limit.RequestsPerSecond, burst: float64(limit.Burst)}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+	l.last = time.Now()
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// acquire waits for a free slot and then for a token
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-l.inFlight }) }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// wait takes a token from the bucket, waiting for it if the bucket is empty
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// Tokens can go below zero: the token is reserved and the request waits for it to be refilled
+	l.tokens--
+	delay := time.Duration(0)
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
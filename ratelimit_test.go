@@ -0,0 +1,160 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testClient.SetRateLimit(&RateLimit{RequestsPerSecond: 50, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, _, err := testClient.Status.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	// 2 requests at once, then 4 more at 50 per second
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("Expected requests to be limited, %s elapsed", elapsed)
+	}
+}
+
+func TestClient_RateLimit_MaxInFlight(t *testing.T) {
+	setup()
+	defer teardown()
+	var mu sync.Mutex
+	current, max := 0, 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > max {
+			max = current
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		current--
+		mu.Unlock()
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testClient.SetRateLimit(&RateLimit{MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := testClient.Status.Get("1"); err != nil {
+				t.Errorf("Error given: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if max > 2 {
+		t.Errorf("Expected at most 2 requests in flight, %d seen", max)
+	}
+}
+
+func TestClient_RateLimit_MaxInFlight_OpenBody(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/attachments/1/content", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content")
+	})
+	testClient.SetRateLimit(&RateLimit{MaxInFlight: 1})
+
+	req, _ := testClient.NewRequest("GET", "api/v3/attachments/1/content", nil)
+	resp, err := testClient.Download(req)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	// The body left open still holds the only slot
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ = testClient.NewRequestWithContext(ctx, "GET", "api/v3/attachments/1/content", nil)
+	if _, err := testClient.Download(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded while the body is open, %v given", err)
+	}
+
+	resp.Body.Close()
+	req, _ = testClient.NewRequest("GET", "api/v3/attachments/1/content", nil)
+	resp, err = testClient.Download(req)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestClient_RateLimit_ContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testClient.SetRateLimit(&RateLimit{RequestsPerSecond: 0.1})
+
+	if _, _, err := testClient.Status.Get("1"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := testClient.NewRequestWithContext(ctx, "GET", "api/v3/statuses/1", nil)
+	if _, err := testClient.Do(req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded while waiting, %v given", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single request sent, %d sent", calls)
+	}
+}
+
+func TestClient_SetRateLimitFor(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	})
+	testMux.HandleFunc("/api/v3/work_packages/1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Collection","total":0,"count":0,"_embedded":{"elements":[]}}`)
+	})
+	if err := testClient.SetRateLimitFor("GET", "api/v3/*/*/attachments", &RateLimit{RequestsPerSecond: 20}); err != nil {
+		t.Fatal(err)
+	}
+	if err := testClient.SetRateLimitFor("", "[", nil); err == nil {
+		t.Error("Expected error for malformed pattern")
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, _, err := testClient.Status.Get("1"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected other requests not to be limited, %s elapsed", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		req, _ := testClient.NewRequest("GET", "api/v3/work_packages/1/attachments", nil)
+		if _, err := testClient.Do(req, nil); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected attachment requests to be limited, %s elapsed", elapsed)
+	}
+}
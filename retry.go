@@ -0,0 +1,215 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how the Client retries failed requests, see Client.SetRetryPolicy.
+// Requests are retried on transport errors like connection resets and on the statuses of RetryStatuses,
+// waiting an exponential backoff with jitter between attempts, or the delay given by the server
+// within a "Retry-After" header.
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried unless RetryNonIdempotent is set,
+// or the request context comes from WithNonIdempotentRetry.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. 0 disables retries
+	MaxRetries int
+
+	// MinBackoff is the wait before the first retry, doubled on every retry up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryStatuses are the response statuses to retry. 429, 502, 503 and 504 if empty
+	RetryStatuses []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests
+	RetryNonIdempotent bool
+
+	// OnAttempt, if set, is called after every attempt
+	OnAttempt func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes a finished attempt of a request
+type RetryAttempt struct {
+	Request *http.Request
+	// Attempt is 1 for the first attempt
+	Attempt int
+	// Response is nil if the attempt failed with Err
+	Response *http.Response
+	Err      error
+	// Retry tells whether the request is retried, after waiting Wait
+	Retry bool
+	Wait  time.Duration
+}
+
+// defaultRetryStatuses are retried if RetryPolicy.RetryStatuses is empty
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryContextKey is the context key of WithNonIdempotentRetry
+type retryContextKey struct{}
+
+// DefaultRetryPolicy returns a RetryPolicy doing 3 retries, waiting from 500ms up to 30s between attempts
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// SetRetryPolicy sets how failed requests are retried. A nil policy disables retries, which is the default
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retry = policy
+}
+
+// WithNonIdempotentRetry returns a context allowing requests created with it to be retried
+// even if they are not idempotent, i.e. a POST the caller knows is safe to repeat
+func WithNonIdempotentRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, true)
+}
+
+// doWithRetry sends a request, retrying it according to the retry policy of the client
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.retry
+	if policy == nil || policy.MaxRetries <= 0 || !policy.canRetry(req) {
+		return c.send(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.send(req)
+
+		retry := attempt <= policy.MaxRetries && policy.shouldRetry(resp, err)
+		wait := time.Duration(0)
+		if retry {
+			wait = policy.backoff(attempt)
+			if delay, ok := retryAfter(resp); ok {
+				// Do not keep the caller waiting longer than MaxBackoff, give the response back instead
+				wait = delay
+				retry = policy.MaxBackoff <= 0 || delay <= policy.MaxBackoff
+			}
+		}
+
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(RetryAttempt{
+				Request:  req,
+				Attempt:  attempt,
+				Response: resp,
+				Err:      err,
+				Retry:    retry,
+				Wait:     wait,
+			})
+		}
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// canRetry reports whether a request can be sent more than once
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be rewound
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE", "TRACE":
+		return true
+	}
+	if optIn, _ := req.Context().Value(retryContextKey{}).(bool); optIn {
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// shouldRetry reports whether the result of an attempt is worth retrying
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+	}
+
+	statuses := p.RetryStatuses
+	if len(statuses) == 0 {
+		statuses = defaultRetryStatuses
+	}
+	for _, status := range statuses {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the given retry: exponential, capped by MaxBackoff, with jitter
+// so clients failing at the same time do not retry at the same time
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Wait between half and the whole backoff
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// retryAfter returns the delay requested by the server within the "Retry-After" header,
+// given either in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
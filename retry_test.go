@@ -0,0 +1,163 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// httpClientFunc adapts a function to the httpClient interface
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_Retry_Statuses(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+		}
+	})
+
+	attempts := make([]RetryAttempt, 0)
+	testClient.SetRetryPolicy(&RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+		OnAttempt: func(attempt RetryAttempt) {
+			attempts = append(attempts, attempt)
+		},
+	})
+
+	status, _, err := testClient.Status.Get("1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if status.Name != "New" || calls != 3 {
+		t.Errorf("Expected status after 3 calls, %d calls done", calls)
+	}
+	if len(attempts) != 3 || !attempts[0].Retry || !attempts[1].Retry || attempts[1].Wait != 0 || attempts[2].Retry {
+		t.Errorf("Unexpected attempts %+v", attempts)
+	}
+}
+
+func TestClient_Retry_GivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	testClient.SetRetryPolicy(&RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond})
+
+	req, _ := testClient.NewRequest("GET", "api/v3/statuses/1", nil)
+	if _, err := testClient.Do(req, nil); err == nil {
+		t.Error("Expected error")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, %d done", calls)
+	}
+}
+
+func TestClient_Retry_NonIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/api/v3/queries", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"name":"Project plan"`) {
+			t.Errorf("Body not rewound on attempt %d: %s", calls, body)
+		}
+		if calls%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"_type":"Query","id":1,"name":"Project plan"}`)
+	})
+	testClient.SetRetryPolicy(&RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond})
+
+	// POST is not retried by default
+	req, _ := testClient.NewRequest("POST", "api/v3/queries", &QueryPayload{Name: "Project plan"})
+	if _, err := testClient.Do(req, nil); err == nil || calls != 1 {
+		t.Errorf("Expected a single failed call, %d done (%v)", calls, err)
+	}
+
+	// unless the caller opts in
+	calls = 0
+	ctx := WithNonIdempotentRetry(context.Background())
+	if _, _, err := testClient.Query.CreateWithContext(ctx, &QueryPayload{Name: "Project plan"}); err != nil || calls != 2 {
+		t.Errorf("Expected success after 2 calls, %d done (%v)", calls, err)
+	}
+}
+
+func TestClient_Retry_ConnectionReset(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_type":"Status","id":1,"name":"New"}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: syscall.ECONNRESET}
+		}
+		return http.DefaultClient.Do(req)
+	}), server.URL)
+	client.SetRetryPolicy(&RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond})
+
+	if _, _, err := client.Status.Get("1"); err != nil || calls != 2 {
+		t.Errorf("Expected success after 2 calls, %d done (%v)", calls, err)
+	}
+}
+
+func TestClient_Retry_ContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/statuses/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	testClient.SetRetryPolicy(&RetryPolicy{MaxRetries: 5, MinBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	req, _ := testClient.NewRequestWithContext(ctx, "GET", "api/v3/statuses/1", nil)
+	if _, err := testClient.Do(req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, %v given", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Expected retries to stop when the context is done")
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		if wait := policy.backoff(attempt); wait < max/2 || wait > max {
+			t.Errorf("Attempt %d: expected wait between %s and %s, %s given", attempt, max/2, max, wait)
+		}
+	}
+}
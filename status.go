@@ -42,6 +42,9 @@ type Status struct {
 func (s *StatusService) GetWithContext(ctx context.Context, statusID string) (*Status, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/statuses/%s", statusID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*Status), Resp, err
 }
 
@@ -60,5 +63,8 @@ func (s *StatusService) GetList() (*SearchResultStatus, *Response, error) {
 func (s *StatusService) GetListWithContext(ctx context.Context) (*SearchResultStatus, *Response, error) {
 	apiEndpoint := "api/v3/statuses"
 	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*SearchResultStatus), Resp, err
 }
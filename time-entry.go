@@ -0,0 +1,23 @@
+package openproject
+
+// TimeEntry is time spent on a work package or a project.
+// Hours is an ISO 8601 duration, i.e. "PT1H30M"
+type TimeEntry struct {
+	Type      string                `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID        int                   `json:"id,omitempty" structs:"id,omitempty"`
+	Comment   *OPGenericDescription `json:"comment,omitempty" structs:"comment,omitempty"`
+	SpentOn   *Date                 `json:"spentOn,omitempty" structs:"spentOn,omitempty"`
+	Hours     string                `json:"hours,omitempty" structs:"hours,omitempty"`
+	CreatedAt *Time                 `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt *Time                 `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Links     *TimeEntryLinks       `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// TimeEntryLinks are TimeEntry Links
+type TimeEntryLinks struct {
+	Self        *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Project     *OPGenericLink `json:"project,omitempty" structs:"project,omitempty"`
+	WorkPackage *OPGenericLink `json:"workPackage,omitempty" structs:"workPackage,omitempty"`
+	User        *OPGenericLink `json:"user,omitempty" structs:"user,omitempty"`
+	Activity    *OPGenericLink `json:"activity,omitempty" structs:"activity,omitempty"`
+}
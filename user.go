@@ -12,24 +12,61 @@ type UserService struct {
 }
 
 // User is the object representing OpenProject users.
-// TODO: Complete object with fields identityUrl, language, _links
 type User struct {
-	Type      string `json:"_type,omitempty" structs:"_type,omitempty"`
-	ID        int    `json:"id,omitempty" structs:"id,omitempty"`
-	Name      string `json:"name,omitempty" structs:"name,omitempty"`
-	CreatedAt *Time  `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
-	UpdatedAt *Time  `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Type        string     `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID          int        `json:"id,omitempty" structs:"id,omitempty"`
+	Name        string     `json:"name,omitempty" structs:"name,omitempty"`
+	CreatedAt   *Time      `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt   *Time      `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Login       string     `json:"login,omitempty" structs:"login,omitempty"`
+	Admin       bool       `json:"admin,omitempty" structs:"admin,omitempty"`
+	FirstName   string     `json:"firstName,omitempty" structs:"firstName,omitempty"`
+	LastName    string     `json:"lastName,omitempty" structs:"lastName,omitempty"`
+	Email       string     `json:"email,omitempty" structs:"email,omitempty"`
+	Avatar      string     `json:"avatar,omitempty" structs:"avatar,omitempty"`
+	Status      string     `json:"status,omitempty" structs:"status,omitempty"`
+	IdentityURL string     `json:"identityUrl,omitempty" structs:"identityUrl,omitempty"`
+	Language    string     `json:"language,omitempty" structs:"language,omitempty"`
+	Password    string     `json:"password,omitempty" structs:"password,omitempty"`
+	Links       *UserLinks `json:"_links,omitempty" structs:"_links,omitempty"`
+}
+
+// UserUpdate holds the changes sent by UserService.Update. Empty fields are left unchanged,
+// Admin is a pointer so that admin rights can be revoked as well as granted
+type UserUpdate struct {
 	Login     string `json:"login,omitempty" structs:"login,omitempty"`
-	Admin     bool   `json:"admin,omitempty" structs:"admin,omitempty"`
+	Admin     *bool  `json:"admin,omitempty" structs:"admin,omitempty"`
 	FirstName string `json:"firstName,omitempty" structs:"firstName,omitempty"`
-	lastName  string `json:"lastName,omitempty" structs:"lastName,omitempty"`
+	LastName  string `json:"lastName,omitempty" structs:"lastName,omitempty"`
 	Email     string `json:"email,omitempty" structs:"email,omitempty"`
-	Avatar    string `json:"avatar,omitempty" structs:"avatar,omitempty"`
-	Status    string `json:"status,omitempty" structs:"status,omitempty"`
 	Language  string `json:"language,omitempty" structs:"language,omitempty"`
 	Password  string `json:"password,omitempty" structs:"password,omitempty"`
 }
 
+// UserLinks are User Links
+// Action links (lock, unlock, delete...) are only present when the current user is allowed to perform them
+type UserLinks struct {
+	Self              *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Memberships       *OPGenericLink `json:"memberships,omitempty" structs:"memberships,omitempty"`
+	ShowUser          *OPGenericLink `json:"showUser,omitempty" structs:"showUser,omitempty"`
+	UpdateImmediately *OPGenericLink `json:"updateImmediately,omitempty" structs:"updateImmediately,omitempty"`
+	Lock              *OPGenericLink `json:"lock,omitempty" structs:"lock,omitempty"`
+	Unlock            *OPGenericLink `json:"unlock,omitempty" structs:"unlock,omitempty"`
+	Delete            *OPGenericLink `json:"delete,omitempty" structs:"delete,omitempty"`
+}
+
+// Constants to represent OpenProject user statuses
+const (
+	// UserStatusActive is the status of users allowed to log in
+	UserStatusActive = "active"
+	// UserStatusRegistered is the status of users registered but not activated yet
+	UserStatusRegistered = "registered"
+	// UserStatusLocked is the status of users not allowed to log in
+	UserStatusLocked = "locked"
+	// UserStatusInvited is the status of users invited by email who haven't activated their account yet
+	UserStatusInvited = "invited"
+)
+
 // SearchResultUser is a small wrapper around the Search
 type SearchResultUser struct {
 	Embedded searchEmbeddedUser `json:"_embedded" structs:"_embedded"`
@@ -45,10 +82,12 @@ type searchEmbeddedUser struct {
 }
 
 // GetWithContext gets user info from OpenProject using its Account ID
-// TODO: Implement GetList and adapt tests
 func (s *UserService) GetWithContext(ctx context.Context, accountID string) (*User, *Response, error) {
-	apiEndpoint := fmt.Sprintf("api/v3/users?id=%s", accountID)
+	apiEndpoint := fmt.Sprintf("api/v3/users/%s", accountID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*User), Resp, err
 }
 
@@ -64,6 +103,9 @@ func (s *UserService) GetListWithContext(ctx context.Context, options *FilterOpt
 	}
 
 	objList, resp, err := GetListWithContext(ctx, s, u.String(), options)
+	if err != nil {
+		return nil, resp, err
+	}
 	return objList.(*SearchResultUser), resp, err
 }
 
@@ -75,7 +117,10 @@ func (s *UserService) GetList(options *FilterOptions) (*SearchResultUser, *Respo
 // CreateWithContext creates a user from a JSON representation.
 func (s *UserService) CreateWithContext(ctx context.Context, user *User) (*User, *Response, error) {
 	apiEndpoint := "api/v3/users"
-	userResponse, resp, err := CreateWithContext(ctx, s, apiEndpoint)
+	userResponse, resp, err := CreateWithContext(ctx, s, apiEndpoint, user)
+	if err != nil {
+		return nil, resp, err
+	}
 	return userResponse.(*User), resp, err
 }
 
@@ -84,6 +129,80 @@ func (s *UserService) Create(user *User) (*User, *Response, error) {
 	return s.CreateWithContext(context.Background(), user)
 }
 
+// UpdateWithContext updates the writable fields of a user (PATCH). Only the fields set in changes are sent.
+func (s *UserService) UpdateWithContext(ctx context.Context, userID string, changes *UserUpdate) (*User, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/users/%s", userID)
+	userResponse, resp, err := UpdateWithContext(ctx, s, apiEndpoint, changes)
+	if err != nil {
+		return nil, resp, err
+	}
+	return userResponse.(*User), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *UserService) Update(userID string, changes *UserUpdate) (*User, *Response, error) {
+	return s.UpdateWithContext(context.Background(), userID, changes)
+}
+
+// LockWithContext locks a user, so it is not allowed to log in anymore
+func (s *UserService) LockWithContext(ctx context.Context, userID string) (*User, *Response, error) {
+	return s.lockWithContext(ctx, "POST", userID)
+}
+
+// Lock wraps LockWithContext using the background context.
+func (s *UserService) Lock(userID string) (*User, *Response, error) {
+	return s.LockWithContext(context.Background(), userID)
+}
+
+// UnlockWithContext removes the lock of a user
+func (s *UserService) UnlockWithContext(ctx context.Context, userID string) (*User, *Response, error) {
+	return s.lockWithContext(ctx, "DELETE", userID)
+}
+
+// Unlock wraps UnlockWithContext using the background context.
+func (s *UserService) Unlock(userID string) (*User, *Response, error) {
+	return s.UnlockWithContext(context.Background(), userID)
+}
+
+// lockWithContext sets (POST) or removes (DELETE) the lock of a user
+func (s *UserService) lockWithContext(ctx context.Context, method string, userID string) (*User, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/users/%s/lock", userID)
+	req, err := s.client.NewRequestWithContext(ctx, method, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return user, resp, nil
+}
+
+// InviteWithContext invites a user by email. The user is created with status "invited"
+// and OpenProject sends the invitation to activate the account. Email is mandatory, names are optional.
+func (s *UserService) InviteWithContext(ctx context.Context, user *User) (*User, *Response, error) {
+	if user == nil || user.Email == "" {
+		return nil, nil, fmt.Errorf("an email is required to invite a user")
+	}
+	invitation := &User{
+		Login:     user.Login,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Language:  user.Language,
+		Admin:     user.Admin,
+		Status:    UserStatusInvited,
+	}
+	return s.CreateWithContext(ctx, invitation)
+}
+
+// Invite wraps InviteWithContext using the background context.
+func (s *UserService) Invite(user *User) (*User, *Response, error) {
+	return s.InviteWithContext(context.Background(), user)
+}
+
 // DeleteWithContext will delete a single user.
 func (s *UserService) DeleteWithContext(ctx context.Context, userID string) (*Response, error) {
 	apiEndPoint := fmt.Sprintf("api/v3/users/%s", userID)
@@ -1,6 +1,7 @@
 package openproject
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -44,9 +45,9 @@ func TestUserService_GetByID_Success(t *testing.T) {
 	if err != nil {
 		t.Error(err.Error())
 	}
-	testMux.HandleFunc("/api/v3/users", func(w http.ResponseWriter, r *http.Request) {
+	testMux.HandleFunc("/api/v3/users/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
-		testRequestURL(t, r, "/api/v3/users?id=1")
+		testRequestURL(t, r, "/api/v3/users/1")
 
 		fmt.Fprint(w, string(raw))
 	})
@@ -55,6 +56,8 @@ func TestUserService_GetByID_Success(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	} else if user == nil {
 		t.Error("Expected user. User is nil")
+	} else if user.LastName != "Boira" || user.Links == nil || user.Links.Lock == nil {
+		t.Errorf("Expected full user model to be decoded, got %+v", user)
 	}
 }
 
@@ -78,7 +81,7 @@ func TestUserService_Create(t *testing.T) {
 		Login:     "john.smith@acme.com",
 		Admin:     false,
 		FirstName: "John",
-		lastName:  "Smith",
+		LastName:  "Smith",
 		Email:     "john.smith@acme.com",
 		Status:    "active",
 		Language:  "en",
@@ -112,3 +115,139 @@ func TestUserService_Delete(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	}
 }
+
+func TestUserService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/users/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, "/api/v3/users/1")
+
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		if len(body) != 1 || body["language"] != "de" {
+			t.Errorf("Expected only language to be sent, got %v", body)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	user, _, err := testClient.User.Update("1", &UserUpdate{Language: "de"})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if user == nil {
+		t.Error("Expected user. User is nil")
+	}
+}
+
+func TestUserService_Update_RevokeAdmin(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/users/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		if len(body) != 1 || body["admin"] != false {
+			t.Errorf("Expected only admin to be sent as false, got %v", body)
+		}
+		fmt.Fprint(w, `{"_type":"User","id":1,"admin":false}`)
+	})
+
+	admin := false
+	user, _, err := testClient.User.Update("1", &UserUpdate{Admin: &admin})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if user == nil || user.Admin {
+		t.Errorf("Expected the admin rights revoked, got %+v", user)
+	}
+}
+
+func TestUserService_Lock(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/post/post-user-lock.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/users/1/lock", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/api/v3/users/1/lock")
+
+		fmt.Fprint(w, string(raw))
+	})
+
+	user, _, err := testClient.User.Lock("1")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if user == nil || user.Status != UserStatusLocked {
+		t.Errorf("Expected locked user, got %+v", user)
+	}
+}
+
+func TestUserService_Unlock(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/users/1/lock", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testRequestURL(t, r, "/api/v3/users/1/lock")
+
+		fmt.Fprint(w, string(raw))
+	})
+
+	user, _, err := testClient.User.Unlock("1")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if user == nil || user.Status != UserStatusActive {
+		t.Errorf("Expected active user, got %+v", user)
+	}
+}
+
+func TestUserService_Invite(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/post/post-user.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/api/v3/users")
+
+		body := new(User)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Error(err.Error())
+		}
+		if body.Status != UserStatusInvited || body.Email != "john.smith@acme.com" || body.Password != "" {
+			t.Errorf("Unexpected invitation sent %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	user, _, err := testClient.User.Invite(&User{Email: "john.smith@acme.com", FirstName: "John", LastName: "Smith", Password: "ignored"})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if user == nil {
+		t.Error("Expected user. User is nil")
+	}
+
+	if _, _, err := testClient.User.Invite(&User{FirstName: "John"}); err == nil {
+		t.Error("Expected an error inviting a user without email")
+	}
+}
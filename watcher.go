@@ -0,0 +1,583 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WatchEventType is the kind of change a Watcher reports
+type WatchEventType string
+
+// Constants to represent the changes reported by a Watcher
+const (
+	// WatchCreated is reported for resources created since the previous poll
+	WatchCreated WatchEventType = "created"
+	// WatchUpdated is reported for resources updated since the previous poll
+	WatchUpdated WatchEventType = "updated"
+	// WatchDeleted is reported for resources which disappeared, only the ID of the event is set
+	WatchDeleted WatchEventType = "deleted"
+)
+
+// WatchResource is the kind of resource watched, named as the resources of webhook actions
+type WatchResource string
+
+// Constants to represent the resources a Watcher polls
+const (
+	WatchWorkPackages WatchResource = "work_package"
+	WatchProjects     WatchResource = "project"
+	WatchTimeEntries  WatchResource = "time_entry"
+)
+
+// WatchEvent is a change of a resource found by a Watcher.
+// The resource matching Resource is set, i.e. WorkPackage for "work_package", the others are nil.
+// Deleted resources are identified by ID only
+type WatchEvent struct {
+	Type        WatchEventType
+	Resource    WatchResource
+	ID          int
+	WorkPackage *WorkPackage
+	Project     *Project
+	TimeEntry   *TimeEntry
+}
+
+// WatchedVersion is the version of a resource last reported by a Watcher
+type WatchedVersion struct {
+	LockVersion int       `json:"lockVersion,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WatchCursor is the position of a Watcher within the changes of a kind of resource:
+// the last updatedAt reported and the versions of the resources reported so far.
+// When deletions are checked, Seen keeps the resources tracked for deletion, up to WatcherOptions.MaxTracked
+type WatchCursor struct {
+	Since time.Time              `json:"since"`
+	Seen  map[int]WatchedVersion `json:"seen,omitempty"`
+}
+
+// WatchCheckpoint is the state of a Watcher, restoring it lets a restarted process
+// resume where it stopped without replaying or missing changes
+type WatchCheckpoint struct {
+	Cursors map[WatchResource]*WatchCursor `json:"cursors"`
+}
+
+// copy returns a deep copy of the checkpoint
+func (c *WatchCheckpoint) copy() *WatchCheckpoint {
+	cp := &WatchCheckpoint{Cursors: make(map[WatchResource]*WatchCursor, len(c.Cursors))}
+	for resource, cursor := range c.Cursors {
+		seen := make(map[int]WatchedVersion, len(cursor.Seen))
+		for id, version := range cursor.Seen {
+			seen[id] = version
+		}
+		cp.Cursors[resource] = &WatchCursor{Since: cursor.Since, Seen: seen}
+	}
+	return cp
+}
+
+// CheckpointStore keeps the checkpoint of a Watcher, saved after every poll.
+// Implementations must be safe for concurrent use
+type CheckpointStore interface {
+	// Checkpoint returns the stored checkpoint, nil if there is none
+	Checkpoint() (*WatchCheckpoint, error)
+	SetCheckpoint(checkpoint *WatchCheckpoint) error
+}
+
+// memoryCheckpointStore is a CheckpointStore keeping the checkpoint in memory
+type memoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *WatchCheckpoint
+}
+
+// NewMemoryCheckpointStore returns a CheckpointStore keeping the checkpoint in memory, starting with the given one (can be nil)
+func NewMemoryCheckpointStore(checkpoint *WatchCheckpoint) CheckpointStore {
+	return &memoryCheckpointStore{checkpoint: checkpoint}
+}
+
+// Checkpoint returns the stored checkpoint
+func (s *memoryCheckpointStore) Checkpoint() (*WatchCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint, nil
+}
+
+// SetCheckpoint replaces the stored checkpoint
+func (s *memoryCheckpointStore) SetCheckpoint(checkpoint *WatchCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint
+	return nil
+}
+
+// fileCheckpointStore is a CheckpointStore keeping the checkpoint as JSON file
+type fileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointStore returns a CheckpointStore keeping the checkpoint as JSON within the file at path,
+// readable by the current user only
+func NewFileCheckpointStore(path string) CheckpointStore {
+	return &fileCheckpointStore{path: path}
+}
+
+// Checkpoint reads the stored checkpoint. A missing file means there is no checkpoint
+func (s *fileCheckpointStore) Checkpoint() (*WatchCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := new(WatchCheckpoint)
+	if err := json.Unmarshal(raw, checkpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint file %s", s.path)
+	}
+	return checkpoint, nil
+}
+
+// SetCheckpoint writes the checkpoint, writing a temporary file first so the stored checkpoint is never partially written
+func (s *fileCheckpointStore) SetCheckpoint(checkpoint *WatchCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(raw)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0600)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Defaults of WatcherOptions
+const (
+	watchInterval              = 30 * time.Second
+	watchPageSize              = 100
+	watchDeletionCheckInterval = 5 * time.Minute
+	watchMaxTracked            = 10000
+)
+
+// watchTimeLayout is the layout of the times of updatedAt filters
+const watchTimeLayout = "2006-01-02T15:04:05Z"
+
+// WatcherOptions configures a Watcher
+type WatcherOptions struct {
+	// Interval is the time between two polls, 30 seconds if zero
+	Interval time.Duration
+
+	// Projects and TimeEntries enable polling projects and time entries, work packages are always polled
+	Projects    bool
+	TimeEntries bool
+
+	// WorkPackageFilters restricts the work packages watched, i.e. to a project
+	WorkPackageFilters []OptionsFields
+
+	// PageSize is the number of resources requested at once, 100 if zero
+	PageSize int
+
+	// DeletionCheckInterval is the minimum time between two checks for deleted work packages and projects,
+	// 5 minutes if zero. Negative values disable the checks.
+	// Work packages which merely stop matching WorkPackageFilters are not reported as deleted
+	DeletionCheckInterval time.Duration
+
+	// MaxTracked is the number of resources of a kind tracked for deletion, 10000 if zero. Beyond it the least
+	// recently updated ones are forgotten, and their deletion is not reported. Negative values mean no limit
+	MaxTracked int
+
+	// Store keeps the checkpoint, in memory if nil
+	Store CheckpointStore
+
+	// Since is the time changes are reported from when the store has no checkpoint, now if zero.
+	// It is compared with the times of the instance, mind the clock skew
+	Since time.Time
+
+	// ErrorLog is called with the errors of the polls of Watch, if not nil
+	ErrorLog func(err error)
+}
+
+// watchItem is a resource returned by a poll
+type watchItem struct {
+	id          int
+	lockVersion int
+	createdAt   time.Time
+	updatedAt   time.Time
+	event       WatchEvent
+}
+
+// watchFeed lists the resources of a kind
+type watchFeed struct {
+	resource WatchResource
+	filters  []OptionsFields
+	// deletions tells whether deleted resources are looked for, the versions of all resources reported are kept then
+	deletions bool
+	list      func(ctx context.Context, options *FilterOptions) ([]watchItem, error)
+}
+
+// Watcher reports the changes of work packages, and optionally projects and time entries, by polling them
+// with updatedAt filters. It is an alternative to webhooks for instances where they cannot be configured.
+//
+// Resources are requested by ascending updatedAt from the last one reported. A resource is reported once per
+// change of its updatedAt and lockVersion, as created when it was created since the previous poll, as updated
+// otherwise: several changes between two polls are reported as one. Deleted work packages and projects are
+// found by requesting the ones reported so far, which also reports the ones which do not match the filters
+// anymore or became invisible to the user. Deleted time entries are not reported.
+//
+// The checkpoint is saved to the store after every poll, so changes are reported at least once: the events of a
+// poll interrupted before its checkpoint was saved are reported again after a restart
+type Watcher struct {
+	client  *Client
+	options WatcherOptions
+	feeds   []*watchFeed
+
+	mu         sync.Mutex
+	checkpoint *WatchCheckpoint
+	lastSweep  time.Time
+}
+
+// NewWatcher returns a Watcher polling with the client, resuming from the checkpoint of the store if there is one
+func NewWatcher(client *Client, options *WatcherOptions) (*Watcher, error) {
+	w := &Watcher{client: client}
+	if options != nil {
+		w.options = *options
+	}
+	if w.options.Interval <= 0 {
+		w.options.Interval = watchInterval
+	}
+	if w.options.PageSize <= 0 {
+		w.options.PageSize = watchPageSize
+	}
+	if w.options.DeletionCheckInterval == 0 {
+		w.options.DeletionCheckInterval = watchDeletionCheckInterval
+	}
+	if w.options.MaxTracked == 0 {
+		w.options.MaxTracked = watchMaxTracked
+	}
+	if w.options.Store == nil {
+		w.options.Store = NewMemoryCheckpointStore(nil)
+	}
+	if w.options.Since.IsZero() {
+		w.options.Since = time.Now()
+	}
+
+	w.feeds = append(w.feeds, &watchFeed{
+		resource:  WatchWorkPackages,
+		filters:   w.options.WorkPackageFilters,
+		deletions: true,
+		list:      w.listWorkPackages,
+	})
+	if w.options.Projects {
+		w.feeds = append(w.feeds, &watchFeed{resource: WatchProjects, deletions: true, list: w.listProjects})
+	}
+	if w.options.TimeEntries {
+		w.feeds = append(w.feeds, &watchFeed{resource: WatchTimeEntries, list: w.listTimeEntries})
+	}
+
+	checkpoint, err := w.options.Store.Checkpoint()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load the checkpoint")
+	}
+	if checkpoint == nil {
+		checkpoint = new(WatchCheckpoint)
+	}
+	w.checkpoint = checkpoint.copy()
+	for _, feed := range w.feeds {
+		if w.checkpoint.Cursors[feed.resource] == nil {
+			w.checkpoint.Cursors[feed.resource] = &WatchCursor{
+				Since: w.options.Since.UTC().Truncate(time.Second),
+				Seen:  make(map[int]WatchedVersion),
+			}
+		}
+	}
+	return w, nil
+}
+
+// Checkpoint returns a copy of the current checkpoint
+func (w *Watcher) Checkpoint() *WatchCheckpoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.checkpoint.copy()
+}
+
+// Watch polls every Interval, starting immediately, and sends the changes found on the returned channel.
+// Errors of polls are passed to ErrorLog and the poll is retried at the next interval.
+// The channel is closed once ctx is done. Only one Watch or Poll may run at a time
+func (w *Watcher) Watch(ctx context.Context) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(w.options.Interval)
+		defer ticker.Stop()
+		for {
+			err := w.Poll(ctx, func(event *WatchEvent) error {
+				select {
+				case events <- *event:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			if err != nil && ctx.Err() == nil && w.options.ErrorLog != nil {
+				w.options.ErrorLog(err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// Poll requests the changes since the previous poll and calls fn for each of them, in the order of their updatedAt.
+// A change is recorded in the checkpoint once fn returned without error, the checkpoint is saved before returning
+func (w *Watcher) Poll(ctx context.Context, fn func(event *WatchEvent) error) error {
+	w.mu.Lock()
+	checkpoint := w.checkpoint.copy()
+	sweep := w.options.DeletionCheckInterval > 0 && time.Since(w.lastSweep) >= w.options.DeletionCheckInterval
+	w.mu.Unlock()
+
+	var err error
+	for _, feed := range w.feeds {
+		cursor := checkpoint.Cursors[feed.resource]
+		if err = w.pollFeed(ctx, feed, cursor, fn); err != nil {
+			break
+		}
+		if sweep && feed.deletions {
+			if err = w.sweepFeed(ctx, feed, cursor, fn); err != nil {
+				break
+			}
+		}
+	}
+
+	w.mu.Lock()
+	w.checkpoint = checkpoint
+	if sweep && err == nil {
+		w.lastSweep = time.Now()
+	}
+	w.mu.Unlock()
+	if serr := w.options.Store.SetCheckpoint(checkpoint.copy()); err == nil && serr != nil {
+		err = errors.Wrap(serr, "could not save the checkpoint")
+	}
+	return err
+}
+
+// pollFeed reports the resources updated since the cursor, page by page
+func (w *Watcher) pollFeed(ctx context.Context, feed *watchFeed, cursor *WatchCursor, fn func(event *WatchEvent) error) error {
+	previous, since, latest := cursor.Since, cursor.Since, cursor.Since
+	page := 1
+	for {
+		options := &FilterOptions{
+			Fields: append(append([]OptionsFields(nil), feed.filters...), OptionsFields{
+				Field:    "updatedAt",
+				Operator: BetweenDates,
+				Values:   []string{since.Format(watchTimeLayout), ""},
+			}),
+			SortBy:   []SortOption{{Field: "updatedAt"}, {Field: "id"}},
+			Offset:   page,
+			PageSize: w.options.PageSize,
+		}
+		items, err := feed.list(ctx, options)
+		if err != nil {
+			return errors.Wrapf(err, "could not poll %s changes", feed.resource)
+		}
+		for _, item := range items {
+			if item.updatedAt.After(latest) {
+				latest = item.updatedAt
+			}
+			seen, known := cursor.Seen[item.id]
+			if known && seen.LockVersion == item.lockVersion && seen.UpdatedAt.Equal(item.updatedAt) {
+				continue
+			}
+			event := item.event
+			event.Type = WatchUpdated
+			if !known && !item.createdAt.Before(previous) {
+				event.Type = WatchCreated
+			}
+			if err := fn(&event); err != nil {
+				return err
+			}
+			cursor.Seen[item.id] = WatchedVersion{LockVersion: item.lockVersion, UpdatedAt: item.updatedAt}
+		}
+		if len(items) < w.options.PageSize {
+			break
+		}
+		// Restart from the last updatedAt of the page, unless the whole page was updated at the same time
+		if last := items[len(items)-1].updatedAt; last.After(since) {
+			since = last
+			page = 1
+		} else {
+			page++
+		}
+	}
+	cursor.Since = latest
+	if !feed.deletions {
+		// Only the versions at the cursor are needed to skip the resources requested again
+		for id, seen := range cursor.Seen {
+			if seen.UpdatedAt.Before(cursor.Since) {
+				delete(cursor.Seen, id)
+			}
+		}
+	} else if max := w.options.MaxTracked; max > 0 && len(cursor.Seen) > max {
+		forgetOldest(cursor.Seen, len(cursor.Seen)-max)
+	}
+	return nil
+}
+
+// forgetOldest removes the n least recently updated resources of seen
+func forgetOldest(seen map[int]WatchedVersion, n int) {
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := seen[ids[i]].UpdatedAt, seen[ids[j]].UpdatedAt
+		return a.Before(b) || (a.Equal(b) && ids[i] < ids[j])
+	})
+	for _, id := range ids[:n] {
+		delete(seen, id)
+	}
+}
+
+// sweepFeed reports the resources reported so far which cannot be found anymore.
+// They are looked up by ID only, so that resources no longer matching the filters of the feed are not reported
+func (w *Watcher) sweepFeed(ctx context.Context, feed *watchFeed, cursor *WatchCursor, fn func(event *WatchEvent) error) error {
+	ids := make([]string, 0, len(cursor.Seen))
+	for id := range cursor.Seen {
+		ids = append(ids, strconv.Itoa(id))
+	}
+	for start := 0; start < len(ids); start += w.options.PageSize {
+		end := start + w.options.PageSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+		items, err := feed.list(ctx, &FilterOptions{
+			Fields:   []OptionsFields{{Field: "id", Operator: Equal, Values: batch}},
+			PageSize: len(batch),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not check for deleted %s", feed.resource)
+		}
+		found := make(map[int]bool, len(items))
+		for _, item := range items {
+			found[item.id] = true
+		}
+		for _, raw := range batch {
+			id, _ := strconv.Atoi(raw)
+			if found[id] {
+				continue
+			}
+			if err := fn(&WatchEvent{Type: WatchDeleted, Resource: feed.resource, ID: id}); err != nil {
+				return err
+			}
+			delete(cursor.Seen, id)
+		}
+	}
+	return nil
+}
+
+// listWorkPackages lists work packages for a poll
+func (w *Watcher) listWorkPackages(ctx context.Context, options *FilterOptions) ([]watchItem, error) {
+	workPackages, _, err := w.client.WorkPackage.GetListWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]watchItem, len(workPackages))
+	for i := range workPackages {
+		wp := &workPackages[i]
+		items[i] = watchItem{
+			id:          wp.ID,
+			lockVersion: wp.LockVersion,
+			createdAt:   watchTime(wp.CreatedAt),
+			updatedAt:   watchTime(wp.UpdatedAt),
+			event:       WatchEvent{Resource: WatchWorkPackages, ID: wp.ID, WorkPackage: wp},
+		}
+	}
+	return items, nil
+}
+
+// listProjects lists projects for a poll
+func (w *Watcher) listProjects(ctx context.Context, options *FilterOptions) ([]watchItem, error) {
+	obj, _, err := GetListWithContext(ctx, w.client.Project, "api/v3/projects", options)
+	if err != nil {
+		return nil, err
+	}
+	projects := obj.(*SearchResultProject).Embedded.Elements
+	items := make([]watchItem, len(projects))
+	for i := range projects {
+		project := &projects[i]
+		items[i] = watchItem{
+			id:        project.ID,
+			createdAt: watchTime(project.CreatedAt),
+			updatedAt: watchTime(project.UpdatedAt),
+			event:     WatchEvent{Resource: WatchProjects, ID: project.ID, Project: project},
+		}
+	}
+	return items, nil
+}
+
+// timeEntryList is a collection of time entries
+type timeEntryList struct {
+	Embedded struct {
+		Elements []TimeEntry `json:"elements"`
+	} `json:"_embedded"`
+}
+
+// listTimeEntries lists time entries for a poll
+func (w *Watcher) listTimeEntries(ctx context.Context, options *FilterOptions) ([]watchItem, error) {
+	req, err := w.client.NewRequestWithContext(ctx, "GET", "api/v3/time_entries", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = options.prepareFilters().Encode()
+	list := new(timeEntryList)
+	if _, err := w.client.Do(req, list); err != nil {
+		return nil, err
+	}
+	entries := list.Embedded.Elements
+	items := make([]watchItem, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		items[i] = watchItem{
+			id:        entry.ID,
+			createdAt: watchTime(entry.CreatedAt),
+			updatedAt: watchTime(entry.UpdatedAt),
+			event:     WatchEvent{Resource: WatchTimeEntries, ID: entry.ID, TimeEntry: entry},
+		}
+	}
+	return items, nil
+}
+
+// watchTime returns the time of an attribute, zero if not set
+func watchTime(t *Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Time(*t).UTC()
+}
@@ -0,0 +1,210 @@
+package openproject_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	openproject "github.com/manuelbcd/go-openproject"
+	"github.com/manuelbcd/go-openproject/openprojecttest"
+)
+
+// pollEvents polls once and returns the events as "type resource id", i.e. "created work_package 1"
+func pollEvents(t *testing.T, watcher *openproject.Watcher) []string {
+	t.Helper()
+	var events []string
+	err := watcher.Poll(context.Background(), func(event *openproject.WatchEvent) error {
+		events = append(events, fmt.Sprintf("%s %s %d", event.Type, event.Resource, event.ID))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return events
+}
+
+func expectEvents(t *testing.T, events []string, expected ...string) {
+	t.Helper()
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("Expected events %q, got %q", expected, events)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	server := openprojecttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	now := time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)
+	server.SetClock(func() time.Time { return now })
+
+	server.AddProject(&openproject.Project{Identifier: "old", Name: "Old"})
+	store := openproject.NewMemoryCheckpointStore(nil)
+	options := &openproject.WatcherOptions{
+		Projects:              true,
+		Store:                 store,
+		Since:                 now.Add(time.Minute),
+		DeletionCheckInterval: time.Nanosecond,
+	}
+	watcher, err := openproject.NewWatcher(client, options)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	expectEvents(t, pollEvents(t, watcher))
+
+	now = now.Add(2 * time.Minute)
+	project := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	first := server.AddWorkPackage(project.Identifier, &openproject.WorkPackage{Subject: "First"})
+	expectEvents(t, pollEvents(t, watcher), "created work_package 1", "created project 2")
+	expectEvents(t, pollEvents(t, watcher))
+
+	now = now.Add(time.Minute)
+	_, _, err = client.WorkPackage.Update(strconv.Itoa(first.ID), &openproject.WorkPackage{Subject: "Renamed", LockVersion: first.LockVersion})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	second := server.AddWorkPackage(project.Identifier, &openproject.WorkPackage{Subject: "Second"})
+	var renamed string
+	err = watcher.Poll(context.Background(), func(event *openproject.WatchEvent) error {
+		if event.Type == openproject.WatchUpdated {
+			renamed = event.WorkPackage.Subject
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if renamed != "Renamed" {
+		t.Errorf("Expected the renamed work package, got %q", renamed)
+	}
+
+	if _, err := client.WorkPackage.Delete(strconv.Itoa(second.ID)); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	expectEvents(t, pollEvents(t, watcher), "deleted work_package 2")
+
+	// A watcher restarted from the stored checkpoint reports the changes made meanwhile only
+	now = now.Add(time.Minute)
+	third := server.AddWorkPackage(project.Identifier, &openproject.WorkPackage{Subject: "Third"})
+	restarted, err := openproject.NewWatcher(client, options)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	expectEvents(t, pollEvents(t, restarted), fmt.Sprintf("created work_package %d", third.ID))
+
+	checkpoint := restarted.Checkpoint()
+	cursor := checkpoint.Cursors[openproject.WatchWorkPackages]
+	if !cursor.Since.Equal(now) || len(cursor.Seen) != 2 {
+		t.Errorf("Unexpected checkpoint %+v", cursor)
+	}
+}
+
+func TestWatcher_Paging(t *testing.T) {
+	server := openprojecttest.NewServer()
+	defer server.Close()
+	now := time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)
+	server.SetClock(func() time.Time { return now })
+	project := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+
+	watcher, err := openproject.NewWatcher(server.Client(), &openproject.WatcherOptions{PageSize: 2, Since: now})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var expected []string
+	for i := 1; i <= 7; i++ {
+		// Three work packages per second, more than a page
+		now = time.Date(2021, 3, 1, 9, 0, i/3, 0, time.UTC)
+		server.AddWorkPackage(project.Identifier, &openproject.WorkPackage{Subject: fmt.Sprintf("Work package %d", i)})
+		expected = append(expected, fmt.Sprintf("created work_package %d", i))
+	}
+	events := pollEvents(t, watcher)
+	sort.Strings(events)
+	expectEvents(t, events, expected...)
+	expectEvents(t, pollEvents(t, watcher))
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	server := openprojecttest.NewServer()
+	defer server.Close()
+	project := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	watcher, err := openproject.NewWatcher(server.Client(), &openproject.WatcherOptions{
+		Interval: 10 * time.Millisecond,
+		Store:    openproject.NewFileCheckpointStore(path),
+		Since:    time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := watcher.Watch(ctx)
+
+	wp := server.AddWorkPackage(project.Identifier, &openproject.WorkPackage{Subject: "Watched"})
+	select {
+	case event := <-events:
+		if event.Type != openproject.WatchCreated || event.WorkPackage == nil || event.WorkPackage.Subject != "Watched" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("No event received")
+	}
+	cancel()
+	for range events {
+	}
+
+	checkpoint, err := openproject.NewFileCheckpointStore(path).Checkpoint()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, ok := checkpoint.Cursors[openproject.WatchWorkPackages].Seen[wp.ID]; !ok {
+		t.Errorf("Expected work package %d in the stored checkpoint, got %+v", wp.ID, checkpoint)
+	}
+}
+
+func TestWatcher_FilteredDeletions(t *testing.T) {
+	server := openprojecttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	now := time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)
+	server.SetClock(func() time.Time { return now })
+	demo := server.AddProject(&openproject.Project{Identifier: "demo", Name: "Demo"})
+	archive := server.AddProject(&openproject.Project{Identifier: "archive", Name: "Archive"})
+
+	watcher, err := openproject.NewWatcher(client, &openproject.WatcherOptions{
+		WorkPackageFilters:    []openproject.OptionsFields{{Field: "project", Operator: openproject.Equal, Value: strconv.Itoa(demo.ID)}},
+		DeletionCheckInterval: time.Nanosecond,
+		MaxTracked:            1,
+		Since:                 now,
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	now = now.Add(time.Minute)
+	first := server.AddWorkPackage(demo.Identifier, &openproject.WorkPackage{Subject: "First"})
+	expectEvents(t, pollEvents(t, watcher), fmt.Sprintf("created work_package %d", first.ID))
+
+	// A work package moved out of the filters is not reported as deleted
+	now = now.Add(time.Minute)
+	_, _, err = client.WorkPackage.Update(strconv.Itoa(first.ID), &openproject.WorkPackage{
+		LockVersion: first.LockVersion,
+		Links:       &openproject.WPLinks{Project: openproject.WPLinksField{Href: fmt.Sprintf("/api/v3/projects/%d", archive.ID)}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	expectEvents(t, pollEvents(t, watcher))
+
+	// Beyond MaxTracked the least recently updated work packages are forgotten
+	now = now.Add(time.Minute)
+	second := server.AddWorkPackage(demo.Identifier, &openproject.WorkPackage{Subject: "Second"})
+	expectEvents(t, pollEvents(t, watcher), fmt.Sprintf("created work_package %d", second.ID))
+	seen := watcher.Checkpoint().Cursors[openproject.WatchWorkPackages].Seen
+	if _, ok := seen[second.ID]; !ok || len(seen) != 1 {
+		t.Errorf("Expected work package %d tracked only, got %+v", second.ID, seen)
+	}
+}
@@ -0,0 +1,235 @@
+package openproject
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookAction is the action an OpenProject webhook is sent for, i.e. "work_package:updated"
+type WebhookAction string
+
+// Constants to represent the actions of OpenProject webhooks
+const (
+	// WebhookWorkPackageCreated is sent with the WorkPackage created
+	WebhookWorkPackageCreated WebhookAction = "work_package:created"
+	// WebhookWorkPackageUpdated is sent with the WorkPackage updated
+	WebhookWorkPackageUpdated WebhookAction = "work_package:updated"
+	// WebhookProjectCreated is sent with the Project created
+	WebhookProjectCreated WebhookAction = "project:created"
+	// WebhookProjectUpdated is sent with the Project updated
+	WebhookProjectUpdated WebhookAction = "project:updated"
+	// WebhookTimeEntryCreated is sent with the TimeEntry logged
+	WebhookTimeEntryCreated WebhookAction = "time_entry:created"
+	// WebhookAttachmentCreated is sent with the Attachment added
+	WebhookAttachmentCreated WebhookAction = "attachment:created"
+)
+
+// Resource returns the kind of resource of the action, i.e. "work_package" for "work_package:updated"
+func (a WebhookAction) Resource() string {
+	return strings.SplitN(string(a), ":", 2)[0]
+}
+
+// webhookSignatureHeader is the header holding the HMAC of the payload, i.e. "sha1=4f3c..."
+const webhookSignatureHeader = "X-OP-Signature"
+
+// webhookMaxPayloadSize is the default limit of the size of payloads, see WebhookHandler.MaxPayloadSize
+const webhookMaxPayloadSize = 5 << 20
+
+// ErrWebhookSignature is returned when the signature of a webhook is missing or does not match its payload
+var ErrWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookEvent is the payload of an OpenProject webhook.
+// The resource of the action is set, i.e. WorkPackage for "work_package:updated", the others are nil
+type WebhookEvent struct {
+	Action      WebhookAction `json:"action"`
+	WorkPackage *WorkPackage  `json:"work_package,omitempty"`
+	Project     *Project      `json:"project,omitempty"`
+	TimeEntry   *TimeEntry    `json:"time_entry,omitempty"`
+	Attachment  *Attachment   `json:"attachment,omitempty"`
+
+	// Payload is the raw payload, for the attributes not modeled by the resources
+	Payload json.RawMessage `json:"-"`
+}
+
+// WebhookFunc is called for the events a WebhookHandler receives
+type WebhookFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler is an http.Handler receiving OpenProject webhooks.
+// It verifies the signature of every payload with the secret of the webhook, decodes it into a WebhookEvent
+// and calls the functions registered for its action. Functions are called in the order they were registered,
+// within the request: OpenProject waits for the response, so long tasks should be run in the background.
+// The response is 200 OK once every function succeeded, 500 Internal Server Error if one failed.
+type WebhookHandler struct {
+	// MaxPayloadSize is the maximum size of payloads in bytes, 5 MiB if zero
+	MaxPayloadSize int64
+
+	// ErrorLog is called with the errors of requests which could not be handled, if not nil
+	ErrorLog func(r *http.Request, err error)
+
+	secret []byte
+
+	mu       sync.RWMutex
+	handlers map[WebhookAction][]WebhookFunc
+	catchAll []WebhookFunc
+}
+
+// NewWebhookHandler returns a WebhookHandler verifying payloads with the secret of the webhook.
+// An empty secret accepts unsigned payloads, only use it for webhooks configured without secret
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		secret:   []byte(secret),
+		handlers: make(map[WebhookAction][]WebhookFunc),
+	}
+}
+
+// On registers fn to be called for the events of an action
+func (h *WebhookHandler) On(action WebhookAction, fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[action] = append(h.handlers[action], fn)
+}
+
+// OnAny registers fn to be called for every event, after the functions registered for its action
+func (h *WebhookHandler) OnAny(fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.catchAll = append(h.catchAll, fn)
+}
+
+// OnWorkPackage registers fn to be called when a work package is created or updated
+func (h *WebhookHandler) OnWorkPackage(fn func(ctx context.Context, action WebhookAction, workPackage *WorkPackage) error) {
+	wrapped := func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Action, event.WorkPackage)
+	}
+	h.On(WebhookWorkPackageCreated, wrapped)
+	h.On(WebhookWorkPackageUpdated, wrapped)
+}
+
+// OnProject registers fn to be called when a project is created or updated
+func (h *WebhookHandler) OnProject(fn func(ctx context.Context, action WebhookAction, project *Project) error) {
+	wrapped := func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Action, event.Project)
+	}
+	h.On(WebhookProjectCreated, wrapped)
+	h.On(WebhookProjectUpdated, wrapped)
+}
+
+// OnTimeEntry registers fn to be called when time is logged
+func (h *WebhookHandler) OnTimeEntry(fn func(ctx context.Context, action WebhookAction, timeEntry *TimeEntry) error) {
+	h.On(WebhookTimeEntryCreated, func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Action, event.TimeEntry)
+	})
+}
+
+// OnAttachment registers fn to be called when a file is attached
+func (h *WebhookHandler) OnAttachment(fn func(ctx context.Context, action WebhookAction, attachment *Attachment) error) {
+	h.On(WebhookAttachmentCreated, func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Action, event.Attachment)
+	})
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	maxSize := h.MaxPayloadSize
+	if maxSize <= 0 {
+		maxSize = webhookMaxPayloadSize
+	}
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, errors.Wrap(err, "could not read webhook payload"))
+		return
+	}
+	if int64(len(payload)) > maxSize {
+		h.fail(w, r, http.StatusRequestEntityTooLarge, errors.Errorf("webhook payload larger than %d bytes", maxSize))
+		return
+	}
+	if len(h.secret) > 0 {
+		if err := VerifyWebhookSignature(h.secret, payload, r.Header.Get(webhookSignatureHeader)); err != nil {
+			h.fail(w, r, http.StatusUnauthorized, err)
+			return
+		}
+	}
+	event, err := ParseWebhookEvent(payload)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.mu.RLock()
+	handlers := append(append([]WebhookFunc(nil), h.handlers[event.Action]...), h.catchAll...)
+	h.mu.RUnlock()
+	for _, fn := range handlers {
+		if err := fn(r.Context(), event); err != nil {
+			h.fail(w, r, http.StatusInternalServerError, errors.Wrapf(err, "could not handle %s", event.Action))
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail responds with an error status and logs the error
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.ErrorLog != nil {
+		h.ErrorLog(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// VerifyWebhookSignature checks the signature of a webhook payload, the value of the X-OP-Signature header
+// like "sha1=4f3c...", against the HMAC of the payload with the secret. Errors match ErrWebhookSignature
+func VerifyWebhookSignature(secret []byte, payload []byte, signature string) error {
+	parts := strings.SplitN(signature, "=", 2)
+	if len(parts) != 2 {
+		return errors.Wrapf(ErrWebhookSignature, "expected a signature like \"sha1=<hex>\", %q given", signature)
+	}
+	var newHash func() hash.Hash
+	switch strings.ToLower(parts[0]) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	default:
+		return errors.Wrapf(ErrWebhookSignature, "unsupported signature algorithm %q", parts[0])
+	}
+	expected, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return errors.Wrap(ErrWebhookSignature, "signature is not hexadecimal")
+	}
+	mac := hmac.New(newHash, secret)
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrWebhookSignature
+	}
+	return nil
+}
+
+// ParseWebhookEvent decodes a webhook payload into a WebhookEvent
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	event := new(WebhookEvent)
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, errors.Wrap(err, "could not parse webhook payload")
+	}
+	if event.Action == "" {
+		return nil, errors.New("webhook payload has no action")
+	}
+	event.Payload = json.RawMessage(payload)
+	return event, nil
+}
@@ -0,0 +1,143 @@
+package openproject
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testWebhookPayload is a webhook sent by OpenProject when a work package is updated
+const testWebhookPayload = `{
+  "action": "work_package:updated",
+  "work_package": {
+    "_type": "WorkPackage",
+    "id": 42,
+    "lockVersion": 3,
+    "subject": "Fix login page",
+    "updatedAt": "2021-01-31T10:00:00Z",
+    "_links": {
+      "status": {"href": "/api/v3/statuses/7", "title": "In progress"},
+      "assignee": {"href": "/api/v3/users/5", "title": "John Doe"}
+    }
+  }
+}`
+
+// signWebhook returns the X-OP-Signature of a payload
+func signWebhook(secret string, payload string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendWebhook sends a payload to a handler and returns the response status
+func sendWebhook(handler http.Handler, payload string, signature string) int {
+	req := httptest.NewRequest("POST", "/webhooks/openproject", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("X-OP-Signature", signature)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookHandler_WorkPackage(t *testing.T) {
+	handler := NewWebhookHandler("s3cr3t")
+	var calls []string
+	handler.OnWorkPackage(func(ctx context.Context, action WebhookAction, workPackage *WorkPackage) error {
+		calls = append(calls, string(action))
+		if workPackage.ID != 42 || workPackage.LockVersion != 3 || workPackage.Links.Status.Title != "In progress" {
+			t.Errorf("Unexpected work package: %+v", workPackage)
+		}
+		return nil
+	})
+	handler.OnProject(func(ctx context.Context, action WebhookAction, project *Project) error {
+		t.Errorf("Unexpected project event %s", action)
+		return nil
+	})
+	handler.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		calls = append(calls, "any")
+		if event.Project != nil || !strings.Contains(string(event.Payload), `"lockVersion": 3`) {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+		return nil
+	})
+
+	if status := sendWebhook(handler, testWebhookPayload, signWebhook("s3cr3t", testWebhookPayload)); status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+	if strings.Join(calls, ",") != "work_package:updated,any" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+func TestWebhookHandler_Signature(t *testing.T) {
+	var logged []error
+	handler := NewWebhookHandler("s3cr3t")
+	handler.ErrorLog = func(r *http.Request, err error) {
+		logged = append(logged, err)
+	}
+	handler.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		t.Errorf("Unexpected event: %+v", event)
+		return nil
+	})
+
+	for _, signature := range []string{
+		"",
+		signWebhook("other secret", testWebhookPayload),
+		signWebhook("s3cr3t", testWebhookPayload+" "),
+		"md5=0123",
+		"sha1=not-hex",
+	} {
+		if status := sendWebhook(handler, testWebhookPayload, signature); status != http.StatusUnauthorized {
+			t.Errorf("Signature %q, expected status 401, got %d", signature, status)
+		}
+	}
+	for _, err := range logged {
+		if !errors.Is(err, ErrWebhookSignature) {
+			t.Errorf("Expected a signature error, got %v", err)
+		}
+	}
+	if len(logged) != 5 {
+		t.Errorf("Expected 5 errors logged, got %d", len(logged))
+	}
+}
+
+func TestWebhookHandler_Errors(t *testing.T) {
+	handler := NewWebhookHandler("")
+	handler.MaxPayloadSize = 1024
+	handler.OnTimeEntry(func(ctx context.Context, action WebhookAction, timeEntry *TimeEntry) error {
+		if timeEntry.Hours != "PT1H30M" || timeEntry.Links.WorkPackage.Href != "/api/v3/work_packages/42" {
+			t.Errorf("Unexpected time entry: %+v", timeEntry)
+		}
+		return errors.New("chat is down")
+	})
+
+	// Unsigned payloads are accepted without secret
+	timeEntry := `{"action": "time_entry:created", "time_entry": {"hours": "PT1H30M", "_links": {"workPackage": {"href": "/api/v3/work_packages/42"}}}}`
+	if status := sendWebhook(handler, timeEntry, ""); status != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", status)
+	}
+	if status := sendWebhook(handler, `{"work_package": {}}`, ""); status != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", status)
+	}
+	if status := sendWebhook(handler, strings.Repeat(" ", 2048)+"{}", ""); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", status)
+	}
+	// Actions without registered function are acknowledged
+	if status := sendWebhook(handler, `{"action": "attachment:created", "attachment": {"id": 1}}`, ""); status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/webhooks/openproject", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "POST" {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
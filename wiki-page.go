@@ -10,18 +10,40 @@ type WikiPageService struct {
 	client *Client
 }
 
-// WikiPage is the object representing OpenProject users.
-// TODO: Complete fields and complex fields (_embedded.attachments, links, project, ...)
+// SearchResultWikiPage represent a list of wiki pages
+type SearchResultWikiPage struct {
+	Embedded wikiPageElements `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Total    int              `json:"total" structs:"total"`
+	Count    int              `json:"count" structs:"count"`
+	PageSize int              `json:"pageSize" structs:"pageSize"`
+	Offset   int              `json:"offset" structs:"offset"`
+}
+
+// wikiPageElements represent elements within SearchResultWikiPage
+type wikiPageElements struct {
+	Elements []WikiPage `json:"elements,omitempty" structs:"elements,omitempty"`
+}
+
+// WikiPage is the object representing OpenProject wiki pages.
 type WikiPage struct {
-	Type     string       `json:"_type,omitempty" structs:"_type,omitempty"`
-	ID       int          `json:"id,omitempty" structs:"id,omitempty"`
-	Title    string       `json:"title,omitempty" structs:"title,omitempty"`
-	Embedded WikiEmbedded `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Type        string         `json:"_type,omitempty" structs:"_type,omitempty"`
+	ID          int            `json:"id,omitempty" structs:"id,omitempty"`
+	Title       string         `json:"title,omitempty" structs:"title,omitempty"`
+	Text        *WikiText      `json:"text,omitempty" structs:"text,omitempty"`
+	LockVersion int            `json:"lockVersion,omitempty" structs:"lockVersion,omitempty"`
+	CreatedAt   *Time          `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
+	UpdatedAt   *Time          `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
+	Embedded    WikiEmbedded   `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links       *WikiPageLinks `json:"_links,omitempty" structs:"_links,omitempty"`
 }
 
+// WikiText type contains the page content and its format
+type WikiText OPGenericDescription
+
 // WikiEmbedded wraps embedded field of WikiPage
 type WikiEmbedded struct {
-	Project WikiProject `json:"project,omitempty" structs:"project,omitempty"`
+	Project     WikiProject             `json:"project,omitempty" structs:"project,omitempty"`
+	Attachments *SearchResultAttachment `json:"attachments,omitempty" structs:"attachments,omitempty"`
 }
 
 // WikiProject wraps WikiEmbedded data
@@ -29,15 +51,36 @@ type WikiProject struct {
 	Type       string `json:"_type,omitempty" structs:"_type,omitempty"`
 	ID         int    `json:"id,omitempty" structs:"id,omitempty"`
 	Identifier string `json:"identifier,omitempty" structs:"identifier,omitempty"`
+	Name       string `json:"name,omitempty" structs:"name,omitempty"`
 	CreatedAt  *Time  `json:"createdAt,omitempty" structs:"createdAt,omitempty"`
 	UpdatedAt  *Time  `json:"updatedAt,omitempty" structs:"updatedAt,omitempty"`
 	Status     string `json:"status,omitempty" structs:"status,omitempty"`
 }
 
+// WikiPageLinks are WikiPage Links
+type WikiPageLinks struct {
+	Self              *OPGenericLink `json:"self,omitempty" structs:"self,omitempty"`
+	Project           *OPGenericLink `json:"project,omitempty" structs:"project,omitempty"`
+	Attachments       *OPGenericLink `json:"attachments,omitempty" structs:"attachments,omitempty"`
+	AddAttachment     *OPGenericLink `json:"addAttachment,omitempty" structs:"addAttachment,omitempty"`
+	PrepareAttachment *OPGenericLink `json:"prepareAttachment,omitempty" structs:"prepareAttachment,omitempty"`
+}
+
+// wikiPagePayload is the body sent to create or update a wiki page
+// LockVersion is a pointer so version 0 (page never updated) is still rendered on updates
+type wikiPagePayload struct {
+	Title       string    `json:"title,omitempty" structs:"title,omitempty"`
+	Text        *WikiText `json:"text,omitempty" structs:"text,omitempty"`
+	LockVersion *int      `json:"lockVersion,omitempty" structs:"lockVersion,omitempty"`
+}
+
 // GetWithContext gets a wiki page from OpenProject using its ID
 func (s *WikiPageService) GetWithContext(ctx context.Context, wikiID string) (*WikiPage, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/wiki_pages/%s", wikiID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*WikiPage), Resp, err
 }
 
@@ -45,3 +88,85 @@ func (s *WikiPageService) GetWithContext(ctx context.Context, wikiID string) (*W
 func (s *WikiPageService) Get(wikiID string) (*WikiPage, *Response, error) {
 	return s.GetWithContext(context.Background(), wikiID)
 }
+
+// GetListWithContext retrieves the wiki pages of a project
+func (s *WikiPageService) GetListWithContext(ctx context.Context, projectID string) (*SearchResultWikiPage, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/wiki_pages", projectID)
+	Obj, Resp, err := GetListWithContext(ctx, s, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*SearchResultWikiPage), Resp, err
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *WikiPageService) GetList(projectID string) (*SearchResultWikiPage, *Response, error) {
+	return s.GetListWithContext(context.Background(), projectID)
+}
+
+// CreateWithContext creates a wiki page with its title and text within a project
+func (s *WikiPageService) CreateWithContext(ctx context.Context, projectID string, page *WikiPage) (*WikiPage, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/wiki_pages", projectID)
+	payload := &wikiPagePayload{
+		Title: page.Title,
+		Text:  page.Text,
+	}
+	Obj, Resp, err := CreateWithContext(ctx, s, apiEndpoint, payload)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*WikiPage), Resp, err
+}
+
+// Create wraps CreateWithContext using the background context.
+func (s *WikiPageService) Create(projectID string, page *WikiPage) (*WikiPage, *Response, error) {
+	return s.CreateWithContext(context.Background(), projectID, page)
+}
+
+// UpdateWithContext updates title and text of a wiki page.
+// page.LockVersion must hold the version the changes are based on, otherwise OpenProject rejects the update
+func (s *WikiPageService) UpdateWithContext(ctx context.Context, wikiID string, page *WikiPage) (*WikiPage, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/wiki_pages/%s", wikiID)
+	lockVersion := page.LockVersion
+	payload := &wikiPagePayload{
+		Title:       page.Title,
+		Text:        page.Text,
+		LockVersion: &lockVersion,
+	}
+	Obj, Resp, err := UpdateWithContext(ctx, s, apiEndpoint, payload)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*WikiPage), Resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *WikiPageService) Update(wikiID string, page *WikiPage) (*WikiPage, *Response, error) {
+	return s.UpdateWithContext(context.Background(), wikiID, page)
+}
+
+// GetAttachmentsWithContext retrieves the attachments of a wiki page
+func (s *WikiPageService) GetAttachmentsWithContext(ctx context.Context, wikiID string) (*SearchResultAttachment, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/wiki_pages/%s/attachments", wikiID)
+	Obj, Resp, err := GetListWithContext(ctx, s.client.Attachment, apiEndpoint, nil)
+	if err != nil {
+		return nil, Resp, err
+	}
+	return Obj.(*SearchResultAttachment), Resp, err
+}
+
+// GetAttachments wraps GetAttachmentsWithContext using the background context.
+func (s *WikiPageService) GetAttachments(wikiID string) (*SearchResultAttachment, *Response, error) {
+	return s.GetAttachmentsWithContext(context.Background(), wikiID)
+}
+
+// AddAttachmentWithContext uploads a file and attaches it to a wiki page
+func (s *WikiPageService) AddAttachmentWithContext(ctx context.Context, wikiID string, fileName string, content []byte) (*Attachment, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/wiki_pages/%s/attachments", wikiID)
+	return s.client.Attachment.UploadWithContext(ctx, apiEndpoint, fileName, content)
+}
+
+// AddAttachment wraps AddAttachmentWithContext using the background context.
+func (s *WikiPageService) AddAttachment(wikiID string, fileName string, content []byte) (*Attachment, *Response, error) {
+	return s.AddAttachmentWithContext(context.Background(), wikiID, fileName, content)
+}
@@ -1,6 +1,7 @@
 package openproject
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -34,3 +35,139 @@ func TestWikiPageService_Get(t *testing.T) {
 		t.Errorf("Unexpected wiki title %s", wiki.Title)
 	}
 }
+
+func TestWikiPageService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/projects/demo-project/wiki_pages"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-wikipages-from-project.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	pages, resp, err := testClient.WikiPage.GetList("demo-project")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if pages == nil {
+		t.Error("Expected wiki page list. Wiki page list is nil")
+		return
+	}
+	if resp.Total != 2 {
+		t.Errorf("Total should populate with 2, %v given", resp.Total)
+	}
+	if pages.Embedded.Elements[1].Text.Raw != "# Release notes" {
+		t.Errorf("Unexpected wiki text %s", pages.Embedded.Elements[1].Text.Raw)
+	}
+}
+
+func TestWikiPageService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/projects/demo-project/wiki_pages"
+
+	raw, err := ioutil.ReadFile("./mocks/post/post-wikipage.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		if body["title"] != "Release notes" {
+			t.Errorf("Unexpected title sent %v", body["title"])
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, string(raw))
+	})
+
+	page := &WikiPage{
+		Title: "Release notes",
+		Text:  &WikiText{Format: "markdown", Raw: "# Release notes"},
+	}
+	wiki, _, err := testClient.WikiPage.Create("demo-project", page)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if wiki == nil {
+		t.Error("Expected wiki page. Wiki page is nil")
+		return
+	}
+	if wiki.ID != 6 {
+		t.Errorf("Unexpected wiki page ID %d", wiki.ID)
+	}
+}
+
+func TestWikiPageService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/wiki_pages/6"
+
+	raw, err := ioutil.ReadFile("./mocks/post/post-wikipage.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, testAPIEdpoint)
+
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		if lockVersion, ok := body["lockVersion"]; !ok || lockVersion != float64(0) {
+			t.Errorf("Expected lockVersion 0 to be sent, got %v", body["lockVersion"])
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	page := &WikiPage{
+		Text:        &WikiText{Raw: "# Release notes"},
+		LockVersion: 0,
+	}
+	wiki, _, err := testClient.WikiPage.Update("6", page)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if wiki == nil {
+		t.Error("Expected wiki page. Wiki page is nil")
+	}
+}
+
+func TestWikiPageService_GetAttachments(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEdpoint := "/api/v3/wiki_pages/6/attachments"
+
+	raw, err := ioutil.ReadFile("./mocks/get/get-wikipage-attachments.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc(testAPIEdpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, testAPIEdpoint)
+		fmt.Fprint(w, string(raw))
+	})
+
+	attachments, _, err := testClient.WikiPage.GetAttachments("6")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if attachments == nil || len(attachments.Embedded.Elements) != 1 {
+		t.Error("Expected one attachment within the list")
+		return
+	}
+	if attachments.Embedded.Elements[0].FileName != "release-notes.md" {
+		t.Errorf("Unexpected attachment filename %s", attachments.Embedded.Elements[0].FileName)
+	}
+}
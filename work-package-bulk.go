@@ -0,0 +1,407 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Defaults of BulkOptions
+const (
+	bulkConcurrency      = 4
+	bulkRateLimitRetries = 3
+)
+
+// BulkOptions configures the bulk operations of WorkPackageService
+type BulkOptions struct {
+	// Concurrency is the number of work packages processed at the same time, 4 if zero.
+	// Requests are limited by the rate limits of the client as well, see Client.SetRateLimit
+	Concurrency int
+
+	// DryRun validates the operations through the form endpoints of OpenProject instead of applying them,
+	// results hold the work packages as they would be saved. Deletions only check the work packages exist
+	DryRun bool
+
+	// RefreshLockVersion updates work packages from their current lockVersion, fetched right before the update,
+	// instead of the one of the changes: for changes not based on a version read before, at the risk of
+	// overwriting changes made meanwhile
+	RefreshLockVersion bool
+
+	// RateLimitRetries is the number of times an operation rejected with 429 Too Many Requests is retried,
+	// 3 if zero. Negative values disable these retries. Every worker waits for the delay of the Retry-After
+	// header, or an exponential backoff, before sending its next request
+	RateLimitRetries int
+
+	// OnResult, if set, is called with the result of every operation as soon as it is done, i.e. to report progress.
+	// Calls are made one at a time, in the order operations finish
+	OnResult func(result BulkResult)
+}
+
+// BulkUpdateItem are the changes of a work package updated in bulk, see WorkPackageService.UpdateWithContext
+type BulkUpdateItem struct {
+	ID      string
+	Changes *WorkPackage
+}
+
+// BulkResult is the result of the operation on an item of a bulk operation
+type BulkResult struct {
+	// Index is the position of the item in the items given
+	Index int
+	// ID is the ID of the work package the operation was applied to, empty for creations
+	ID string
+	// WorkPackage is the work package created, updated, moved or copied, as validated by the form on dry runs.
+	// It is the work package found for dry-run deletions, nil for deletions
+	WorkPackage *WorkPackage
+	Err         error
+}
+
+// BulkReport holds the results of a bulk operation, in the order of the items given
+type BulkReport struct {
+	Results []BulkResult
+}
+
+// Failed returns the results of the operations which failed
+func (r *BulkReport) Failed() []BulkResult {
+	var failed []BulkResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns nil if every operation succeeded, an error wrapping the first failure otherwise
+func (r *BulkReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Wrapf(failed[0].Err, "%d of %d work packages failed, first at index %d", len(failed), len(r.Results), failed[0].Index)
+}
+
+// BulkUpdateWithContext updates many work packages. Operations do not stop at the first failure:
+// the report holds the result of every item and the error returned is the one of BulkReport.Err
+func (s *WorkPackageService) BulkUpdateWithContext(ctx context.Context, items []BulkUpdateItem, options *BulkOptions) (*BulkReport, error) {
+	opts := bulkDefaults(options)
+	return s.runBulk(ctx, len(items), func(i int) string { return items[i].ID }, opts,
+		func(ctx context.Context, i int) (*WorkPackage, *Response, error) {
+			item := items[i]
+			if item.Changes == nil {
+				return nil, nil, errors.New("no changes given")
+			}
+			changes := *item.Changes
+			if opts.RefreshLockVersion {
+				current, resp, err := s.GetWithContext(ctx, item.ID)
+				if err != nil {
+					return nil, resp, err
+				}
+				changes.LockVersion = current.LockVersion
+			}
+			if opts.DryRun {
+				payload, err := workPackagePatchPayload(&changes)
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.validateWithForm(ctx, fmt.Sprintf("api/v3/work_packages/%s/form", item.ID), payload)
+			}
+			return s.UpdateWithContext(ctx, item.ID, &changes)
+		})
+}
+
+// BulkUpdate wraps BulkUpdateWithContext using the background context.
+func (s *WorkPackageService) BulkUpdate(items []BulkUpdateItem, options *BulkOptions) (*BulkReport, error) {
+	return s.BulkUpdateWithContext(context.Background(), items, options)
+}
+
+// BulkDeleteWithContext deletes many work packages, see BulkUpdateWithContext for the report
+func (s *WorkPackageService) BulkDeleteWithContext(ctx context.Context, workpackageIDs []string, options *BulkOptions) (*BulkReport, error) {
+	opts := bulkDefaults(options)
+	return s.runBulk(ctx, len(workpackageIDs), func(i int) string { return workpackageIDs[i] }, opts,
+		func(ctx context.Context, i int) (*WorkPackage, *Response, error) {
+			if opts.DryRun {
+				return s.GetWithContext(ctx, workpackageIDs[i])
+			}
+			resp, err := s.DeleteWithContext(ctx, workpackageIDs[i])
+			return nil, resp, err
+		})
+}
+
+// BulkDelete wraps BulkDeleteWithContext using the background context.
+func (s *WorkPackageService) BulkDelete(workpackageIDs []string, options *BulkOptions) (*BulkReport, error) {
+	return s.BulkDeleteWithContext(context.Background(), workpackageIDs, options)
+}
+
+// BulkCreateWithContext creates many work packages within a project, see BulkUpdateWithContext for the report
+func (s *WorkPackageService) BulkCreateWithContext(ctx context.Context, workPackages []*WorkPackage, projectName string, options *BulkOptions) (*BulkReport, error) {
+	opts := bulkDefaults(options)
+	return s.runBulk(ctx, len(workPackages), func(int) string { return "" }, opts,
+		func(ctx context.Context, i int) (*WorkPackage, *Response, error) {
+			if opts.DryRun {
+				return s.validateWithForm(ctx, fmt.Sprintf("api/v3/projects/%s/work_packages/form", projectName), workPackages[i])
+			}
+			return s.CreateWithContext(ctx, workPackages[i], projectName)
+		})
+}
+
+// BulkCreate wraps BulkCreateWithContext using the background context.
+func (s *WorkPackageService) BulkCreate(workPackages []*WorkPackage, projectName string, options *BulkOptions) (*BulkReport, error) {
+	return s.BulkCreateWithContext(context.Background(), workPackages, projectName, options)
+}
+
+// BulkMoveWithContext moves many work packages to another project, given by ID.
+// Work packages are moved from their current lockVersion. See BulkUpdateWithContext for the report
+func (s *WorkPackageService) BulkMoveWithContext(ctx context.Context, workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error) {
+	opts := bulkDefaults(options)
+	return s.runBulk(ctx, len(workpackageIDs), func(i int) string { return workpackageIDs[i] }, opts,
+		func(ctx context.Context, i int) (*WorkPackage, *Response, error) {
+			current, resp, err := s.GetWithContext(ctx, workpackageIDs[i])
+			if err != nil {
+				return nil, resp, err
+			}
+			changes := &WorkPackage{
+				LockVersion: current.LockVersion,
+				Links:       &WPLinks{Project: WPLinksField{Href: fmt.Sprintf("/api/v3/projects/%s", projectID)}},
+			}
+			if opts.DryRun {
+				payload, err := workPackagePatchPayload(changes)
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.validateWithForm(ctx, fmt.Sprintf("api/v3/work_packages/%s/form", workpackageIDs[i]), payload)
+			}
+			return s.UpdateWithContext(ctx, workpackageIDs[i], changes)
+		})
+}
+
+// BulkMove wraps BulkMoveWithContext using the background context.
+func (s *WorkPackageService) BulkMove(workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error) {
+	return s.BulkMoveWithContext(context.Background(), workpackageIDs, projectID, options)
+}
+
+// BulkCopyWithContext copies many work packages into a project, given by ID. Copies get the subject, the description,
+// the dates, the type, the priority, the status, the assignee and the responsible of their source, custom fields,
+// relations, attachments and comments are not copied. See BulkUpdateWithContext for the report
+func (s *WorkPackageService) BulkCopyWithContext(ctx context.Context, workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error) {
+	opts := bulkDefaults(options)
+	return s.runBulk(ctx, len(workpackageIDs), func(i int) string { return workpackageIDs[i] }, opts,
+		func(ctx context.Context, i int) (*WorkPackage, *Response, error) {
+			source, resp, err := s.GetWithContext(ctx, workpackageIDs[i])
+			if err != nil {
+				return nil, resp, err
+			}
+			copied := workPackageCopy(source, projectID)
+			if opts.DryRun {
+				return s.validateWithForm(ctx, fmt.Sprintf("api/v3/projects/%s/work_packages/form", projectID), copied)
+			}
+			return s.CreateWithContext(ctx, copied, projectID)
+		})
+}
+
+// BulkCopy wraps BulkCopyWithContext using the background context.
+func (s *WorkPackageService) BulkCopy(workpackageIDs []string, projectID string, options *BulkOptions) (*BulkReport, error) {
+	return s.BulkCopyWithContext(context.Background(), workpackageIDs, projectID, options)
+}
+
+// workPackageCopy returns a new work package with the attributes of source, within a project
+func workPackageCopy(source *WorkPackage, projectID string) *WorkPackage {
+	copied := &WorkPackage{
+		Subject:   source.Subject,
+		StartDate: source.StartDate,
+		DueDate:   source.DueDate,
+		Links:     new(WPLinks),
+	}
+	if source.Description != nil {
+		copied.Description = &WPDescription{Format: source.Description.Format, Raw: source.Description.Raw}
+	}
+	if source.Links != nil {
+		copied.Links.Type = source.Links.Type
+		copied.Links.Priority = source.Links.Priority
+		copied.Links.Status = source.Links.Status
+		copied.Links.Assignee = source.Links.Assignee
+		copied.Links.Responsible = source.Links.Responsible
+	}
+	copied.Links.Project = WPLinksField{Href: fmt.Sprintf("/api/v3/projects/%s", projectID)}
+	return copied
+}
+
+// workPackageForm is the response of a work-package form: the payload completed by OpenProject and
+// its violations, by attribute
+type workPackageForm struct {
+	Embedded struct {
+		Payload          *WorkPackage      `json:"payload"`
+		ValidationErrors map[string]*Error `json:"validationErrors"`
+	} `json:"_embedded"`
+}
+
+// validateWithForm posts payload to a form endpoint, returning the payload validated or its violations
+// as PropertyConstraintViolation error, MultipleErrors if there are several
+func (s *WorkPackageService) validateWithForm(ctx context.Context, endpoint string, payload interface{}) (*WorkPackage, *Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "POST", endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	form := new(workPackageForm)
+	resp, err := s.client.Do(req, form)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	violations := form.Embedded.ValidationErrors
+	if len(violations) == 0 {
+		return form.Embedded.Payload, resp, nil
+	}
+	attributes := make([]string, 0, len(violations))
+	for attribute := range violations {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	opErr := &Error{
+		StatusCode: http.StatusUnprocessableEntity,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Type:       "Error",
+		Identifier: ErrMultipleErrors,
+		Message:    "Multiple field constraints have been violated.",
+	}
+	for _, attribute := range attributes {
+		opErr.Embedded.Errors = append(opErr.Embedded.Errors, violations[attribute])
+	}
+	if len(attributes) == 1 {
+		violation := *violations[attributes[0]]
+		violation.StatusCode, violation.Method, violation.URL = opErr.StatusCode, opErr.Method, opErr.URL
+		opErr = &violation
+	}
+	return nil, resp, opErr
+}
+
+// bulkOperation applies a bulk operation to the item at index i
+type bulkOperation func(ctx context.Context, i int) (*WorkPackage, *Response, error)
+
+// bulkDefaults returns options with their defaults set
+func bulkDefaults(options *BulkOptions) BulkOptions {
+	var opts BulkOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = bulkConcurrency
+	}
+	if opts.RateLimitRetries == 0 {
+		opts.RateLimitRetries = bulkRateLimitRetries
+	}
+	return opts
+}
+
+// runBulk applies an operation to n items with the workers of the options, pausing them all after rate limited requests.
+// Items not processed before ctx is done fail with the error of ctx
+func (s *WorkPackageService) runBulk(ctx context.Context, n int, id func(i int) string, opts BulkOptions, op bulkOperation) (*BulkReport, error) {
+	report := &BulkReport{Results: make([]BulkResult, n)}
+	indexes := make(chan int)
+	var (
+		wg        sync.WaitGroup
+		resultsMu sync.Mutex
+		pause     bulkPause
+	)
+	for w := 0; w < opts.Concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := BulkResult{Index: i, ID: id(i)}
+				for attempt := 1; ; attempt++ {
+					if result.Err = pause.wait(ctx); result.Err != nil {
+						break
+					}
+					var resp *Response
+					result.WorkPackage, resp, result.Err = op(ctx, i)
+					if attempt > opts.RateLimitRetries || !isRateLimited(resp, result.Err) {
+						break
+					}
+					wait, ok := time.Duration(0), false
+					if resp != nil {
+						wait, ok = retryAfter(resp.Response)
+					}
+					if !ok {
+						wait = DefaultRetryPolicy().backoff(attempt)
+					}
+					pause.extend(wait)
+				}
+
+				resultsMu.Lock()
+				report.Results[i] = result
+				if opts.OnResult != nil {
+					opts.OnResult(result)
+				}
+				resultsMu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if ctx.Err() != nil {
+			report.Results[i] = BulkResult{Index: i, ID: id(i), Err: ctx.Err()}
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			report.Results[i] = BulkResult{Index: i, ID: id(i), Err: ctx.Err()}
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return report, report.Err()
+}
+
+// isRateLimited reports whether an operation failed with 429 Too Many Requests
+func isRateLimited(resp *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	var opErr *Error
+	return errors.As(err, &opErr) && opErr.StatusCode == http.StatusTooManyRequests
+}
+
+// bulkPause holds back the workers of a bulk operation until the delay requested by a rate limited response is over
+type bulkPause struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// extend makes workers wait at least d from now
+func (p *bulkPause) extend(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.until) {
+		p.until = until
+	}
+}
+
+// wait waits for the end of the pause, or until ctx is done
+func (p *bulkPause) wait(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		d := time.Until(p.until)
+		p.mu.Unlock()
+		if d <= 0 {
+			return ctx.Err()
+		}
+		// The pause may be extended meanwhile, so it is checked again
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
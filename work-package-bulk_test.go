@@ -0,0 +1,103 @@
+package openproject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWorkPackageService_BulkUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	testMux.HandleFunc("/api/v3/work_packages/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		id := strings.TrimPrefix(r.URL.Path, "/api/v3/work_packages/")
+		mu.Lock()
+		attempts[id]++
+		attempt := attempts[id]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		switch {
+		case id == "2":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"_type": "Error", "errorIdentifier": "urn:openproject-org:api:v3:errors:UpdateConflict", "message": "Changed meanwhile."}`)
+		case id == "3" && attempt == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"_type": "Error", "message": "Too many requests."}`)
+		default:
+			fmt.Fprintf(w, `{"_type": "WorkPackage", "id": %s, "subject": "Triaged", "lockVersion": 2}`, id)
+		}
+	})
+
+	var reported []int
+	items := []BulkUpdateItem{
+		{ID: "1", Changes: &WorkPackage{Subject: "Triaged", LockVersion: 1}},
+		{ID: "2", Changes: &WorkPackage{Subject: "Triaged", LockVersion: 1}},
+		{ID: "3", Changes: &WorkPackage{Subject: "Triaged", LockVersion: 1}},
+	}
+	report, err := testClient.WorkPackage.BulkUpdate(items, &BulkOptions{
+		Concurrency: 2,
+		OnResult:    func(result BulkResult) { reported = append(reported, result.Index) },
+	})
+	if !errors.Is(err, ErrUpdateConflict) || !strings.Contains(err.Error(), "1 of 3 work packages failed") {
+		t.Errorf("Expected an update conflict, got %v", err)
+	}
+	if len(report.Results) != 3 || len(reported) != 3 {
+		t.Fatalf("Expected 3 results, got %+v, %d reported", report.Results, len(reported))
+	}
+	for i, result := range report.Results {
+		if result.Index != i || result.ID != items[i].ID {
+			t.Errorf("Unexpected result %d: %+v", i, result)
+		}
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].ID != "2" {
+		t.Errorf("Expected work package 2 to fail, got %+v", failed)
+	}
+	if wp := report.Results[2].WorkPackage; wp == nil || wp.ID != 3 || attempts["3"] != 2 {
+		t.Errorf("Expected work package 3 updated on the second attempt, got %+v after %d attempts", wp, attempts["3"])
+	}
+}
+
+func TestWorkPackageService_BulkCreate_DryRun(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/api/v3/projects/demo/work_packages/form", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		payload := new(WorkPackage)
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			t.Error(err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		validationErrors := "{}"
+		if payload.Subject == "" {
+			validationErrors = `{"subject": {"_type": "Error", "errorIdentifier": "urn:openproject-org:api:v3:errors:PropertyConstraintViolation",
+				"message": "Subject can't be blank.", "_embedded": {"details": {"attribute": "subject"}}}}`
+		}
+		fmt.Fprintf(w, `{"_type": "Form", "_embedded": {"payload": {"subject": %q, "lockVersion": 0}, "validationErrors": %s}}`,
+			payload.Subject, validationErrors)
+	})
+
+	report, err := testClient.WorkPackage.BulkCreate([]*WorkPackage{{Subject: "Valid"}, {}}, "demo", &BulkOptions{DryRun: true})
+	if err == nil {
+		t.Fatal("Expected the second work package to be invalid")
+	}
+	if wp := report.Results[0].WorkPackage; report.Results[0].Err != nil || wp == nil || wp.Subject != "Valid" {
+		t.Errorf("Unexpected result %+v", report.Results[0])
+	}
+	var opErr *Error
+	if !errors.As(report.Results[1].Err, &opErr) || !errors.Is(opErr, ErrPropertyConstraintViolation) ||
+		opErr.Attribute() != "subject" || opErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a violation of the subject, got %v", report.Results[1].Err)
+	}
+}
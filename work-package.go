@@ -1,11 +1,15 @@
 package openproject
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/trivago/tgo/tcontainer"
 
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -73,24 +77,62 @@ type WorkPackage struct {
 	Position    int            `json:"position,omitempty" structs:"position,omitempty"`
 	Custom      tcontainer.MarshalMap
 
-	Links *WPLinks `json:"_links,omitempty" _links:"id,omitempty"`
+	Embedded *WPEmbedded `json:"_embedded,omitempty" structs:"_embedded,omitempty"`
+	Links    *WPLinks    `json:"_links,omitempty" _links:"id,omitempty"`
 }
 
 // WPDescription type contains description and format
 type WPDescription OPGenericDescription
 
+// WPEmbedded wraps embedded fields of WorkPackage
+// Assignee and Responsible are principals, so they can be users, groups or placeholder users
+type WPEmbedded struct {
+	Assignee    *Principal `json:"assignee,omitempty" structs:"assignee,omitempty"`
+	Responsible *Principal `json:"responsible,omitempty" structs:"responsible,omitempty"`
+}
+
 // WPLinks are WorkPackage Links
 type WPLinks struct {
-	Self     WPLinksField `json:"self,omitempty" structs:"self,omitempty"`
-	Type     WPLinksField `json:"type,omitempty" structs:"type,omitempty"`
-	Priority WPLinksField `json:"priority,omitempty" structs:"priority,omitempty"`
-	Status   WPLinksField `json:"status,omitempty" structs:"status,omitempty"`
+	Self        WPLinksField `json:"self,omitempty" structs:"self,omitempty"`
+	Type        WPLinksField `json:"type,omitempty" structs:"type,omitempty"`
+	Priority    WPLinksField `json:"priority,omitempty" structs:"priority,omitempty"`
+	Status      WPLinksField `json:"status,omitempty" structs:"status,omitempty"`
+	Project     WPLinksField `json:"project,omitempty" structs:"project,omitempty"`
+	Assignee    WPLinksField `json:"assignee,omitempty" structs:"assignee,omitempty"`
+	Responsible WPLinksField `json:"responsible,omitempty" structs:"responsible,omitempty"`
 }
 
-// WPLinksField link and title
+// MarshalJSON renders the links as OpenProject expects them and leaves out the empty ones,
+// so that a work package sent to update only changes the links that are set.
+// Links with Clear set are rendered as {"href": null}, which removes them
+func (links WPLinks) MarshalJSON() ([]byte, error) {
+	fields := map[string]WPLinksField{
+		"self":        links.Self,
+		"type":        links.Type,
+		"priority":    links.Priority,
+		"status":      links.Status,
+		"project":     links.Project,
+		"assignee":    links.Assignee,
+		"responsible": links.Responsible,
+	}
+	rendered := make(map[string]interface{})
+	for rel, field := range fields {
+		switch {
+		case field.Clear:
+			rendered[rel] = map[string]interface{}{"href": nil}
+		case field != (WPLinksField{}):
+			rendered[rel] = OPGenericLink{Href: field.Href, Title: field.Title}
+		}
+	}
+	return json.Marshal(rendered)
+}
+
+// WPLinksField link and title.
+// Clear removes the link when updating, i.e. to unassign a work package: WPLinks{Assignee: WPLinksField{Clear: true}}
 type WPLinksField struct {
 	Href  string
 	Title string
+	Clear bool `json:"-"`
 }
 
 // WPForm represents WorkPackage form
@@ -139,25 +181,109 @@ const (
 	GreaterOrEqual SearchOperator = 6
 	// LowerOrEqual is 	'<='
 	LowerOrEqual SearchOperator = 7
+	// Not is 			'!' (list filters, i.e. status is not)
+	Not SearchOperator = 8
+	// NotLike is 		'!~'
+	NotLike SearchOperator = 9
+	// Open is 			'o' (status filter, no values)
+	Open SearchOperator = 10
+	// Closed is 		'c' (status filter, no values)
+	Closed SearchOperator = 11
+	// All is 			'*' (any value is set, no values)
+	All SearchOperator = 12
+	// None is 			'!*' (no value is set, no values)
+	None SearchOperator = 13
+	// Today is 		't' (date filters, no values)
+	Today SearchOperator = 14
+	// ThisWeek is 		'w' (date filters, no values)
+	ThisWeek SearchOperator = 15
+	// DaysAgo is 		't-' (date filters, value is a number of days)
+	DaysAgo SearchOperator = 16
+	// LessThanDaysAgo is	'>t-' (date filters, value is a number of days)
+	LessThanDaysAgo SearchOperator = 17
+	// MoreThanDaysAgo is	'<t-' (date filters, value is a number of days)
+	MoreThanDaysAgo SearchOperator = 18
+	// InDays is 		't+' (date filters, value is a number of days)
+	InDays SearchOperator = 19
+	// InLessThanDays is	'<t+' (date filters, value is a number of days)
+	InLessThanDays SearchOperator = 20
+	// InMoreThanDays is	'>t+' (date filters, value is a number of days)
+	InMoreThanDays SearchOperator = 21
+	// OnDate is 		'=d' (date filters, value is a date like "2021-01-31")
+	OnDate SearchOperator = 22
+	// BetweenDates is	'<>d' (date filters, values are two dates, either can be empty)
+	BetweenDates SearchOperator = 23
 )
 
+// operatorSymbols maps every SearchOperator to its OpenProject symbol
+var operatorSymbols = map[SearchOperator]string{
+	Equal:           "=",
+	Different:       "<>",
+	GreaterThan:     ">",
+	LowerThan:       "<",
+	SearchString:    "**",
+	Like:            "~",
+	GreaterOrEqual:  ">=",
+	LowerOrEqual:    "<=",
+	Not:             "!",
+	NotLike:         "!~",
+	Open:            "o",
+	Closed:          "c",
+	All:             "*",
+	None:            "!*",
+	Today:           "t",
+	ThisWeek:        "w",
+	DaysAgo:         "t-",
+	LessThanDaysAgo: ">t-",
+	MoreThanDaysAgo: "<t-",
+	InDays:          "t+",
+	InLessThanDays:  "<t+",
+	InMoreThanDays:  ">t+",
+	OnDate:          "=d",
+	BetweenDates:    "<>d",
+}
+
 // Constants to represent OpenProject standard GET parameters
-const paramFilters = "filters"
+const (
+	paramFilters  = "filters"
+	paramSortBy   = "sortBy"
+	paramOffset   = "offset"
+	paramPageSize = "pageSize"
+)
 
 // FilterOptions allows you to specify search parameters for the get-workpackage action
 // When used they will be converted to GET parameters within the URL
 // Up to now OpenProject only allows "AND" combinations. "OR" combinations feature is under development,
 // tracked by this ticket https://community.openproject.org/projects/openproject/work_packages/26837/activity
 // More information about filters https://docs.openproject.org/api/filters/
+// Offset is the page to return, starting at 1, and PageSize the number of elements per page.
+// Both are left to the defaults of the instance if zero
 type FilterOptions struct {
-	Fields []OptionsFields
+	Fields   []OptionsFields
+	SortBy   []SortOption
+	Offset   int
+	PageSize int
+}
+
+// SortOption sorts results by a field, i.e. {Field: "updatedAt", Descending: true}
+type SortOption struct {
+	Field      string
+	Descending bool
 }
 
 // OptionsFields array wraps field, Operator, Value within FilterOptions
+// Values is used instead of Value for operators taking several values (i.e. status is one of "1", "7")
 type OptionsFields struct {
 	Field    string
 	Operator SearchOperator
 	Value    string
+	Values   []string
+}
+
+// filterValue is the JSON representation of a single filter within the "filters" GET parameter
+type filterValue struct {
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
 }
 
 // SearchResultWP is only a small wrapper around the Search
@@ -179,6 +305,9 @@ type SearchEmbeddedWP struct {
 func (s *WorkPackageService) GetWithContext(ctx context.Context, workpackageID string) (*WorkPackage, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s", workpackageID)
 	Obj, Resp, err := GetWithContext(ctx, s, apiEndpoint)
+	if err != nil {
+		return nil, Resp, err
+	}
 	return Obj.(*WorkPackage), Resp, err
 }
 
@@ -192,31 +321,121 @@ func (s *WorkPackageService) Get(workpackageID string) (*WorkPackage, *Response,
 func (fops *FilterOptions) prepareFilters() url.Values {
 	values := make(url.Values)
 
-	filterTemplate := "["
+	filters := make([]map[string]filterValue, 0, len(fops.Fields))
 	for _, field := range fops.Fields {
-		s := fmt.Sprintf(
-			"{\"%[1]v\":{\"operator\":\"%[2]v\",\"values\":[\"%[3]v\"]}}",
-			field.Field, interpretOperator(field.Operator), field.Value)
+		filters = append(filters, map[string]filterValue{
+			field.Field: {
+				Operator: interpretOperator(field.Operator),
+				Values:   field.filterValues(),
+			},
+		})
+	}
+
+	// Operators like "<>d" must not be HTML-escaped
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(filters); err != nil {
+		return values
+	}
 
-		filterTemplate += s
+	values.Add(paramFilters, strings.TrimSpace(buf.String()))
+
+	if len(fops.SortBy) > 0 {
+		sortBy := make([][]string, 0, len(fops.SortBy))
+		for _, option := range fops.SortBy {
+			direction := "asc"
+			if option.Descending {
+				direction = "desc"
+			}
+			sortBy = append(sortBy, []string{option.Field, direction})
+		}
+		if raw, err := json.Marshal(sortBy); err == nil {
+			values.Add(paramSortBy, string(raw))
+		}
 	}
-	filterTemplate += "]"
 
-	values.Add(paramFilters, filterTemplate)
+	if fops.Offset > 0 {
+		values.Add(paramOffset, strconv.Itoa(fops.Offset))
+	}
+	if fops.PageSize > 0 {
+		values.Add(paramPageSize, strconv.Itoa(fops.PageSize))
+	}
 
 	return values
 }
 
+// filterValues returns the values of a filter field, never nil so "values" is always rendered
+func (field OptionsFields) filterValues() []string {
+	if len(field.Values) > 0 {
+		return field.Values
+	}
+	if field.Value != "" {
+		return []string{field.Value}
+	}
+	return []string{}
+}
+
 // CreateWithContext creates a work-package or a sub-task from a JSON representation.
-func (s *WorkPackageService) CreateWithContext(ctx context.Context, projectName string) (*WorkPackage, *Response, error) {
+func (s *WorkPackageService) CreateWithContext(ctx context.Context, workPackage *WorkPackage, projectName string) (*WorkPackage, *Response, error) {
 	apiEndpoint := fmt.Sprintf("api/v3/projects/%s/work_packages", projectName)
-	wpResponse, resp, err := CreateWithContext(ctx, s, apiEndpoint)
+	wpResponse, resp, err := CreateWithContext(ctx, s, apiEndpoint, workPackage)
+	if err != nil {
+		return nil, resp, err
+	}
 	return wpResponse.(*WorkPackage), resp, err
 }
 
 // Create wraps CreateWithContext using the background context.
 func (s *WorkPackageService) Create(workPackage *WorkPackage, projectName string) (*WorkPackage, *Response, error) {
-	return s.CreateWithContext(context.Background(), projectName)
+	return s.CreateWithContext(context.Background(), workPackage, projectName)
+}
+
+// UpdateWithContext updates a work-package (PATCH). Only non-empty fields of workPackage are sent,
+// so build it with the fields to be changed. LockVersion must hold the version the changes are based on.
+func (s *WorkPackageService) UpdateWithContext(ctx context.Context, workpackageID string, workPackage *WorkPackage) (*WorkPackage, *Response, error) {
+	apiEndpoint := fmt.Sprintf("api/v3/work_packages/%s", workpackageID)
+	payload, err := workPackagePatchPayload(workPackage)
+	if err != nil {
+		return nil, nil, err
+	}
+	wpResponse, resp, err := UpdateWithContext(ctx, s, apiEndpoint, payload)
+	if err != nil {
+		return nil, resp, err
+	}
+	return wpResponse.(*WorkPackage), resp, err
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *WorkPackageService) Update(workpackageID string, workPackage *WorkPackage) (*WorkPackage, *Response, error) {
+	return s.UpdateWithContext(context.Background(), workpackageID, workPackage)
+}
+
+// workPackagePatchPayload renders a work-package as PATCH body.
+// Read-only attributes are dropped, custom fields are placed at top level (i.e. "customField3")
+// and lockVersion is always rendered, since 0 is the version of a work-package never updated.
+func workPackagePatchPayload(workPackage *WorkPackage) (map[string]interface{}, error) {
+	raw, err := json.Marshal(workPackage)
+	if err != nil {
+		return nil, err
+	}
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+
+	for _, readOnly := range []string{"_type", "_embedded", "id", "createdAt", "updatedAt", "Custom"} {
+		delete(payload, readOnly)
+	}
+	if links, ok := payload["_links"].(map[string]interface{}); ok {
+		delete(links, "self")
+	}
+	for field, value := range workPackage.Custom {
+		payload[field] = value
+	}
+	payload["lockVersion"] = workPackage.LockVersion
+
+	return payload, nil
 }
 
 // GetListWithContext will retrieve a list of work-packages using filters
@@ -226,6 +445,9 @@ func (s *WorkPackageService) GetListWithContext(ctx context.Context, options *Fi
 	}
 
 	objList, resp, err := GetListWithContext(ctx, s, u.String(), options)
+	if err != nil {
+		return nil, resp, err
+	}
 	return objList.(*SearchResultWP).Embedded.Elements, resp, err
 }
 
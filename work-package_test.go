@@ -1,6 +1,7 @@
 package openproject
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -24,10 +25,17 @@ func TestWorkPackageService_Get_Success(t *testing.T) {
 	workpkg, _, err := testClient.WorkPackage.Get("36350")
 	if workpkg == nil {
 		t.Error("Expected work-package. Work-package is nil")
+		return
 	}
 	if err != nil {
 		t.Errorf("Error given: %s", err)
 	}
+	if workpkg.Embedded == nil || workpkg.Embedded.Assignee == nil || workpkg.Embedded.Assignee.User == nil {
+		t.Error("Expected embedded assignee decoded as user")
+	}
+	if workpkg.Links.Assignee.Href != "/api/v3/users/2" {
+		t.Errorf("Unexpected assignee link %+v", workpkg.Links.Assignee)
+	}
 }
 
 func TestWorkPackageService_Get_SearchListSuccess(t *testing.T) {
@@ -67,6 +75,31 @@ func TestWorkPackageService_Get_SearchListSuccess(t *testing.T) {
 	}
 }
 
+func TestFilterOptions_prepareFilters(t *testing.T) {
+	opt := &FilterOptions{
+		Fields: []OptionsFields{
+			{Field: "status", Operator: Open},
+			{Field: "type", Operator: Equal, Values: []string{"1", "2"}},
+			{Field: "createdAt", Operator: BetweenDates, Values: []string{"2021-01-01", ""}},
+		},
+	}
+
+	expected := `[{"status":{"operator":"o","values":[]}},{"type":{"operator":"=","values":["1","2"]}},{"createdAt":{"operator":"<>d","values":["2021-01-01",""]}}]`
+	if filters := opt.prepareFilters().Get(paramFilters); filters != expected {
+		t.Errorf("Unexpected filters %s", filters)
+	}
+
+	opt.SortBy = []SortOption{{Field: "updatedAt", Descending: true}, {Field: "id"}}
+	if sortBy := opt.prepareFilters().Get(paramSortBy); sortBy != `[["updatedAt","desc"],["id","asc"]]` {
+		t.Errorf("Unexpected sortBy %s", sortBy)
+	}
+
+	opt.Offset, opt.PageSize = 2, 50
+	if values := opt.prepareFilters(); values.Get(paramOffset) != "2" || values.Get(paramPageSize) != "50" {
+		t.Errorf("Unexpected pagination %s", values.Encode())
+	}
+}
+
 func TestWorkPackageService_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -117,3 +150,45 @@ func TestWorkPackageService_Delete(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	}
 }
+
+func TestWorkPackageService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	raw, err := ioutil.ReadFile("./mocks/get/get-workpackage.json")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	testMux.HandleFunc("/api/v3/work_packages/36350", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testRequestURL(t, r, "/api/v3/work_packages/36350")
+
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err.Error())
+		}
+		if body["lockVersion"] != float64(0) || body["subject"] != "Renamed" || body["customField3"] != "high" {
+			t.Errorf("Unexpected work-package payload %v", body)
+		}
+		if _, ok := body["id"]; ok {
+			t.Error("Read-only attribute id should not be sent")
+		}
+		if links := fmt.Sprint(body["_links"]); links != "map[assignee:map[href:<nil>] status:map[href:/api/v3/statuses/7]]" {
+			t.Errorf("Expected the status link and the assignee removed only, got %s", links)
+		}
+		fmt.Fprint(w, string(raw))
+	})
+
+	change := &WorkPackage{
+		ID:      36350,
+		Subject: "Renamed",
+		Custom:  map[string]interface{}{"customField3": "high"},
+		Links:   &WPLinks{Status: WPLinksField{Href: "/api/v3/statuses/7"}, Assignee: WPLinksField{Clear: true}},
+	}
+	wp, _, err := testClient.WorkPackage.Update("36350", change)
+	if wp == nil {
+		t.Error("Expected work-package. Work-package is nil")
+	}
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}